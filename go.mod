@@ -2,9 +2,10 @@ module codeberg.org/snonux/gitsyncer
 
 go 1.24.3
 
+require github.com/spf13/cobra v1.9.1
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/magefile/mage v1.15.0 // indirect
-	github.com/spf13/cobra v1.9.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
 )
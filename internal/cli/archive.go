@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+const (
+	// archivedNote prefixes the description of an archived repository
+	archivedNote = "[archived]"
+	// archiveBundleDir holds the final bundles of archived repositories
+	archiveBundleDir = ".gitsyncer-archive"
+)
+
+// HandleArchiveRepo retires a repository without deleting it: it writes a
+// final bundle of all branches and tags into the work directory, notes the
+// archival in the description and archives the repository on every forge.
+// Syncs skip the repository from then on.
+func HandleArchiveRepo(cfg *config.Config, flags *Flags, repoName string) int {
+	if repoName == "" {
+		fmt.Println("ERROR: Repository name is required")
+		return 1
+	}
+	stateManager, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load sync state: %v\n", err)
+		return 1
+	}
+	if archived, ok := syncState.RepoArchived(repoName); ok && !flags.Force {
+		fmt.Printf("%s was already archived at %s. Use --force to archive it again.\n", repoName, archived.Format("2006-01-02 15:04"))
+		return 0
+	}
+
+	bundlePath := filepath.Join(flags.WorkDir, archiveBundleDir, repoName+".bundle")
+	if flags.DryRun {
+		fmt.Printf("[DRY RUN] Would write the final bundle of %s to %s\n", repoName, bundlePath)
+	} else {
+		fmt.Printf("Writing the final bundle of %s...\n", repoName)
+		if err := sync.New(cfg, flags.WorkDir).BundleRepository(repoName, bundlePath); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			fmt.Println("Nothing was archived.")
+			return 1
+		}
+		fmt.Printf("Final bundle written to %s\n", bundlePath)
+	}
+
+	hasError := !setArchivedEverywhere(cfg, repoName, true, flags.DryRun)
+	if flags.DryRun {
+		return 0
+	}
+
+	syncState.SetRepoArchived(repoName, time.Now())
+	if err := stateManager.Save(syncState); err != nil {
+		fmt.Printf("ERROR: Failed to save sync state: %v\n", err)
+		return 1
+	}
+	updateCachedDescription(flags.WorkDir, repoName, archivedDescription)
+	if contains(cfg.Repositories, repoName) {
+		fmt.Printf("%s stays in the repositories of your configuration but is skipped while archived.\n", repoName)
+	}
+
+	if hasError {
+		fmt.Println("\n⚠️  Some forges could not be updated. Check the errors above and rerun with --force.")
+		return 1
+	}
+	fmt.Printf("\n✅ Repository '%s' has been archived.\n", repoName)
+	return 0
+}
+
+// HandleUnarchiveRepo reverses HandleArchiveRepo: it unarchives the repository
+// on every forge, removes the note from the description and lets syncs pick
+// the repository up again. The final bundle is kept.
+func HandleUnarchiveRepo(cfg *config.Config, flags *Flags, repoName string) int {
+	if repoName == "" {
+		fmt.Println("ERROR: Repository name is required")
+		return 1
+	}
+	stateManager, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load sync state: %v\n", err)
+		return 1
+	}
+
+	hasError := !setArchivedEverywhere(cfg, repoName, false, flags.DryRun)
+	if flags.DryRun {
+		return 0
+	}
+
+	syncState.ClearRepoArchived(repoName)
+	if err := stateManager.Save(syncState); err != nil {
+		fmt.Printf("ERROR: Failed to save sync state: %v\n", err)
+		return 1
+	}
+	updateCachedDescription(flags.WorkDir, repoName, unarchivedDescription)
+
+	if hasError {
+		fmt.Println("\n⚠️  Some forges could not be updated. Check the errors above and rerun.")
+		return 1
+	}
+	fmt.Printf("\n✅ Repository '%s' has been unarchived.\n", repoName)
+	return 0
+}
+
+// setArchivedEverywhere archives or unarchives a repository on all forges
+// and reports whether all of them succeeded
+func setArchivedEverywhere(cfg *config.Config, repoName string, archived, dryRun bool) bool {
+	ok := true
+	for _, f := range forge.Configured(cfg) {
+		if err := setForgeArchived(f, repoName, archived, dryRun); err != nil {
+			fmt.Printf("  %s: FAILED: %v\n", f.DisplayName(), err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// setForgeArchived archives or unarchives a repository on one forge and adds
+// or removes the description note. Archived repositories are read-only, so
+// the description is changed while the repository is writable. Forges
+// without an archived flag only get the note.
+func setForgeArchived(f forge.Forge, repoName string, archived, dryRun bool) error {
+	name := f.DisplayName()
+	repo, exists, err := f.GetRepo(repoName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	description := unarchivedDescription(repo.Description)
+	action := "unarchive"
+	if archived {
+		description = archivedDescription(repo.Description)
+		action = "archive"
+	}
+	setFlag := f.SupportsSetting(config.SettingArchived) && repo.Archived != archived
+	setDescription := description != strings.TrimSpace(repo.Description)
+	if !setFlag && !setDescription {
+		fmt.Printf("  %s: already %sd\n", name, action)
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("  [DRY RUN] Would %s %s on %s\n", action, repoName, name)
+		return nil
+	}
+	if !f.HasToken() {
+		return fmt.Errorf("no %s token", name)
+	}
+
+	updateDescription := func() error {
+		if !setDescription {
+			return nil
+		}
+		return f.UpdateDescription(repoName, description)
+	}
+	updateFlag := func() error {
+		if !setFlag {
+			return nil
+		}
+		return f.UpdateSettings(repoName, forge.RepoSettings{Archived: &archived})
+	}
+	steps := []func() error{updateFlag, updateDescription}
+	if archived {
+		steps = []func() error{updateDescription, updateFlag}
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("  %s: %sd\n", name, action)
+	return nil
+}
+
+// archivedDescription adds the archived note to a description
+func archivedDescription(description string) string {
+	description = strings.TrimSpace(description)
+	switch {
+	case strings.HasPrefix(description, archivedNote):
+		return description
+	case description == "":
+		return archivedNote
+	default:
+		return archivedNote + " " + description
+	}
+}
+
+// unarchivedDescription removes the archived note from a description
+func unarchivedDescription(description string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(description), archivedNote))
+}
+
+// updateCachedDescription keeps the cached canonical description in line
+// with the descriptions just written to the forges
+func updateCachedDescription(workDir, repoName string, update func(string) string) {
+	cache := loadDescriptionCache(workDir)
+	description, ok := cache[repoName]
+	if !ok {
+		return
+	}
+	cache[repoName] = update(description)
+	if err := saveDescriptionCache(workDir, cache); err != nil {
+		fmt.Printf("Warning: Failed to save descriptions cache: %v\n", err)
+	}
+}
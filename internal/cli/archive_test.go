@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+)
+
+// archiveForge is a stub forge recording description and archived updates
+type archiveForge struct {
+	forge.Forge
+	repo          *forge.Repository
+	canArchive    bool
+	calls         []string
+	archivedWrite bool // a description was written while archived
+}
+
+func (f *archiveForge) DisplayName() string { return "Stub" }
+func (f *archiveForge) HasToken() bool      { return true }
+
+func (f *archiveForge) GetRepo(repoName string) (forge.Repository, bool, error) {
+	if f.repo == nil {
+		return forge.Repository{}, false, nil
+	}
+	return *f.repo, true, nil
+}
+
+func (f *archiveForge) SupportsSetting(setting string) bool {
+	return setting != config.SettingArchived || f.canArchive
+}
+
+func (f *archiveForge) UpdateDescription(repoName, description string) error {
+	f.calls = append(f.calls, "description")
+	f.archivedWrite = f.archivedWrite || f.repo.Archived
+	f.repo.Description = description
+	return nil
+}
+
+func (f *archiveForge) UpdateSettings(repoName string, settings forge.RepoSettings) error {
+	f.calls = append(f.calls, "archived")
+	f.repo.Archived = *settings.Archived
+	return nil
+}
+
+func TestSetForgeArchived_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	f := &archiveForge{repo: &forge.Repository{Description: "A tool"}, canArchive: true}
+	if err := setForgeArchived(f, "tool", true, false); err != nil {
+		t.Fatalf("archive error = %v", err)
+	}
+	if !f.repo.Archived || f.repo.Description != "[archived] A tool" {
+		t.Fatalf("archived repo = %+v", f.repo)
+	}
+	if err := setForgeArchived(f, "tool", true, false); err != nil {
+		t.Fatalf("second archive error = %v", err)
+	}
+	if err := setForgeArchived(f, "tool", false, false); err != nil {
+		t.Fatalf("unarchive error = %v", err)
+	}
+	if f.repo.Archived || f.repo.Description != "A tool" {
+		t.Fatalf("unarchived repo = %+v", f.repo)
+	}
+	if got := strings.Join(f.calls, ","); got != "description,archived,archived,description" {
+		t.Fatalf("calls = %q", got)
+	}
+	if f.archivedWrite {
+		t.Fatal("description must not be written while the repository is archived")
+	}
+}
+
+func TestSetForgeArchived_NoteOnlyAndMissing(t *testing.T) {
+	t.Parallel()
+
+	noFlag := &archiveForge{repo: &forge.Repository{}}
+	if err := setForgeArchived(noFlag, "tool", true, false); err != nil {
+		t.Fatalf("archive error = %v", err)
+	}
+	if got := strings.Join(noFlag.calls, ","); got != "description" || noFlag.repo.Description != archivedNote {
+		t.Fatalf("calls = %q, description = %q", got, noFlag.repo.Description)
+	}
+
+	missing := &archiveForge{canArchive: true}
+	if err := setForgeArchived(missing, "tool", true, false); err != nil || len(missing.calls) != 0 {
+		t.Fatalf("missing repo: err = %v, calls = %v", err, missing.calls)
+	}
+
+	dryRun := &archiveForge{repo: &forge.Repository{Description: "A tool"}, canArchive: true}
+	if err := setForgeArchived(dryRun, "tool", true, true); err != nil || len(dryRun.calls) != 0 {
+		t.Fatalf("dry run: err = %v, calls = %v", err, dryRun.calls)
+	}
+}
+
+func TestEvaluateSyncPolicy_SkipsArchivedRepoEvenWithForce(t *testing.T) {
+	t.Parallel()
+
+	st := &state.State{}
+	st.SetRepoArchived("repo", time.Now().Add(-48*time.Hour))
+
+	decision := evaluateSyncPolicy("repo", st, false, true, false)
+	if !decision.Skip {
+		t.Fatal("expected archived repo to be skipped")
+	}
+	if !strings.Contains(decision.Message, "manage unarchive-repo repo") {
+		t.Fatalf("expected unarchive hint, got %q", decision.Message)
+	}
+
+	st.ClearRepoArchived("repo")
+	if decision := evaluateSyncPolicy("repo", st, false, true, false); decision.Skip {
+		t.Fatalf("expected unarchived repo to sync, got %q", decision.Message)
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// auditLogFile collects destructive operations, one JSON object per line
+const auditLogFile = ".gitsyncer-audit.log"
+
+// auditEntry records one destructive operation on one location
+type auditEntry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Repo     string    `json:"repo"`
+	Location string    `json:"location,omitempty"`
+	Bundle   string    `json:"bundle,omitempty"` // bundle taken before the operation
+	Error    string    `json:"error,omitempty"`
+}
+
+// appendAuditLog appends an entry to the audit log in the work directory
+func appendAuditLog(workDir string, entry auditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(workDir, auditLogFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
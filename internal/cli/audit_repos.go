@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// Kinds of repository mismatches found by the audit
+const (
+	auditBackupOnly   = "backup-only"
+	auditNowhere      = "nowhere"
+	auditForkOnly     = "fork-only"
+	auditArchived     = "archived"
+	auditOrphanMirror = "orphaned-mirror"
+	auditMissing      = "missing"
+	auditNoBackup     = "missing-backup"
+)
+
+// auditLocation is one configured organization and the repositories on it.
+// Backup and plain git locations only report names, so their repositories
+// carry nothing but the name.
+type auditLocation struct {
+	label   string
+	org     *config.Organization
+	isForge bool
+	repos   map[string]forge.Repository
+	err     error
+}
+
+// repoFinding is a mismatch of one repository across the locations
+type repoFinding struct {
+	repo       string
+	kind       string
+	detail     string
+	suggestion string
+}
+
+// HandleAuditRepos lists the repositories of every configured organization,
+// builds the matrix of which repository exists where, and reports every
+// mismatch together with a suggested action. Forks, archived repositories
+// and pull mirrors are listed too, as those are exactly what sync skips.
+// Exits non-zero if any mismatch is found.
+func HandleAuditRepos(cfg *config.Config, flags *Flags, showAll bool) int {
+	if len(cfg.Organizations) == 0 {
+		fmt.Println("No organizations configured.")
+		return 1
+	}
+
+	fmt.Println("Listing repositories...")
+	locations := listAuditLocations(cfg)
+	_, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
+	}
+
+	names := auditRepoNames(cfg, locations)
+	findings := auditRepos(syncState, locations, names)
+
+	printAuditMatrix(locations, names, findings, showAll)
+	if len(findings) == 0 {
+		fmt.Println("\n✅ Every repository exists on every location.")
+	} else {
+		fmt.Printf("\n%d mismatches:\n", len(findings))
+		for _, finding := range findings {
+			fmt.Printf("  %s [%s]: %s\n", finding.repo, finding.kind, finding.detail)
+			fmt.Printf("    → %s\n", finding.suggestion)
+		}
+	}
+
+	failed := false
+	for _, loc := range locations {
+		if loc.err != nil {
+			fmt.Printf("\n❌ Could not list %s: %v\n", loc.label, loc.err)
+			failed = true
+		}
+	}
+	if failed || len(findings) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// listAuditLocations lists the repositories of every organization, using the
+// forge API where there is one and the directory listing otherwise
+func listAuditLocations(cfg *config.Config) []auditLocation {
+	var locations []auditLocation
+	for i := range cfg.Organizations {
+		org := &cfg.Organizations[i]
+		loc := auditLocation{label: org.Host, org: org, repos: make(map[string]forge.Repository)}
+		if f, err := forge.New(org); err == nil {
+			loc.label = org.GetGitURL()
+			loc.isForge = true
+			var repos []forge.Repository
+			if repos, loc.err = f.ListAllRepos(f.HasToken()); loc.err == nil {
+				for _, repo := range repos {
+					loc.repos[repo.Name] = repo
+				}
+			}
+		} else {
+			var names []string
+			if names, loc.err = sync.ListBackupRepositories(org); loc.err == nil {
+				for _, name := range names {
+					loc.repos[name] = forge.Repository{Name: name}
+				}
+			}
+		}
+		locations = append(locations, loc)
+	}
+	return locations
+}
+
+// auditRepoNames returns the configured repositories and all repositories
+// found on any location, sorted
+func auditRepoNames(cfg *config.Config, locations []auditLocation) []string {
+	seen := make(map[string]bool)
+	for _, name := range cfg.Repositories {
+		seen[name] = true
+	}
+	for _, loc := range locations {
+		for name := range loc.repos {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// auditRepos classifies the mismatch of every repository, if any. Locations
+// that could not be listed are left out, so that a failing API does not make
+// every repository look missing.
+func auditRepos(st *state.State, locations []auditLocation, names []string) []repoFinding {
+	var findings []repoFinding
+	for _, name := range names {
+		if finding, ok := auditRepo(st, locations, name); ok {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func auditRepo(st *state.State, locations []auditLocation, name string) (repoFinding, bool) {
+	var active, forks, archived, mirrors, missing, backups, noBackup []string
+	var sources []string
+	private := false
+	_, archivedByUs := st.RepoArchived(name)
+	for _, loc := range locations {
+		if loc.err != nil {
+			continue
+		}
+		repo, exists := loc.repos[name]
+		switch {
+		case loc.org.BackupLocation && exists:
+			backups = append(backups, loc.label)
+		case loc.org.BackupLocation:
+			noBackup = append(noBackup, loc.label)
+		case !exists:
+			missing = append(missing, loc.label)
+		case repo.Fork:
+			forks = append(forks, loc.label)
+		case repo.Archived && !archivedByUs:
+			archived = append(archived, loc.label)
+		case repo.Mirror:
+			mirrors = append(mirrors, loc.label)
+			sources = append(sources, repo.MirrorURL)
+		default:
+			active = append(active, loc.label)
+			private = private || repo.Private
+		}
+	}
+
+	finding := repoFinding{repo: name}
+	switch {
+	case len(active)+len(forks)+len(archived)+len(mirrors) == 0 && len(backups) > 0:
+		finding.kind = auditBackupOnly
+		finding.detail = fmt.Sprintf("only on backup locations %s, every forge copy is gone", strings.Join(backups, ", "))
+		if len(missing) > 0 {
+			finding.suggestion = fmt.Sprintf("restore it with 'gitsyncer backup restore %s --from %s --to %s --create-repos', or delete it with 'gitsyncer manage delete-repo %s'", name, backups[0], missing[0], name)
+		} else {
+			finding.suggestion = fmt.Sprintf("delete it with 'gitsyncer manage delete-repo %s' if it is no longer needed", name)
+		}
+	case len(active)+len(forks)+len(archived)+len(mirrors)+len(backups) == 0:
+		finding.kind = auditNowhere
+		finding.detail = "configured but not found on any location"
+		finding.suggestion = "remove it from the configured repositories, or create it on a forge"
+	case len(active)+len(archived)+len(mirrors) == 0:
+		finding.kind = auditForkOnly
+		finding.detail = fmt.Sprintf("only a fork on %s, forks are never synced", strings.Join(forks, ", "))
+		finding.suggestion = "delete the fork if it is no longer needed, otherwise nothing to do"
+	case len(archived) > 0:
+		finding.kind = auditArchived
+		finding.detail = fmt.Sprintf("archived on %s but not synced there any more", strings.Join(archived, ", "))
+		finding.suggestion = fmt.Sprintf("archive it everywhere with 'gitsyncer manage archive-repo %s', or unarchive it on %s to sync it again", name, archived[0])
+	case len(active) == 0 && len(mirrors) > 0:
+		finding.kind = auditOrphanMirror
+		finding.detail = fmt.Sprintf("pull mirror on %s of %s, which no longer exists", strings.Join(mirrors, ", "), strings.Join(sources, ", "))
+		finding.suggestion = "convert the mirror into a regular repository on the forge, or delete it"
+	case len(missing) > 0:
+		finding.kind = auditMissing
+		finding.detail = fmt.Sprintf("missing on %s", strings.Join(missing, ", "))
+		finding.suggestion = fmt.Sprintf("create the missing copies with 'gitsyncer sync repo %s --create-repos'", name)
+		if private {
+			finding.detail += " (private)"
+			finding.suggestion = fmt.Sprintf("create the missing copies with 'gitsyncer sync repo %s --create-repos --include-private'", name)
+		}
+		if archivedByUs {
+			finding.suggestion = fmt.Sprintf("sync skips archived repositories; run 'gitsyncer manage unarchive-repo %s' first to recreate the missing copies", name)
+		}
+	case len(noBackup) > 0:
+		finding.kind = auditNoBackup
+		finding.detail = fmt.Sprintf("not backed up to %s", strings.Join(noBackup, ", "))
+		finding.suggestion = fmt.Sprintf("back it up with 'gitsyncer sync repo %s --backup'", name)
+	default:
+		return repoFinding{}, false
+	}
+	return finding, true
+}
+
+// printAuditMatrix prints the repository × location matrix. Only repositories
+// with a mismatch are shown unless showAll is set.
+func printAuditMatrix(locations []auditLocation, names []string, findings []repoFinding, showAll bool) {
+	mismatched := make(map[string]bool)
+	for _, finding := range findings {
+		mismatched[finding.repo] = true
+	}
+
+	fmt.Println("\nLocations:")
+	for i, loc := range locations {
+		kind := "forge"
+		switch {
+		case loc.org.BackupLocation:
+			kind = "backup"
+		case !loc.isForge:
+			kind = "git"
+		}
+		fmt.Printf("  [%d] %s (%s, %d repositories)\n", i+1, loc.label, kind, len(loc.repos))
+	}
+
+	width := len("Repository")
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	fmt.Printf("\n%-*s", width, "Repository")
+	for i := range locations {
+		fmt.Printf("  %-8s", fmt.Sprintf("[%d]", i+1))
+	}
+	fmt.Println()
+	for _, name := range names {
+		if !showAll && !mismatched[name] {
+			continue
+		}
+		fmt.Printf("%-*s", width, name)
+		for _, loc := range locations {
+			fmt.Printf("  %-8s", auditCell(loc, name))
+		}
+		fmt.Println()
+	}
+	fmt.Println("\n✓ present, - missing, fork/archived/mirror as on the forge, ? not listed")
+}
+
+func auditCell(loc auditLocation, name string) string {
+	if loc.err != nil {
+		return "?"
+	}
+	repo, exists := loc.repos[name]
+	switch {
+	case !exists:
+		return "-"
+	case repo.Fork:
+		return "fork"
+	case repo.Archived:
+		return "archived"
+	case repo.Mirror:
+		return "mirror"
+	}
+	return "✓"
+}
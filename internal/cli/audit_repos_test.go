@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+)
+
+func TestAuditRepo_ClassifiesMismatches(t *testing.T) {
+	github := auditLocation{label: "github", org: &config.Organization{}, repos: map[string]forge.Repository{
+		"synced":   {Name: "synced"},
+		"half":     {Name: "half"},
+		"upstream": {Name: "upstream", Fork: true},
+		"old":      {Name: "old", Archived: true},
+		"retired":  {Name: "retired", Archived: true},
+		"secret":   {Name: "secret", Private: true},
+	}}
+	codeberg := auditLocation{label: "codeberg", org: &config.Organization{}, repos: map[string]forge.Repository{
+		"synced":  {Name: "synced"},
+		"old":     {Name: "old"},
+		"retired": {Name: "retired", Archived: true},
+		"mirror":  {Name: "mirror", Mirror: true, MirrorURL: "https://github.com/me/mirror.git"},
+	}}
+	backup := auditLocation{label: "nas", org: &config.Organization{BackupLocation: true}, repos: map[string]forge.Repository{
+		"synced":  {Name: "synced"},
+		"old":     {Name: "old"},
+		"retired": {Name: "retired"},
+		"gone":    {Name: "gone"},
+	}}
+	locations := []auditLocation{github, codeberg, backup}
+	st := &state.State{}
+	st.SetRepoArchived("retired", time.Now())
+
+	tests := map[string]string{
+		"synced":     "",
+		"retired":    "",
+		"half":       auditMissing,
+		"secret":     auditMissing,
+		"upstream":   auditForkOnly,
+		"old":        auditArchived,
+		"mirror":     auditOrphanMirror,
+		"gone":       auditBackupOnly,
+		"configured": auditNowhere,
+	}
+	for name, want := range tests {
+		finding, ok := auditRepo(st, locations, name)
+		if !ok {
+			finding.kind = ""
+		}
+		if finding.kind != want {
+			t.Errorf("auditRepo(%s) = %q (%s), want %q", name, finding.kind, finding.detail, want)
+		}
+	}
+}
+
+func TestAuditRepo_IgnoresUnlistedLocations(t *testing.T) {
+	locations := []auditLocation{
+		{label: "github", org: &config.Organization{}, repos: map[string]forge.Repository{"tool": {Name: "tool"}}},
+		{label: "codeberg", org: &config.Organization{}, repos: map[string]forge.Repository{}, err: os.ErrPermission},
+	}
+	if finding, ok := auditRepo(nil, locations, "tool"); ok {
+		t.Fatalf("unexpected finding %+v", finding)
+	}
+}
+
+func TestListAuditLocations_BackupDirectory(t *testing.T) {
+	root := t.TempDir()
+	gitRun(t, "", "init", "-q", "--bare", filepath.Join(root, "tool.git"))
+	cfg := &config.Config{Organizations: []config.Organization{{Host: "file://" + root, BackupLocation: true}}}
+
+	locations := listAuditLocations(cfg)
+	if len(locations) != 1 || locations[0].err != nil {
+		t.Fatalf("listAuditLocations() = %+v", locations)
+	}
+	if _, ok := locations[0].repos["tool"]; !ok || len(locations[0].repos) != 1 {
+		t.Fatalf("repos = %v", locations[0].repos)
+	}
+	if got := auditCell(locations[0], "tool"); got != "✓" {
+		t.Fatalf("auditCell() = %q", got)
+	}
+}
@@ -0,0 +1,315 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// RestoreOptions holds the arguments of `backup restore`
+type RestoreOptions struct {
+	RepoName    string
+	From        string // Backup organization host
+	To          string // Target organization host
+	All         bool   // Restore every repository found at the backup location
+	CreateRepos bool   // Create missing target repositories via the forge API
+}
+
+// findOrganizationRef finds an organization by host or full git URL
+func findOrganizationRef(cfg *config.Config, ref string) *config.Organization {
+	for i := range cfg.Organizations {
+		org := &cfg.Organizations[i]
+		if org.Host == ref || org.GetGitURL() == ref {
+			return org
+		}
+	}
+	return nil
+}
+
+// HandleBackupRestore restores one or all repositories from a backup location
+func HandleBackupRestore(cfg *config.Config, flags *Flags, opts RestoreOptions) int {
+	backupOrg := findOrganizationRef(cfg, opts.From)
+	if backupOrg == nil {
+		fmt.Printf("ERROR: No organization matches --from %q\n", opts.From)
+		return 1
+	}
+	if !backupOrg.BackupLocation {
+		fmt.Printf("ERROR: %s is not a backup location\n", backupOrg.Host)
+		return 1
+	}
+
+	targetOrg := findOrganizationRef(cfg, opts.To)
+	if targetOrg == nil {
+		fmt.Printf("ERROR: No organization matches --to %q\n", opts.To)
+		return 1
+	}
+	if targetOrg.IsS3() {
+		fmt.Println("ERROR: Cannot restore into an object storage backup location")
+		return 1
+	}
+
+	var repoNames []string
+	if opts.All {
+		fmt.Printf("Listing repositories on %s...\n", backupOrg.Host)
+		names, err := sync.ListBackupRepositories(backupOrg)
+		if err != nil {
+			fmt.Printf("ERROR: Failed to list backup repositories: %v\n", err)
+			return 1
+		}
+		repoNames = names
+	} else {
+		if opts.RepoName == "" {
+			fmt.Println("Error: Repository name is required unless --all is given")
+			return 1
+		}
+		repoNames = []string{opts.RepoName}
+	}
+
+	if len(repoNames) == 0 {
+		fmt.Printf("No repositories found on %s\n", backupOrg.Host)
+		return 0
+	}
+
+	if flags.DryRun {
+		fmt.Printf("\n[DRY RUN] Would restore %d repositories from %s to %s:\n", len(repoNames), backupOrg.Host, targetOrg.GetGitURL())
+		for _, name := range repoNames {
+			fmt.Printf("  - %s\n", name)
+		}
+		if opts.CreateRepos {
+			fmt.Println("Would create missing target repositories")
+		}
+		return 0
+	}
+
+	descCache := loadDescriptionCache(flags.WorkDir)
+	var failed []string
+
+	for i, name := range repoNames {
+		fmt.Printf("\n[%d/%d] Restoring %s...\n", i+1, len(repoNames), name)
+
+		if opts.CreateRepos {
+			if err := createRestoreTarget(targetOrg, name, descCache[name]); err != nil {
+				fmt.Printf("ERROR: Failed to create %s on %s: %v\n", name, targetOrg.Host, err)
+				failed = append(failed, name)
+				continue
+			}
+		}
+
+		if err := sync.RestoreRepository(backupOrg, targetOrg, name); err != nil {
+			fmt.Printf("ERROR: Failed to restore %s: %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		fmt.Printf("  Restored %s\n", name)
+	}
+
+	fmt.Printf("\n=== Summary ===\n")
+	fmt.Printf("Restored: %d repositories\n", len(repoNames)-len(failed))
+	if len(failed) > 0 {
+		fmt.Printf("Failed: %d repositories\n", len(failed))
+		for _, name := range failed {
+			fmt.Printf("  - %s\n", name)
+		}
+		return 1
+	}
+	return 0
+}
+
+// createRestoreTarget creates the target repository on a forge if it is missing
+func createRestoreTarget(org *config.Organization, repoName, description string) error {
+	if description == "" {
+		description = fmt.Sprintf("Mirror of %s", repoName)
+	}
+
+	f, err := forge.New(org)
+	if err != nil {
+		// SSH and file targets are created on demand while pushing
+		return nil
+	}
+	return f.CreateRepo(repoName, description, false)
+}
+
+// VerifyOptions holds the arguments of `backup verify`
+type VerifyOptions struct {
+	RepoName string // Verify only this repository; all work-dir clones when empty
+	Fsck     bool   // Run git fsck --connectivity-only on the backup copies
+}
+
+// HandleBackupVerify checks every backup location against the work-dir refs
+func HandleBackupVerify(cfg *config.Config, flags *Flags, opts VerifyOptions) int {
+	backupOrgs := backupOrganizations(cfg)
+	if len(backupOrgs) == 0 {
+		fmt.Println("No backup locations configured")
+		return 1
+	}
+
+	repoNames := []string{opts.RepoName}
+	if opts.RepoName == "" {
+		names, err := workDirRepositories(flags.WorkDir)
+		if err != nil {
+			fmt.Printf("Error reading work directory %s: %v\n", flags.WorkDir, err)
+			return 1
+		}
+		repoNames = names
+	}
+	if len(repoNames) == 0 {
+		fmt.Println("No repositories found in work directory")
+		return 1
+	}
+
+	stateManager, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
+	}
+
+	var problems []sync.BackupCheck
+	okCount := 0
+	for _, repoName := range repoNames {
+		repoPath := filepath.Join(flags.WorkDir, repoName)
+		for _, org := range backupOrgs {
+			check := sync.VerifyBackup(org, repoName, repoPath, opts.Fsck)
+			if check.Health != sync.BackupOK {
+				problems = append(problems, check)
+				continue
+			}
+			okCount++
+			fmt.Printf("  ✅ %s on %s\n", repoName, org.Host)
+			if !flags.DryRun {
+				syncState.SetBackupVerified(repoName, org.Host, time.Now())
+			}
+		}
+	}
+
+	if stateManager != nil && !flags.DryRun && okCount > 0 {
+		if err := stateManager.Save(syncState); err != nil {
+			fmt.Printf("Warning: Failed to save sync state: %v\n", err)
+		}
+	}
+
+	fmt.Printf("\n=== Backup Verification ===\n")
+	fmt.Printf("Healthy: %d\n", okCount)
+	if len(problems) == 0 {
+		return 0
+	}
+
+	fmt.Printf("Problems: %d\n", len(problems))
+	for _, check := range problems {
+		lastVerified := "never verified"
+		if t := syncState.GetBackupRecord(check.Repo, check.Location).LastVerified; !t.IsZero() {
+			lastVerified = "last verified " + t.Format("2006-01-02 15:04")
+		}
+
+		switch check.Health {
+		case sync.BackupMissing:
+			fmt.Printf("  ⬜ %s on %s: MISSING (%s)\n", check.Repo, check.Location, lastVerified)
+		case sync.BackupStale:
+			fmt.Printf("  ⚠️  %s on %s: STALE, %d refs behind (%s)\n", check.Repo, check.Location, len(check.Outdated), lastVerified)
+			for _, ref := range check.Outdated {
+				fmt.Printf("       - %s\n", ref)
+			}
+		default:
+			fmt.Printf("  ❌ %s on %s: ERROR %v (%s)\n", check.Repo, check.Location, check.Err, lastVerified)
+		}
+	}
+	return 1
+}
+
+// backupOrganizations returns all organizations marked as backup locations
+func backupOrganizations(cfg *config.Config) []*config.Organization {
+	var orgs []*config.Organization
+	for i := range cfg.Organizations {
+		if cfg.Organizations[i].BackupLocation {
+			orgs = append(orgs, &cfg.Organizations[i])
+		}
+	}
+	return orgs
+}
+
+// recordBackupPushes stores the backup pushes of a synced repository in the state
+func recordBackupPushes(repoName string, st *state.State, pushes []sync.BackupPush) {
+	for _, push := range pushes {
+		st.SetBackupPush(repoName, push.Location, push.PushedAt, push.Refs)
+	}
+}
+
+// staleBackup is a repository whose last push to a backup location is too old
+type staleBackup struct {
+	Repo     string
+	Location string
+	LastPush time.Time // Zero if the repository was never pushed there
+}
+
+// findStaleBackups returns every synced repository whose last push to one of
+// the backup locations is older than maxAge or missing entirely
+func findStaleBackups(st *state.State, locations []string, maxAge time.Duration, now time.Time) []staleBackup {
+	repos := make(map[string]bool)
+	for repo := range st.LastRepoSync {
+		repos[repo] = true
+	}
+	for repo := range st.Backups {
+		repos[repo] = true
+	}
+
+	names := make([]string, 0, len(repos))
+	for repo := range repos {
+		names = append(names, repo)
+	}
+	sort.Strings(names)
+
+	var stale []staleBackup
+	for _, repo := range names {
+		for _, location := range locations {
+			lastPush := st.GetBackupRecord(repo, location).LastPush
+			if lastPush.IsZero() || now.Sub(lastPush) > maxAge {
+				stale = append(stale, staleBackup{Repo: repo, Location: location, LastPush: lastPush})
+			}
+		}
+	}
+	return stale
+}
+
+// HandleBackupReport lists backups whose last recorded push is older than maxAge
+func HandleBackupReport(cfg *config.Config, flags *Flags, maxAge time.Duration) int {
+	backupOrgs := backupOrganizations(cfg)
+	if len(backupOrgs) == 0 {
+		fmt.Println("No backup locations configured")
+		return 1
+	}
+
+	_, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Error: Failed to load sync state: %v\n", err)
+		return 1
+	}
+
+	locations := make([]string, 0, len(backupOrgs))
+	for _, org := range backupOrgs {
+		locations = append(locations, org.Host)
+	}
+
+	now := time.Now()
+	stale := findStaleBackups(syncState, locations, maxAge, now)
+
+	fmt.Printf("=== Backup Freshness (max age %s) ===\n", maxAge)
+	if len(stale) == 0 {
+		fmt.Println("All backups are fresh")
+		return 0
+	}
+
+	for _, entry := range stale {
+		if entry.LastPush.IsZero() {
+			fmt.Printf("  ⬜ %s on %s: never pushed\n", entry.Repo, entry.Location)
+			continue
+		}
+		age := now.Sub(entry.LastPush).Round(time.Hour)
+		fmt.Printf("  ⚠️  %s on %s: last pushed %s (%s ago)\n", entry.Repo, entry.Location, entry.LastPush.Format("2006-01-02 15:04"), age)
+	}
+	fmt.Printf("\nStale: %d\n", len(stale))
+	return 1
+}
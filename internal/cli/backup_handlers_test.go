@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/state"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+func TestFindStaleBackups_ReportsOldAndMissingPushes(t *testing.T) {
+	now := time.Now()
+	st := &state.State{}
+	st.SetLastRepoSync("fresh", now)
+	st.SetLastRepoSync("old", now)
+	st.SetLastRepoSync("never", now)
+
+	recordBackupPushes("fresh", st, []sync.BackupPush{{Location: "nas:git", Refs: map[string]string{"refs/heads/main": "abc"}, PushedAt: now.Add(-time.Hour)}})
+	recordBackupPushes("old", st, []sync.BackupPush{{Location: "nas:git", PushedAt: now.Add(-10 * 24 * time.Hour)}})
+
+	if got := st.GetBackupRecord("fresh", "nas:git").Refs["refs/heads/main"]; got != "abc" {
+		t.Fatalf("expected pushed refs to be recorded, got %q", got)
+	}
+
+	stale := findStaleBackups(st, []string{"nas:git"}, 7*24*time.Hour, now)
+	if len(stale) != 2 {
+		t.Fatalf("expected 2 stale backups, got %#v", stale)
+	}
+	if stale[0].Repo != "never" || !stale[0].LastPush.IsZero() {
+		t.Fatalf("expected never-pushed repo first, got %#v", stale[0])
+	}
+	if stale[1].Repo != "old" {
+		t.Fatalf("expected old repo to be stale, got %#v", stale[1])
+	}
+}
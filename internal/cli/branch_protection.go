@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// protectionChange is a branch protection rule to apply on one forge
+type protectionChange struct {
+	current *forge.BranchProtection // nil if the branch is not protected yet
+	rule    forge.BranchProtection
+	lost    []string // options of the source rule the forge cannot enforce
+}
+
+// syncBranchProtection mirrors the branch protection rules of the source forge
+// to all other forges that have the repository. Only required reviews, force
+// pushes and branch deletion are mirrored; everything else is reported. Rules
+// that exist only on a target forge are left alone. Does nothing unless
+// branch_protection_sync is configured.
+func syncBranchProtection(cfg *config.Config, dryRun bool, repoName string) {
+	if cfg.BranchProtectionSync == nil {
+		return
+	}
+
+	source := protectionSource(cfg)
+	if source == nil {
+		fmt.Println("  Warning: No forge with branch protection configured")
+		return
+	}
+	if !source.HasToken() {
+		fmt.Printf("  Warning: No %s token; cannot read branch protection\n", source.DisplayName())
+		return
+	}
+	rules, err := source.ListBranchProtections(repoName)
+	if err != nil {
+		fmt.Printf("  Warning: Failed to read %s branch protection: %v\n", source.DisplayName(), err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	for _, f := range forge.Configured(cfg) {
+		if f.Organization() == source.Organization() {
+			continue
+		}
+		name := f.DisplayName()
+		target, ok := f.(forge.BranchProtector)
+		if !ok {
+			fmt.Printf("  %s has no branch protection; not mirrored: %s\n", name, branchList(rules))
+			continue
+		}
+		if exists, err := target.RepoExists(repoName); err != nil || !exists {
+			continue
+		}
+		if !target.HasToken() {
+			fmt.Printf("  Warning: No %s token; cannot mirror branch protection\n", name)
+			continue
+		}
+		current, err := target.ListBranchProtections(repoName)
+		if err != nil {
+			fmt.Printf("  Warning: Failed to read %s branch protection: %v\n", name, err)
+			continue
+		}
+
+		for _, change := range planProtectionChanges(rules, target, current) {
+			branch := change.rule.Branch
+			diff := describeProtectionDiff(change.current, change.rule)
+			if dryRun {
+				fmt.Printf("  [DRY RUN] Would protect %s on %s for %s: %s\n", branch, name, repoName, diff)
+			} else if err := target.SetBranchProtection(repoName, change.rule); err != nil {
+				fmt.Printf("  Warning: Failed to protect %s on %s: %v\n", branch, name, err)
+				continue
+			} else {
+				fmt.Printf("  Protected %s on %s for %s: %s\n", branch, name, repoName, diff)
+			}
+			if len(change.lost) > 0 {
+				fmt.Printf("    Not mirrored to %s: %s\n", name, strings.Join(change.lost, ", "))
+			}
+		}
+	}
+}
+
+// protectionSource returns the forge whose rules are mirrored: the configured
+// source type, or the first organization whose forge supports branch protection
+func protectionSource(cfg *config.Config) forge.BranchProtector {
+	for i := range cfg.Organizations {
+		org := &cfg.Organizations[i]
+		if org.BackupLocation {
+			continue
+		}
+		f, err := forge.New(org)
+		if err != nil {
+			continue
+		}
+		protector, ok := f.(forge.BranchProtector)
+		if !ok {
+			continue
+		}
+		if source := cfg.BranchProtectionSync.Source; source == "" || f.Type() == source {
+			return protector
+		}
+	}
+	return nil
+}
+
+// planProtectionChanges translates the source rules for a target forge and
+// returns those that differ from the target's current rules
+func planProtectionChanges(rules []forge.BranchProtection, target forge.BranchProtector, current []forge.BranchProtection) []protectionChange {
+	existing := make(map[string]forge.BranchProtection, len(current))
+	for _, rule := range current {
+		existing[rule.Branch] = rule
+	}
+
+	var changes []protectionChange
+	for _, rule := range rules {
+		translated, lost := target.TranslateBranchProtection(rule)
+		translated.Other = nil
+		lost = append(lost, rule.Other...)
+
+		change := protectionChange{rule: translated, lost: lost}
+		if have, ok := existing[rule.Branch]; ok {
+			if have.Equal(translated) {
+				continue
+			}
+			change.current = &have
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].rule.Branch < changes[j].rule.Branch })
+	return changes
+}
+
+// describeProtectionDiff summarizes how a rule changes, e.g.
+// "required reviews 0 -> 1, force push allowed -> blocked"
+func describeProtectionDiff(current *forge.BranchProtection, rule forge.BranchProtection) string {
+	from := forge.BranchProtection{AllowForcePush: true, AllowDeletion: true}
+	prefix := "new rule: "
+	if current != nil {
+		from = *current
+		prefix = ""
+	}
+
+	var parts []string
+	if from.RequiredReviews != rule.RequiredReviews || current == nil {
+		parts = append(parts, fmt.Sprintf("required reviews %d -> %d", from.RequiredReviews, rule.RequiredReviews))
+	}
+	if from.AllowForcePush != rule.AllowForcePush || current == nil {
+		parts = append(parts, "force push "+allowedOrBlocked(from.AllowForcePush)+" -> "+allowedOrBlocked(rule.AllowForcePush))
+	}
+	if from.AllowDeletion != rule.AllowDeletion || current == nil {
+		parts = append(parts, "deletion "+allowedOrBlocked(from.AllowDeletion)+" -> "+allowedOrBlocked(rule.AllowDeletion))
+	}
+	return prefix + strings.Join(parts, ", ")
+}
+
+func allowedOrBlocked(allowed bool) string {
+	if allowed {
+		return "allowed"
+	}
+	return "blocked"
+}
+
+func branchList(rules []forge.BranchProtection) string {
+	branches := make([]string, len(rules))
+	for i, rule := range rules {
+		branches[i] = rule.Branch
+	}
+	return strings.Join(branches, ", ")
+}
+
+// HandleSyncProtection mirrors branch protection rules without syncing git
+// data. It works on the given repository, or on all configured and discovered
+// ones.
+func HandleSyncProtection(cfg *config.Config, flags *Flags) int {
+	if cfg.BranchProtectionSync == nil {
+		// Running the command explicitly enables mirroring from the first forge
+		cfg.BranchProtectionSync = &config.BranchProtectionSync{}
+	}
+
+	repoNames := []string{flags.SyncRepo}
+	if flags.SyncRepo == "" {
+		var err error
+		repoNames, err = getAllRepositories(cfg, includePrivateRepos(cfg, flags))
+		if err != nil {
+			fmt.Printf("ERROR: Failed to get repositories: %v\n", err)
+			return 1
+		}
+		sort.Strings(repoNames)
+	}
+
+	for i, repoName := range repoNames {
+		fmt.Printf("\n[%d/%d] Mirroring branch protection of %s...\n", i+1, len(repoNames), repoName)
+		syncBranchProtection(cfg, flags.DryRun, repoName)
+	}
+	return 0
+}
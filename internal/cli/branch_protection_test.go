@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// protectionForge is a stub forge that cannot enforce required reviews
+type protectionForge struct {
+	forge.BranchProtector
+}
+
+func (f *protectionForge) TranslateBranchProtection(rule forge.BranchProtection) (forge.BranchProtection, []string) {
+	if rule.RequiredReviews == 0 {
+		return rule, nil
+	}
+	rule.RequiredReviews = 0
+	return rule, []string{forge.ProtectionRequiredReviews}
+}
+
+func TestPlanProtectionChanges_TranslatesAndSkipsMatchingRules(t *testing.T) {
+	t.Parallel()
+
+	source := []forge.BranchProtection{
+		{Branch: "release", AllowForcePush: false},
+		{Branch: "main", RequiredReviews: 1, Other: []string{"required status checks"}},
+	}
+	current := []forge.BranchProtection{
+		{Branch: "release"},
+		{Branch: "main", AllowForcePush: true},
+	}
+
+	changes := planProtectionChanges(source, &protectionForge{}, current)
+	if len(changes) != 1 {
+		t.Fatalf("expected only main to change, got %#v", changes)
+	}
+	change := changes[0]
+	if !change.rule.Equal(forge.BranchProtection{Branch: "main"}) || change.current == nil {
+		t.Fatalf("unexpected change %#v", change)
+	}
+	if want := []string{forge.ProtectionRequiredReviews, "required status checks"}; !reflect.DeepEqual(change.lost, want) {
+		t.Fatalf("lost = %v, want %v", change.lost, want)
+	}
+	if diff := describeProtectionDiff(change.current, change.rule); diff != "force push allowed -> blocked" {
+		t.Fatalf("diff = %q", diff)
+	}
+
+	changes = planProtectionChanges(source, &protectionForge{}, nil)
+	if len(changes) != 2 || changes[0].rule.Branch != "main" || changes[0].current != nil {
+		t.Fatalf("expected new rules for both branches, got %#v", changes)
+	}
+	if diff := describeProtectionDiff(nil, changes[0].rule); !strings.HasPrefix(diff, "new rule: ") {
+		t.Fatalf("diff = %q", diff)
+	}
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+)
+
+// workDirClone is a clone in the work directory as seen by manage clean
+type workDirClone struct {
+	name    string
+	orphan  string // why the clone is an orphan, empty if it is still in use
+	unsaved string // work found only in the clone, which is then never deleted
+	size    int64
+}
+
+// HandleClean lists the clones in the work directory and flags those that
+// are neither configured nor discovered on any forge, or that no forge has
+// any more, as orphans. Orphans are deleted after confirmation or with
+// --force, except clones with work that exists nowhere else: uncommitted
+// changes, unpushed commits or stashes. With gc, the remaining clones are
+// garbage collected.
+func HandleClean(cfg *config.Config, flags *Flags, gc bool) int {
+	names, err := workDirRepositories(flags.WorkDir)
+	if os.IsNotExist(err) {
+		fmt.Printf("Work directory %s does not exist.\n", flags.WorkDir)
+		return 0
+	}
+	if err != nil {
+		fmt.Printf("ERROR: Failed to read work directory: %v\n", err)
+		return 1
+	}
+	if len(names) == 0 {
+		fmt.Printf("No clones in %s.\n", flags.WorkDir)
+		return 0
+	}
+
+	_, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
+	}
+	var forges []forge.Forge
+	for _, f := range forge.Configured(cfg) {
+		if !f.Organization().BackupLocation {
+			forges = append(forges, f)
+		}
+	}
+	clones, err := classifyClones(cfg, forges, syncState, flags.WorkDir, names)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		fmt.Println("Cannot tell orphans apart without all forges; nothing was deleted.")
+		return 1
+	}
+
+	fmt.Printf("Clones in %s:\n", flags.WorkDir)
+	for _, clone := range clones {
+		status := "in use"
+		if clone.orphan != "" {
+			status = "ORPHAN: " + clone.orphan
+		}
+		if clone.unsaved != "" {
+			status += ", " + clone.unsaved
+		}
+		fmt.Printf("  %-30s %10s  %s\n", clone.name, formatSize(clone.size), status)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	deleted := make(map[string]bool)
+	var reclaimed int64
+	for _, clone := range clones {
+		if clone.orphan == "" {
+			continue
+		}
+		repoPath := filepath.Join(flags.WorkDir, clone.name)
+		switch {
+		case clone.unsaved != "":
+			fmt.Printf("Keeping %s: it has %s\n", clone.name, clone.unsaved)
+			continue
+		case flags.DryRun:
+			fmt.Printf("[DRY RUN] Would delete %s (%s)\n", clone.name, formatSize(clone.size))
+			continue
+		case !flags.Force:
+			fmt.Printf("Delete %s (%s)? [y/N]: ", clone.name, formatSize(clone.size))
+			answer, _ := reader.ReadString('\n')
+			if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+				continue
+			}
+		}
+
+		entry := auditEntry{Action: "clean", Repo: clone.name, Location: repoPath}
+		if err := os.RemoveAll(repoPath); err != nil {
+			fmt.Printf("  Failed to delete %s: %v\n", clone.name, err)
+			entry.Error = err.Error()
+		} else {
+			fmt.Printf("  Deleted %s\n", clone.name)
+			deleted[clone.name] = true
+			reclaimed += clone.size
+		}
+		if err := appendAuditLog(flags.WorkDir, entry); err != nil {
+			fmt.Printf("  Warning: %v\n", err)
+		}
+	}
+	if len(deleted) > 0 {
+		fmt.Printf("Reclaimed %s from %d orphaned clones\n", formatSize(reclaimed), len(deleted))
+	}
+
+	if gc {
+		collectGarbage(flags, clones, deleted)
+	}
+	return 0
+}
+
+// classifyClones flags orphaned clones. Repositories archived with manage
+// archive-repo are still in use. Forge existence is only checked for
+// configured repositories, as discovered ones exist by definition.
+func classifyClones(cfg *config.Config, forges []forge.Forge, st *state.State, workDir string, names []string) ([]workDirClone, error) {
+	discovered := make(map[string]bool)
+	for _, f := range forges {
+		repos, err := f.ListRepos(f.HasToken())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s repositories: %w", f.DisplayName(), err)
+		}
+		for _, repo := range repos {
+			discovered[repo.Name] = true
+		}
+	}
+
+	clones := make([]workDirClone, 0, len(names))
+	for _, name := range names {
+		repoPath := filepath.Join(workDir, name)
+		clone := workDirClone{name: name, size: dirSize(repoPath)}
+		unsaved, err := unsavedWork(repoPath)
+		if err != nil {
+			// A clone git cannot inspect is kept to be safe
+			unsaved = "a state git cannot inspect"
+		}
+		clone.unsaved = unsaved
+
+		_, archived := st.RepoArchived(name)
+		switch {
+		case archived || discovered[name]:
+		case !contains(cfg.Repositories, name):
+			clone.orphan = "not configured or discovered"
+		case len(forges) > 0:
+			missing, err := missingOnAllForges(forges, name)
+			if err != nil {
+				return nil, err
+			}
+			if missing {
+				clone.orphan = "missing on every forge"
+			}
+		}
+		clones = append(clones, clone)
+	}
+	return clones, nil
+}
+
+func missingOnAllForges(forges []forge.Forge, repoName string) (bool, error) {
+	for _, f := range forges {
+		exists, err := f.RepoExists(repoName)
+		if err != nil {
+			return false, fmt.Errorf("failed to look up %s on %s: %w", repoName, f.DisplayName(), err)
+		}
+		if exists {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// collectGarbage runs git gc on every clone that was not deleted and reports
+// the space it freed
+func collectGarbage(flags *Flags, clones []workDirClone, deleted map[string]bool) {
+	fmt.Println("\nCollecting garbage...")
+	var reclaimed int64
+	for _, clone := range clones {
+		if deleted[clone.name] {
+			continue
+		}
+		if flags.DryRun {
+			fmt.Printf("[DRY RUN] Would run git gc on %s\n", clone.name)
+			continue
+		}
+		repoPath := filepath.Join(flags.WorkDir, clone.name)
+		if output, err := exec.Command("git", "-C", repoPath, "gc", "--prune=now", "--quiet").CombinedOutput(); err != nil {
+			fmt.Printf("  %s: git gc failed: %v\n%s", clone.name, err, string(output))
+			continue
+		}
+		if freed := clone.size - dirSize(repoPath); freed > 0 {
+			reclaimed += freed
+			fmt.Printf("  %s: %s freed\n", clone.name, formatSize(freed))
+		}
+	}
+	if !flags.DryRun {
+		fmt.Printf("Reclaimed %s with git gc\n", formatSize(reclaimed))
+	}
+}
+
+// unsavedWork describes the work a clone holds that no remote has: staged,
+// unstaged or untracked changes, commits on local branches that are on no
+// remote-tracking branch, and stashes. It returns "" if there is none.
+func unsavedWork(repoPath string) (string, error) {
+	checks := []struct {
+		what string
+		args []string
+	}{
+		{"uncommitted changes", []string{"status", "--porcelain"}},
+		{"unpushed commits", []string{"log", "--branches", "--not", "--remotes", "--oneline"}},
+		{"stashed changes", []string{"stash", "list"}},
+	}
+	for _, check := range checks {
+		output, err := exec.Command("git", append([]string{"-C", repoPath}, check.args...)...).Output()
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(string(output)) != "" {
+			return check.what, nil
+		}
+	}
+	return "", nil
+}
+
+// dirSize returns the total size of the files below path
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
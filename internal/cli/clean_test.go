@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestHandleClean_DeletesOnlyCleanOrphans(t *testing.T) {
+	source := t.TempDir()
+	gitRun(t, source, "init", "-q", "-b", "main")
+	gitRun(t, source, "commit", "-q", "--allow-empty", "-m", "initial")
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	gitRun(t, "", "clone", "-q", "--bare", source, remote)
+
+	workDir := t.TempDir()
+	for _, name := range []string{"kept", "orphan", "dirty", "unpushed", "stashed", "local"} {
+		gitRun(t, workDir, "clone", "-q", remote, name)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "dirty", "notes.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, filepath.Join(workDir, "unpushed"), "commit", "-q", "--allow-empty", "-m", "local work")
+	if err := os.WriteFile(filepath.Join(workDir, "stashed", "notes.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, filepath.Join(workDir, "stashed"), "stash", "-q", "-u")
+	// A branch that was never pushed anywhere
+	gitRun(t, filepath.Join(workDir, "local"), "checkout", "-q", "-b", "experiment")
+	gitRun(t, filepath.Join(workDir, "local"), "commit", "-q", "--allow-empty", "-m", "experiment")
+	gitRun(t, filepath.Join(workDir, "local"), "checkout", "-q", "main")
+
+	cfg := &config.Config{Repositories: []string{"kept"}}
+	if code := HandleClean(cfg, &Flags{WorkDir: workDir, DryRun: true}, true); code != 0 {
+		t.Fatalf("dry run exit code = %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "orphan")); err != nil {
+		t.Fatalf("dry run deleted the orphan: %v", err)
+	}
+
+	if code := HandleClean(cfg, &Flags{WorkDir: workDir, Force: true}, true); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "orphan")); !os.IsNotExist(err) {
+		t.Fatalf("expected the orphan to be deleted: %v", err)
+	}
+	for _, name := range []string{"kept", "dirty", "unpushed", "stashed", "local"} {
+		if _, err := os.Stat(filepath.Join(workDir, name, ".git")); err != nil {
+			t.Fatalf("expected %s to be kept: %v", name, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, auditLogFile))
+	if err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	var entry auditEntry
+	if len(lines) != 1 || json.Unmarshal([]byte(lines[0]), &entry) != nil || entry.Action != "clean" || entry.Repo != "orphan" {
+		t.Fatalf("audit log = %s", data)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for size, want := range tests {
+		if got := formatSize(size); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
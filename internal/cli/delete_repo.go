@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// deletedBundleDir holds the bundles taken before repositories are deleted
+const deletedBundleDir = ".gitsyncer-deleted"
+
+// deleteTarget is one organization a repository may be deleted from
+type deleteTarget struct {
+	location string
+	exists   bool
+	err      error
+	kept     bool // a backup location spared by --keep-backups
+	delete   func() error
+}
+
+// HandleDeleteRepo deletes a repository from every configured organization:
+// forges through their API, SSH and file:// locations by removing the bare
+// repository and S3 locations by removing the bundle. A bundle of the
+// work-dir clone, freshly fetched from all forges, is always written first,
+// and every deletion is appended to the audit log.
+func HandleDeleteRepo(cfg *config.Config, flags *Flags, repoName string, keepBackups bool) int {
+	if err := validateRepoName(repoName); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n⚠️  WARNING: This will permanently delete the repository '%s' from all configured organizations!\n\n", repoName)
+
+	targets := deleteTargets(cfg, repoName, keepBackups)
+	fmt.Println("Repository status:")
+	foundAny, unchecked := false, false
+	for _, target := range targets {
+		switch {
+		case target.kept:
+			fmt.Printf("  🔒 %s: Backup location - kept (--keep-backups)\n", target.location)
+		case target.err != nil:
+			fmt.Printf("  ❌ %s: Error checking - %v\n", target.location, target.err)
+			unchecked = true
+		case target.exists:
+			fmt.Printf("  ✅ %s: EXISTS - will be DELETED\n", target.location)
+			foundAny = true
+		default:
+			fmt.Printf("  ⬜ %s: Not found\n", target.location)
+		}
+	}
+
+	if unchecked {
+		fmt.Printf("\nERROR: Not every location could be checked, so '%s' might survive there. Nothing was deleted; fix the problem and rerun.\n", repoName)
+		return 1
+	}
+	if !foundAny {
+		fmt.Printf("\nRepository '%s' not found in any configured organization.\n", repoName)
+		return 0
+	}
+
+	bundlePath := filepath.Join(flags.WorkDir, deletedBundleDir, fmt.Sprintf("%s-%s.bundle", repoName, time.Now().Format("20060102-150405")))
+	if flags.DryRun {
+		fmt.Printf("\n[DRY RUN] Would write a bundle to %s and delete '%s' from the locations marked above.\n", bundlePath, repoName)
+		return 0
+	}
+
+	if !flags.Force {
+		fmt.Printf("\nAre you sure you want to delete '%s' from the above organizations? A bundle is kept in %s.\n", repoName, filepath.Dir(bundlePath))
+		fmt.Print("Type 'yes' to confirm: ")
+		confirmation, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(confirmation) != "yes" {
+			fmt.Println("Deletion cancelled.")
+			return 0
+		}
+	}
+
+	fmt.Printf("\nWriting a bundle of %s...\n", repoName)
+	if err := sync.New(cfg, flags.WorkDir).BundleRepository(repoName, bundlePath); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		fmt.Println("Nothing was deleted.")
+		return 1
+	}
+	fmt.Printf("Bundle written to %s\n", bundlePath)
+
+	fmt.Println("\nDeleting repositories...")
+	if !deleteFromTargets(flags.WorkDir, repoName, bundlePath, targets) {
+		fmt.Println("\n⚠️  Some deletions failed. Check the errors above.")
+		return 1
+	}
+
+	fmt.Printf("\n✅ Repository '%s' has been successfully deleted from all organizations.\n", repoName)
+	fmt.Printf("Restore it with: git clone %s\n", bundlePath)
+	return 0
+}
+
+// deleteTargets checks every configured organization for the repository
+func deleteTargets(cfg *config.Config, repoName string, keepBackups bool) []deleteTarget {
+	var targets []deleteTarget
+	for i := range cfg.Organizations {
+		org := &cfg.Organizations[i]
+		target := deleteTarget{location: org.Host}
+		if f, err := forge.New(org); err == nil {
+			target.location = org.GetGitURL()
+			target.exists, target.err = f.RepoExists(repoName)
+			target.delete = func() error { return f.DeleteRepo(repoName) }
+		} else {
+			target.exists, target.err = sync.BackupRepositoryExists(org, repoName)
+			target.delete = func() error { return sync.DeleteBackupRepository(org, repoName) }
+		}
+		target.kept = keepBackups && org.BackupLocation
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// deleteFromTargets deletes the repository wherever it exists, logs every
+// deletion and reports whether all of them succeeded. A location that could
+// not be checked counts as a failure.
+func deleteFromTargets(workDir, repoName, bundlePath string, targets []deleteTarget) bool {
+	ok := true
+	for _, target := range targets {
+		if target.kept {
+			continue
+		}
+		if target.err != nil {
+			fmt.Printf("  Skipping %s, which could not be checked\n", target.location)
+			ok = false
+			continue
+		}
+		if !target.exists {
+			continue
+		}
+
+		fmt.Printf("  Deleting from %s... ", target.location)
+		entry := auditEntry{Action: "delete-repo", Repo: repoName, Location: target.location, Bundle: bundlePath}
+		if err := target.delete(); err != nil {
+			fmt.Printf("FAILED: %v\n", err)
+			entry.Error = err.Error()
+			ok = false
+		} else {
+			fmt.Println("SUCCESS")
+		}
+		if err := appendAuditLog(workDir, entry); err != nil {
+			fmt.Printf("  Warning: %v\n", err)
+		}
+	}
+	return ok
+}
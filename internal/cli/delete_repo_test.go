@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func TestHandleDeleteRepo_BundlesDeletesAndAudits(t *testing.T) {
+	primaryRoot := t.TempDir()
+	backupRoot := t.TempDir()
+	work := t.TempDir()
+	gitRun(t, work, "init", "-q", "-b", "main")
+	gitRun(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	for _, root := range []string{primaryRoot, backupRoot} {
+		gitRun(t, "", "clone", "-q", "--bare", work, filepath.Join(root, "tool.git"))
+	}
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{
+			{Host: "file://" + primaryRoot},
+			{Host: "file://" + backupRoot, BackupLocation: true},
+		},
+	}
+	workDir := t.TempDir()
+	primaryRepo := filepath.Join(primaryRoot, "tool.git")
+	backupRepo := filepath.Join(backupRoot, "tool.git")
+
+	if code := HandleDeleteRepo(cfg, &Flags{WorkDir: workDir, DryRun: true}, "tool", false); code != 0 {
+		t.Fatalf("dry run exit code = %d", code)
+	}
+	if _, err := os.Stat(primaryRepo); err != nil {
+		t.Fatalf("dry run deleted the repository: %v", err)
+	}
+
+	if code := HandleDeleteRepo(cfg, &Flags{WorkDir: workDir, Force: true}, "tool", true); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	if _, err := os.Stat(primaryRepo); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted: %v", primaryRepo, err)
+	}
+	if _, err := os.Stat(backupRepo); err != nil {
+		t.Fatalf("--keep-backups deleted the backup: %v", err)
+	}
+
+	bundles, _ := filepath.Glob(filepath.Join(workDir, deletedBundleDir, "tool-*.bundle"))
+	if len(bundles) != 1 {
+		t.Fatalf("bundles = %v, want one", bundles)
+	}
+	gitRun(t, "", "bundle", "verify", bundles[0])
+
+	data, err := os.ReadFile(filepath.Join(workDir, auditLogFile))
+	if err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("audit log has %d entries, want 1:\n%s", len(lines), data)
+	}
+	var entry auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("parsing audit entry: %v", err)
+	}
+	if entry.Action != "delete-repo" || entry.Repo != "tool" || entry.Location != "file://"+primaryRoot || entry.Bundle != bundles[0] || entry.Error != "" {
+		t.Fatalf("audit entry = %+v", entry)
+	}
+}
+
+func TestHandleDeleteRepo_RefusesWhenALocationCannotBeChecked(t *testing.T) {
+	primaryRoot := t.TempDir()
+	work := t.TempDir()
+	gitRun(t, work, "init", "-q", "-b", "main")
+	gitRun(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	gitRun(t, "", "clone", "-q", "--bare", work, filepath.Join(primaryRoot, "tool.git"))
+	unreachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+	}))
+	defer unreachable.Close()
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{
+			{Host: "file://" + primaryRoot},
+			{Host: "git@git.example.com", Name: "me", Type: config.TypeGitea, APIURL: unreachable.URL, GiteaToken: "secret"},
+		},
+	}
+	if code := HandleDeleteRepo(cfg, &Flags{WorkDir: t.TempDir(), Force: true}, "tool", false); code != 1 {
+		t.Fatalf("exit code = %d, want 1", code)
+	}
+	if _, err := os.Stat(filepath.Join(primaryRoot, "tool.git")); err != nil {
+		t.Fatalf("expected nothing to be deleted: %v", err)
+	}
+}
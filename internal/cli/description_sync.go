@@ -8,104 +8,62 @@ import (
 	"path/filepath"
 	"strings"
 
-	"codeberg.org/snonux/gitsyncer/internal/codeberg"
 	"codeberg.org/snonux/gitsyncer/internal/config"
-	"codeberg.org/snonux/gitsyncer/internal/github"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 )
 
-// syncRepoDescriptions ensures both platforms have the canonical description
-// Precedence: Codeberg > GitHub; if Codeberg empty and GitHub has one, use GitHub.
-// knownCBDesc and knownGHDesc can be empty; the function fetches as needed.
-func syncRepoDescriptions(cfg *config.Config, dryRun bool, repoName, knownCBDesc, knownGHDesc string, cache map[string]string) {
-	// Load orgs
-	ghOrg := cfg.FindGitHubOrg()
-	cbOrg := cfg.FindCodebergOrg()
-
-	var ghClient *github.Client
-	var cbClient *codeberg.Client
-	if ghOrg != nil {
-		c := github.NewClient(ghOrg.GitHubToken, ghOrg.Name)
-		ghClient = &c
-	}
-	if cbOrg != nil {
-		c := codeberg.NewClient(cbOrg.Name, cbOrg.CodebergToken)
-		cbClient = &c
+// syncRepoDescriptions ensures all forges have the canonical description.
+// The canonical description is the first non-empty one in forge precedence
+// order (Codeberg first, see forge.Register). If source is non-nil, sourceDesc
+// is used as its already known description instead of fetching it again.
+func syncRepoDescriptions(cfg *config.Config, dryRun bool, repoName string, source forge.Forge, sourceDesc string, cache map[string]string) {
+	type forgeDescription struct {
+		forge       forge.Forge
+		description string
+		exists      bool
 	}
 
 	// Get current descriptions (use known if provided)
-	cbDesc := strings.TrimSpace(knownCBDesc)
-	ghDesc := strings.TrimSpace(knownGHDesc)
-	var cbExists, ghExists bool
-
-	if cbDesc == "" && cbClient != nil {
-		if repo, exists, err := cbClient.GetRepo(repoName); err == nil {
-			cbExists = exists
-			if exists {
-				cbDesc = strings.TrimSpace(repo.Description)
-			}
+	var current []forgeDescription
+	canonical := ""
+	for _, f := range forge.Configured(cfg) {
+		entry := forgeDescription{forge: f}
+		if source != nil && strings.TrimSpace(sourceDesc) != "" && f.Organization().GetGitURL() == source.Organization().GetGitURL() {
+			entry.description = strings.TrimSpace(sourceDesc)
+			entry.exists = true
+		} else if repo, exists, err := f.GetRepo(repoName); err == nil {
+			entry.exists = exists
+			entry.description = strings.TrimSpace(repo.Description)
 		} else {
-			fmt.Printf("  Warning: Codeberg repo lookup failed: %v\n", err)
+			fmt.Printf("  Warning: %s repo lookup failed: %v\n", f.DisplayName(), err)
 		}
-	} else if cbClient != nil {
-		cbExists = true
-	}
 
-	if ghClient != nil {
-		if ghDesc == "" || !ghExists {
-			if repo, exists, err := ghClient.GetRepo(repoName); err == nil {
-				ghExists = exists
-				if exists {
-					ghDesc = strings.TrimSpace(repo.Description)
-				}
-			} else {
-				fmt.Printf("  Warning: GitHub repo lookup failed: %v\n", err)
-			}
+		if canonical == "" && entry.exists {
+			canonical = entry.description
 		}
+		current = append(current, entry)
 	}
 
-	// Determine canonical description
-	canonical := cbDesc
-	if canonical == "" {
-		canonical = ghDesc
-	}
-	canonical = strings.TrimSpace(canonical)
-
 	// If nothing to sync, bail
 	if canonical == "" {
 		return
 	}
 
-	// Update Codeberg if needed
-	if cbClient != nil && cbExists {
-		if cbDesc != canonical {
-			if dryRun {
-				fmt.Printf("  [DRY RUN] Would update Codeberg description for %s -> %q\n", repoName, canonical)
-			} else if cbClient.HasToken() {
-				if err := cbClient.UpdateRepoDescription(repoName, canonical); err != nil {
-					fmt.Printf("  Warning: Failed to update Codeberg description: %v\n", err)
-				} else {
-					fmt.Printf("  Updated Codeberg description for %s\n", repoName)
-				}
-			} else {
-				fmt.Println("  Warning: No Codeberg token; cannot update description")
-			}
+	for _, entry := range current {
+		if !entry.exists || entry.description == canonical {
+			continue
 		}
-	}
-
-	// Update GitHub if needed
-	if ghClient != nil && ghExists {
-		if ghDesc != canonical {
-			if dryRun {
-				fmt.Printf("  [DRY RUN] Would update GitHub description for %s -> %q\n", repoName, canonical)
-			} else if ghClient.HasToken() {
-				if err := ghClient.UpdateRepoDescription(repoName, canonical); err != nil {
-					fmt.Printf("  Warning: Failed to update GitHub description: %v\n", err)
-				} else {
-					fmt.Printf("  Updated GitHub description for %s\n", repoName)
-				}
+		name := entry.forge.DisplayName()
+		if dryRun {
+			fmt.Printf("  [DRY RUN] Would update %s description for %s -> %q\n", name, repoName, canonical)
+		} else if entry.forge.HasToken() {
+			if err := entry.forge.UpdateDescription(repoName, canonical); err != nil {
+				fmt.Printf("  Warning: Failed to update %s description: %v\n", name, err)
 			} else {
-				fmt.Println("  Warning: No GitHub token; cannot update description")
+				fmt.Printf("  Updated %s description for %s\n", name, repoName)
 			}
+		} else {
+			fmt.Printf("  Warning: No %s token; cannot update description\n", name)
 		}
 	}
 
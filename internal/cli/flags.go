@@ -10,33 +10,38 @@ import (
 
 // Flags holds all command-line flag values
 type Flags struct {
-	VersionFlag         bool
-	ConfigPath          string
-	ListOrgs            bool
-	ListRepos           bool
-	SyncRepo            string
-	SyncAll             bool
-	SyncCodebergPublic  bool
-	SyncGitHubPublic    bool
-	FullSync            bool
-	CreateGitHubRepos   bool
-	CreateCodebergRepos bool
-	DryRun              bool
-	WorkDir             string
-	TestGitHubToken     bool
-	Clean               bool
-	DeleteRepo          string
-	Backup              bool
-	Showcase            bool
-	Force               bool
-	BatchRun            bool
-	CheckReleases       bool
-	NoCheckReleases     bool
-	AutoCreateReleases  bool
-	AIReleaseNotes      bool
-	UpdateReleases      bool
-	AITool              string
-	Throttle            bool
+	VersionFlag          bool
+	ConfigPath           string
+	ListOrgs             bool
+	ListRepos            bool
+	SyncRepo             string
+	SyncAll              bool
+	SyncCodebergPublic   bool
+	SyncGitHubPublic     bool
+	FullSync             bool
+	CreateGitHubRepos    bool
+	CreateCodebergRepos  bool
+	CreateGiteaRepos     bool
+	CreateGitLabRepos    bool
+	CreateSourceHutRepos bool
+	DryRun               bool
+	WorkDir              string
+	TestGitHubToken      bool
+	Clean                bool
+	DeleteRepo           string
+	Backup               bool
+	Showcase             bool
+	Force                bool
+	BatchRun             bool
+	CheckReleases        bool
+	NoCheckReleases      bool
+	AutoCreateReleases   bool
+	AIReleaseNotes       bool
+	UpdateReleases       bool
+	AITool               string
+	Throttle             bool
+	IncludePrivate       bool
+	PropagateRenames     bool
 
 	// Internal fields for batch run state management (not set by flags)
 	BatchRunStateManager *state.Manager
@@ -75,6 +80,8 @@ func ParseFlags() *Flags {
 	flag.BoolVar(&f.AIReleaseNotes, "ai-release-notes", false, "generate release notes using AI (opencode by default) based on git diff")
 	flag.BoolVar(&f.UpdateReleases, "update-releases", false, "update existing releases with new AI-generated notes")
 	flag.BoolVar(&f.Throttle, "throttle", false, "enable throttled syncing based on local activity")
+	flag.BoolVar(&f.IncludePrivate, "include-private", false, "also discover and mirror private repositories")
+	flag.BoolVar(&f.PropagateRenames, "propagate-renames", false, "rename repositories everywhere when discovery detects a rename")
 
 	flag.Parse()
 
@@ -95,6 +102,9 @@ func ParseFlags() *Flags {
 		f.SyncGitHubPublic = true
 		f.CreateGitHubRepos = true
 		f.CreateCodebergRepos = true
+		f.CreateGiteaRepos = true
+		f.CreateGitLabRepos = true
+		f.CreateSourceHutRepos = true
 	}
 
 	// Handle --batch-run flag by enabling --full and --showcase
@@ -106,6 +116,9 @@ func ParseFlags() *Flags {
 		f.SyncGitHubPublic = true
 		f.CreateGitHubRepos = true
 		f.CreateCodebergRepos = true
+		f.CreateGiteaRepos = true
+		f.CreateGitLabRepos = true
+		f.CreateSourceHutRepos = true
 	}
 
 	return f
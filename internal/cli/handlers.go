@@ -1,15 +1,13 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"codeberg.org/snonux/gitsyncer/internal/codeberg"
 	"codeberg.org/snonux/gitsyncer/internal/config"
-	"codeberg.org/snonux/gitsyncer/internal/github"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 	"codeberg.org/snonux/gitsyncer/internal/version"
 )
 
@@ -19,30 +17,33 @@ func HandleVersion() int {
 	return 0
 }
 
-// HandleTestGitHubToken tests GitHub token authentication
-func HandleTestGitHubToken() int {
-	fmt.Println("Testing GitHub token authentication...")
-	client := github.NewClient("", "snonux") // Empty token to trigger loading from env/file
-	if !client.HasToken() {
-		fmt.Println("ERROR: No GitHub token found!")
-		fmt.Println("Please set GITHUB_TOKEN environment variable or create ~/.gitsyncer_github_token file")
+// HandleTestToken tests the API token of the first organization of a forge type
+func HandleTestToken(cfg *config.Config, forgeType string) int {
+	f := forge.Find(cfg, forgeType)
+	if f == nil {
+		fmt.Printf("ERROR: No %s organization found in configuration\n", forgeType)
 		return 1
 	}
 
-	// Test the token by checking a known repo
-	exists, err := client.RepoExists("gitsyncer")
-	if err != nil {
+	fmt.Printf("Testing %s token authentication...\n", f.DisplayName())
+	if !f.HasToken() {
+		fmt.Printf("ERROR: No %s token found!\n", f.DisplayName())
+		fmt.Println("Please set it in the config file, the environment or the token file (see README)")
+		return 1
+	}
+
+	if err := f.TestAuth(); err != nil {
 		fmt.Printf("ERROR: Token test failed: %v\n", err)
 		if strings.Contains(err.Error(), "401") {
 			fmt.Println("\nThe token is invalid or expired. Please check:")
 			fmt.Println("1. Token has not expired")
-			fmt.Println("2. Token has 'repo' scope")
+			fmt.Println("2. Token has repository scope")
 			fmt.Println("3. Token was not revoked")
 		}
 		return 1
 	}
 
-	fmt.Printf("SUCCESS: Token is valid! Repository check returned: %v\n", exists)
+	fmt.Println("SUCCESS: Token is valid!")
 	return 0
 }
 
@@ -158,111 +159,22 @@ func ShowUsage(cfg *config.Config) {
 	fmt.Println("  Set via: config file, GITHUB_TOKEN env var, or ~/.gitsyncer_github_token file")
 }
 
-// HandleDeleteRepo handles the --delete-repo flag
-func HandleDeleteRepo(cfg *config.Config, repoName string) int {
-	if repoName == "" {
-		fmt.Println("Error: Repository name is required for --delete-repo")
-		return 1
-	}
-
-	fmt.Printf("\n⚠️  WARNING: This will permanently delete the repository '%s' from all configured organizations!\n\n", repoName)
-
-	// Find organizations where the repo exists
-	var orgsWithRepo []struct {
-		org    config.Organization
-		exists bool
-		err    error
-	}
-
-	for _, org := range cfg.Organizations {
-		var exists bool
-		var err error
-
-		switch org.Host {
-		case "git@github.com":
-			client := github.NewClient(org.GitHubToken, org.Name)
-			exists, err = client.RepoExists(repoName)
-		case "git@codeberg.org":
-			client := codeberg.NewClient(org.Name, org.CodebergToken)
-			exists, err = client.RepoExists(repoName)
-		default:
-			fmt.Printf("Skipping unsupported host: %s\n", org.Host)
-			continue
-		}
-
-		orgsWithRepo = append(orgsWithRepo, struct {
-			org    config.Organization
-			exists bool
-			err    error
-		}{org, exists, err})
-	}
-
-	// Show summary of where the repo exists
-	fmt.Println("Repository status:")
-	foundAny := false
-	for _, info := range orgsWithRepo {
-		if info.err != nil {
-			fmt.Printf("  ❌ %s: Error checking - %v\n", info.org.GetGitURL(), info.err)
-		} else if info.exists {
-			fmt.Printf("  ✅ %s: EXISTS - will be DELETED\n", info.org.GetGitURL())
-			foundAny = true
-		} else {
-			fmt.Printf("  ⬜ %s: Not found\n", info.org.GetGitURL())
-		}
-	}
-
-	if !foundAny {
-		fmt.Printf("\nRepository '%s' not found in any configured organization.\n", repoName)
-		return 0
-	}
-
-	// Confirm deletion
-	fmt.Printf("\nAre you sure you want to delete '%s' from the above organizations? This action cannot be undone!\n", repoName)
-	fmt.Print("Type 'yes' to confirm: ")
-
-	reader := bufio.NewReader(os.Stdin)
-	confirmation, _ := reader.ReadString('\n')
-	confirmation = strings.TrimSpace(confirmation)
-
-	if confirmation != "yes" {
-		fmt.Println("Deletion cancelled.")
-		return 0
+// workDirRepositories lists the git clones in the work directory
+func workDirRepositories(workDir string) ([]string, error) {
+	entries, err := os.ReadDir(workDir)
+	if err != nil {
+		return nil, err
 	}
 
-	// Perform deletions
-	fmt.Println("\nDeleting repositories...")
-	hasError := false
-
-	for _, info := range orgsWithRepo {
-		if !info.exists || info.err != nil {
-			continue
+	var repositories []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			// Check if it's a git repository
+			gitPath := filepath.Join(workDir, entry.Name(), ".git")
+			if _, err := os.Stat(gitPath); err == nil {
+				repositories = append(repositories, entry.Name())
+			}
 		}
-
-		fmt.Printf("  Deleting from %s... ", info.org.GetGitURL())
-
-		var deleteErr error
-		switch info.org.Host {
-		case "git@github.com":
-			client := github.NewClient(info.org.GitHubToken, info.org.Name)
-			deleteErr = client.DeleteRepo(repoName)
-		case "git@codeberg.org":
-			client := codeberg.NewClient(info.org.Name, info.org.CodebergToken)
-			deleteErr = client.DeleteRepo(repoName)
-		}
-
-		if deleteErr != nil {
-			fmt.Printf("FAILED: %v\n", deleteErr)
-			hasError = true
-		} else {
-			fmt.Println("SUCCESS")
-		}
-	}
-
-	if hasError {
-		fmt.Println("\n⚠️  Some deletions failed. Check the errors above.")
-		return 1
 	}
-
-	fmt.Printf("\n✅ Repository '%s' has been successfully deleted from all organizations.\n", repoName)
-	return 0
+	return repositories, nil
 }
@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// importSource is one clone URL of an import list
+type importSource struct {
+	url   string
+	host  string // empty for local paths
+	owner string // path of the repository without its name
+	name  string
+}
+
+// HandleImport imports the repositories listed in a file, one clone URL per
+// line. Each is created on every configured forge with the description of
+// the source, all branches and tags are pushed, the work-dir clone is set
+// up, and finally the names are appended to the configuration file.
+// Repositories already configured are imported again, which makes it safe
+// to rerun the import after a failure.
+func HandleImport(cfg *config.Config, flags *Flags, listPath string) int {
+	f, err := os.Open(listPath)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+	sources, err := parseImportList(f)
+	f.Close()
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+	if len(sources) == 0 {
+		fmt.Printf("No clone URLs found in %s\n", listPath)
+		return 0
+	}
+
+	configPath, err := config.ResolvePath(flags.ConfigPath)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+
+	forges := forge.Configured(cfg)
+	syncer := sync.New(cfg, flags.WorkDir)
+	syncer.SetBackupEnabled(shouldEnableBackupSync(flags))
+
+	stateManager, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
+	}
+
+	var imported []string
+	failed := 0
+	for i, source := range sources {
+		fmt.Printf("\n[%d/%d] Importing %s as %s...\n", i+1, len(sources), source.url, source.name)
+		if flags.DryRun {
+			fmt.Printf("[DRY RUN] Would create %s on %d forges, push all branches and tags and add it to %s\n", source.name, len(withoutNativeMirrors(cfg, forges, source.name)), configPath)
+			continue
+		}
+
+		repo, found := sourceRepository(cfg, source)
+		if err := createImportedRepo(withoutNativeMirrors(cfg, forges, source.name), repo); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			failed++
+			continue
+		}
+		if err := syncer.ImportRepository(source.url, source.name); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			failed++
+			continue
+		}
+		syncNativeMirrors(cfg, flags, source.name, false)
+		if found {
+			recordRepoVisibility(syncState, repo)
+		}
+		fmt.Printf("  Imported %s\n", source.name)
+		imported = append(imported, source.name)
+	}
+
+	if len(imported) > 0 {
+		if err := stateManager.Save(syncState); err != nil {
+			fmt.Printf("Warning: Failed to save sync state: %v\n", err)
+		}
+	}
+
+	if len(imported) > 0 {
+		added, err := config.AppendRepositories(configPath, imported)
+		if err != nil {
+			fmt.Printf("\nERROR: Failed to add the imported repositories to %s: %v\n", configPath, err)
+			return 1
+		}
+		if len(added) > 0 {
+			fmt.Printf("\nAdded %s to %s\n", strings.Join(added, ", "), configPath)
+		}
+	}
+
+	fmt.Printf("\n=== Summary ===\n")
+	fmt.Printf("Imported: %d, failed: %d\n", len(imported), failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// parseImportList reads clone URLs, one per line. Blank lines and lines
+// starting with # are ignored.
+func parseImportList(r io.Reader) ([]importSource, error) {
+	var sources []importSource
+	seen := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		source, err := parseCloneURL(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if other, ok := seen[source.name]; ok {
+			return nil, fmt.Errorf("line %d: %s and %s would both be imported as %s", lineNo, other, line, source.name)
+		}
+		seen[source.name] = line
+		sources = append(sources, source)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read import list: %w", err)
+	}
+	return sources, nil
+}
+
+// parseCloneURL splits a clone URL into host, owner and repository name.
+// URLs with a scheme, scp-like "user@host:path" and local paths are accepted.
+func parseCloneURL(raw string) (importSource, error) {
+	source := importSource{url: raw}
+	repoPath := raw
+	switch {
+	case strings.Contains(raw, "://"):
+		u, err := url.Parse(raw)
+		if err != nil {
+			return source, fmt.Errorf("invalid clone URL %q: %w", raw, err)
+		}
+		source.host = u.Hostname()
+		repoPath = u.Path
+	case strings.Contains(raw, ":") && !strings.HasPrefix(raw, "/"):
+		hostPart, pathPart, _ := strings.Cut(raw, ":")
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		source.host = hostPart
+		repoPath = pathPart
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	source.name = path.Base(repoPath)
+	if dir := path.Dir(repoPath); dir != "." {
+		source.owner = dir
+	}
+	if err := validateRepoName(source.name); err != nil {
+		return source, fmt.Errorf("cannot derive a repository name from %q: %w", raw, err)
+	}
+	return source, nil
+}
+
+// sourceRepository looks the source up on its forge to get its description
+// and visibility: through the configured organization if the URL belongs to
+// one, otherwise through a client for the host, with whatever token is
+// configured for it, if the host is a known forge. If the source cannot be
+// looked up, it returns a private repository of that name and false: a failed
+// lookup may well be a private repository asked without access to it.
+func sourceRepository(cfg *config.Config, source importSource) (forge.Repository, bool) {
+	fallback := forge.Repository{Name: source.name, Private: true}
+	if source.host == "" || source.owner == "" {
+		return fallback, false
+	}
+
+	var sourceForge forge.Forge
+	for _, f := range forge.Configured(cfg) {
+		org := f.Organization()
+		if strings.EqualFold(org.WebHost(), source.host) && strings.EqualFold(org.Name, source.owner) {
+			sourceForge = f
+			break
+		}
+	}
+	if sourceForge == nil {
+		f, err := forge.New(&config.Organization{Host: "git@" + source.host, Name: source.owner})
+		if err != nil {
+			return fallback, false
+		}
+		sourceForge = f
+	}
+
+	repo, exists, err := sourceForge.GetRepo(source.name)
+	if err != nil || !exists {
+		fmt.Printf("  Warning: Could not look up %s on %s, importing it as private without a description\n", source.name, sourceForge.DisplayName())
+		return fallback, false
+	}
+	repo.Name = source.name
+	return repo, true
+}
+
+// createImportedRepo creates the repository on every forge that does not
+// have it yet, with the description and visibility of the source
+func createImportedRepo(forges []forge.Forge, repo forge.Repository) error {
+	for _, f := range forges {
+		exists, err := f.RepoExists(repo.Name)
+		if err != nil {
+			return fmt.Errorf("failed to check %s on %s: %w", repo.Name, f.DisplayName(), err)
+		}
+		if exists {
+			continue
+		}
+		visibility := "public"
+		if repo.Private {
+			visibility = "private"
+		}
+		fmt.Printf("  Creating %s %s repository on %s...\n", visibility, repo.Name, f.DisplayName())
+		if err := f.CreateRepo(repo.Name, repo.Description, repo.Private); err != nil {
+			return fmt.Errorf("failed to create %s on %s: %w", repo.Name, f.DisplayName(), err)
+		}
+	}
+	return nil
+}
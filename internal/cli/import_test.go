@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+func TestParseCloneURL(t *testing.T) {
+	tests := []struct {
+		url, host, owner, name string
+	}{
+		{url: "https://github.com/me/tool.git", host: "github.com", owner: "me", name: "tool"},
+		{url: "git@codeberg.org:me/tool", host: "codeberg.org", owner: "me", name: "tool"},
+		{url: "ssh://git@gitlab.com:2222/group/sub/tool.git/", host: "gitlab.com", owner: "group/sub", name: "tool"},
+		{url: "file:///srv/git/tool.git", owner: "srv/git", name: "tool"},
+		{url: "/srv/git/tool", owner: "srv/git", name: "tool"},
+	}
+	for _, tt := range tests {
+		source, err := parseCloneURL(tt.url)
+		if err != nil || source.host != tt.host || source.owner != tt.owner || source.name != tt.name {
+			t.Errorf("parseCloneURL(%s) = %+v, %v", tt.url, source, err)
+		}
+	}
+	if _, err := parseCloneURL("https://example.com/"); err == nil {
+		t.Error("expected an error for a URL without a repository name")
+	}
+}
+
+func TestParseImportList_RejectsDuplicateNames(t *testing.T) {
+	list := "# projects\n\nhttps://github.com/me/tool.git\ngit@codeberg.org:other/tool.git\n"
+	if _, err := parseImportList(strings.NewReader(list)); err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("parseImportList() error = %v, want a duplicate on line 4", err)
+	}
+}
+
+func TestHandleImport_PushesEverywhereAndAppendsConfig(t *testing.T) {
+	source := t.TempDir()
+	gitRun(t, source, "init", "-q", "-b", "main")
+	gitRun(t, source, "commit", "-q", "--allow-empty", "-m", "initial")
+	gitRun(t, source, "branch", "feature")
+	gitRun(t, source, "tag", "v1.0.0")
+
+	primaryRoot := t.TempDir()
+	secondRoot := t.TempDir()
+	cfg := &config.Config{
+		Organizations: []config.Organization{{Host: "file://" + primaryRoot}, {Host: "file://" + secondRoot}},
+		Repositories:  []string{"existing"},
+	}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configText := "{\n  \"organizations\": [],\n  \"repositories\": [\n    \"existing\"\n  ]\n}\n"
+	if err := os.WriteFile(configPath, []byte(configText), 0644); err != nil {
+		t.Fatal(err)
+	}
+	listPath := filepath.Join(t.TempDir(), "repos.txt")
+	if err := os.WriteFile(listPath, []byte(source+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Base(source)
+	workDir := t.TempDir()
+	flags := &Flags{ConfigPath: configPath, WorkDir: workDir}
+
+	if code := HandleImport(cfg, flags, listPath); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	for _, root := range []string{primaryRoot, secondRoot} {
+		output, err := exec.Command("git", "-C", filepath.Join(root, name+".git"), "for-each-ref", "--format=%(refname)").Output()
+		if err != nil {
+			t.Fatalf("listing refs in %s: %v", root, err)
+		}
+		if refs := strings.Fields(string(output)); len(refs) != 3 {
+			t.Fatalf("refs in %s = %v, want main, feature and v1.0.0", root, refs)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(workDir, name, ".git")); err != nil {
+		t.Fatalf("expected a work-dir clone: %v", err)
+	}
+	got, _ := os.ReadFile(configPath)
+	want := "{\n  \"organizations\": [],\n  \"repositories\": [\n    \"existing\",\n    \"" + name + "\"\n  ]\n}\n"
+	if string(got) != want {
+		t.Fatalf("config =\n%s\nwant\n%s", got, want)
+	}
+
+	// Rerunning is safe and does not list the repository twice
+	if code := HandleImport(cfg, flags, listPath); code != 0 {
+		t.Fatalf("rerun exit code = %d", code)
+	}
+	if again, _ := os.ReadFile(configPath); string(again) != want {
+		t.Fatalf("rerun changed the config to\n%s", again)
+	}
+}
+
+func TestImport_FailedLookupCreatesPrivateRepos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	cfg := &config.Config{Organizations: []config.Organization{
+		{Host: "git@github.com", Name: "me", APIURL: server.URL, GitHubToken: "secret"},
+	}}
+
+	for _, cloneURL := range []string{"https://github.com/me/tool.git", "/srv/git/tool"} {
+		source, err := parseCloneURL(cloneURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo, found := sourceRepository(cfg, source)
+		if found || !repo.Private || repo.Name != "tool" {
+			t.Fatalf("sourceRepository(%s) = %+v, %v, want an unfound private repository", cloneURL, repo, found)
+		}
+
+		target := &recordingForge{org: &config.Organization{Name: "target"}, created: map[string]bool{}}
+		if err := createImportedRepo([]forge.Forge{target}, repo); err != nil {
+			t.Fatalf("createImportedRepo() error = %v", err)
+		}
+		if private, ok := target.created["tool"]; !ok || !private {
+			t.Fatalf("expected a private copy of %s, got %v", cloneURL, target.created)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/issues"
+)
+
+// IssuesSyncOptions holds the options of the issues sync command
+type IssuesSyncOptions struct {
+	RepoName   string // Empty means all configured and discovered repositories
+	ReportOnly bool   // Only report what would be mirrored
+}
+
+// issueForges returns the two forges whose issues are mirrored: GitHub and
+// Codeberg, or a self-hosted Gitea/Forgejo instance if there is no Codeberg
+func issueForges(cfg *config.Config) (forge.IssueTracker, forge.IssueTracker, error) {
+	github, _ := forge.Find(cfg, forge.TypeGitHub).(forge.IssueTracker)
+	other, _ := forge.Find(cfg, forge.TypeCodeberg).(forge.IssueTracker)
+	if other == nil {
+		other, _ = forge.Find(cfg, forge.TypeGitea).(forge.IssueTracker)
+	}
+	if github == nil || other == nil {
+		return nil, nil, fmt.Errorf("issue mirroring needs a GitHub and a Codeberg (or Gitea/Forgejo) organization")
+	}
+	for _, f := range []forge.IssueTracker{github, other} {
+		if !f.HasToken() {
+			return nil, nil, fmt.Errorf("%s token required for issue mirroring", f.DisplayName())
+		}
+	}
+	return github, other, nil
+}
+
+// HandleIssuesSync mirrors issues and comments between GitHub and Codeberg
+func HandleIssuesSync(cfg *config.Config, flags *Flags, opts IssuesSyncOptions) int {
+	github, other, err := issueForges(cfg)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+
+	repoNames := []string{opts.RepoName}
+	if opts.RepoName == "" {
+		repoNames, err = getAllRepositories(cfg, includePrivateRepos(cfg, flags))
+		if err != nil {
+			fmt.Printf("ERROR: Failed to get repositories: %v\n", err)
+			return 1
+		}
+		sort.Strings(repoNames)
+	}
+
+	mapping, err := issues.LoadMapping(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+	mirror := issues.NewMirror(github, other, mapping, opts.ReportOnly)
+
+	exitCode := 0
+	var total issues.Result
+	for i, repoName := range repoNames {
+		fmt.Printf("\n[%d/%d] Mirroring issues of %s...\n", i+1, len(repoNames), repoName)
+		if !repoExistsOn(repoName, github, other) {
+			fmt.Printf("  Skipping: %s is not on both %s and %s\n", repoName, github.DisplayName(), other.DisplayName())
+			continue
+		}
+		result, err := mirror.SyncRepo(repoName)
+		total.Issues += result.Issues
+		total.Comments += result.Comments
+		total.Closed += result.Closed
+		if err != nil {
+			fmt.Printf("  ERROR: %v\n", err)
+			exitCode = 1
+		}
+		// Saved after every repository, so that an interrupted run does not
+		// lose the links of issues already mirrored and mirror them again
+		if !opts.ReportOnly {
+			if err := mapping.Save(flags.WorkDir); err != nil {
+				fmt.Printf("ERROR: %v\n", err)
+				return 1
+			}
+		}
+	}
+
+	if opts.ReportOnly {
+		fmt.Printf("\nWould mirror %d issues and %d comments and close %d issues\n", total.Issues, total.Comments, total.Closed)
+		return exitCode
+	}
+	fmt.Printf("\nMirrored %d issues and %d comments, closed %d issues\n", total.Issues, total.Comments, total.Closed)
+	return exitCode
+}
+
+func repoExistsOn(repoName string, forges ...forge.Forge) bool {
+	for _, f := range forges {
+		exists, err := f.RepoExists(repoName)
+		if err != nil {
+			fmt.Printf("  Warning: %s repo lookup failed: %v\n", f.DisplayName(), err)
+			return false
+		}
+		if !exists {
+			return false
+		}
+	}
+	return true
+}
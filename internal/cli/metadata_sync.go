@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// forgeRepo is the copy of a repository on one forge
+type forgeRepo struct {
+	forge forge.Forge
+	repo  forge.Repository
+}
+
+// settingChange is one setting of one forge that differs from the canonical value
+type settingChange struct {
+	setting string
+	from    string
+	to      string
+}
+
+// metadataUpdate collects the setting changes of one forge
+type metadataUpdate struct {
+	forge    forge.Forge
+	changes  []settingChange
+	settings forge.RepoSettings
+}
+
+// syncRepoMetadata reconciles repository settings (homepage, topics, archived
+// flag, default branch, issues, wiki and visibility) across all forges that
+// have the repository. Each setting takes the value of the first forge in its
+// configured precedence order; topics may be merged instead, and visibility
+// only ever changes to private. Without a configured precedence, the default
+// branch of the primary organization wins. detectedBranch, the branch the
+// remotes' HEAD points to, is the default branch when no forge reports one. The canonical
+// topics are stored in topicsCache. Without metadata_sync only the default
+// branch is reconciled.
+func syncRepoMetadata(cfg *config.Config, dryRun bool, repoName, detectedBranch string, topicsCache map[string][]string) {
+	ms := effectiveMetadataSync(cfg)
+	syncTopics := contains(ms.EnabledFields(), config.SettingTopics)
+
+	var copies []forgeRepo
+	for _, f := range forge.Configured(cfg) {
+		repo, exists, err := f.GetRepo(repoName)
+		if err != nil {
+			fmt.Printf("  Warning: %s repo lookup failed: %v\n", f.DisplayName(), err)
+			continue
+		}
+		if !exists {
+			continue
+		}
+		if syncTopics && f.SupportsSetting(config.SettingTopics) {
+			if repo.Topics, err = f.ListTopics(repoName); err != nil {
+				fmt.Printf("  Warning: %s topics lookup failed: %v\n", f.DisplayName(), err)
+				continue
+			}
+		}
+		copies = append(copies, forgeRepo{forge: f, repo: repo})
+	}
+
+	if syncTopics && topicsCache != nil {
+		if canonical, ok := canonicalFor(cfg, copies, config.SettingTopics, ms); ok {
+			topicsCache[repoName] = normalizeTopics(canonical.Topics)
+		}
+	}
+
+	for _, update := range planMetadataUpdates(cfg, copies, ms, detectedBranch) {
+		name := update.forge.DisplayName()
+		for _, change := range update.changes {
+			if dryRun {
+				fmt.Printf("  [DRY RUN] Would update %s %s for %s: %q -> %q\n", name, change.setting, repoName, change.from, change.to)
+			} else {
+				fmt.Printf("  %s %s for %s: %q -> %q\n", name, change.setting, repoName, change.from, change.to)
+			}
+		}
+		if dryRun {
+			continue
+		}
+		if !update.forge.HasToken() {
+			fmt.Printf("  Warning: No %s token; cannot update settings\n", name)
+			continue
+		}
+		if err := update.forge.UpdateSettings(repoName, update.settings); err != nil {
+			fmt.Printf("  Warning: Failed to update %s settings: %v\n", name, err)
+		} else {
+			fmt.Printf("  Updated %s settings for %s\n", name, repoName)
+		}
+	}
+}
+
+// effectiveMetadataSync returns the configured metadata sync, or one that only
+// reconciles the default branch
+func effectiveMetadataSync(cfg *config.Config) *config.MetadataSync {
+	if cfg.MetadataSync != nil {
+		return cfg.MetadataSync
+	}
+	return &config.MetadataSync{Fields: []string{config.SettingDefaultBranch}}
+}
+
+// planMetadataUpdates computes the setting changes needed to bring every copy
+// of a repository in line with the canonical values. detectedBranch is the
+// fallback default branch when no forge reports one.
+func planMetadataUpdates(cfg *config.Config, copies []forgeRepo, ms *config.MetadataSync, detectedBranch string) []metadataUpdate {
+	if len(copies) < 2 {
+		return nil
+	}
+
+	updates := make([]metadataUpdate, len(copies))
+	for i, c := range copies {
+		updates[i].forge = c.forge
+	}
+
+	for _, setting := range ms.EnabledFields() {
+		canonical, ok := canonicalFor(cfg, copies, setting, ms)
+		if !ok && setting == config.SettingDefaultBranch && detectedBranch != "" {
+			canonical, ok = forge.Repository{DefaultBranch: detectedBranch}, true
+		}
+		if !ok {
+			continue
+		}
+		want := settingValue(canonical, setting)
+		for i, c := range copies {
+			if !c.forge.SupportsSetting(setting) {
+				continue
+			}
+			if have := settingValue(c.repo, setting); have != want {
+				updates[i].changes = append(updates[i].changes, settingChange{setting: setting, from: have, to: want})
+				setSetting(&updates[i].settings, canonical, setting)
+			}
+		}
+	}
+
+	var result []metadataUpdate
+	for _, update := range updates {
+		if !update.settings.IsEmpty() {
+			result = append(result, update)
+		}
+	}
+	return result
+}
+
+// canonicalFor returns a repository holding the canonical value of a setting.
+// With the union topics policy, that is a synthetic repository with the topics
+// of all forges. Visibility is canonical only when a copy is private, so that
+// public copies are made private but no repository is ever made public. The
+// default branch follows the configuration order of the organizations unless
+// a precedence is configured, like the branch detected from the remotes.
+func canonicalFor(cfg *config.Config, copies []forgeRepo, setting string, ms *config.MetadataSync) (forge.Repository, bool) {
+	if setting == config.SettingVisibility {
+		for _, c := range copies {
+			if c.forge.SupportsSetting(setting) && c.repo.Private {
+				return forge.Repository{Private: true}, true
+			}
+		}
+		return forge.Repository{}, false
+	}
+	if setting == config.SettingTopics && ms.TopicsPolicy == config.TopicsPolicyUnion {
+		var topics []string
+		for _, c := range copies {
+			if c.forge.SupportsSetting(setting) {
+				topics = append(topics, c.repo.Topics...)
+			}
+		}
+		topics = normalizeTopics(topics)
+		return forge.Repository{Topics: topics}, len(topics) > 0
+	}
+	precedence := ms.PrecedenceFor(setting)
+	if setting == config.SettingDefaultBranch && len(precedence) == 0 {
+		copies = inConfigOrder(cfg, copies)
+	}
+	return canonicalSetting(copies, setting, precedence)
+}
+
+// inConfigOrder sorts copies by the position of their organization in the
+// configuration, the primary organization first
+func inConfigOrder(cfg *config.Config, copies []forgeRepo) []forgeRepo {
+	position := func(c forgeRepo) int {
+		for i := range cfg.Organizations {
+			if c.forge.Organization() == &cfg.Organizations[i] {
+				return i
+			}
+		}
+		return len(cfg.Organizations)
+	}
+	ordered := append([]forgeRepo(nil), copies...)
+	sort.SliceStable(ordered, func(i, j int) bool { return position(ordered[i]) < position(ordered[j]) })
+	return ordered
+}
+
+// canonicalSetting returns the repository whose value of a setting wins. The
+// forges named in precedence come first, the others follow in forge
+// precedence order. Empty homepages, topic lists and default branches never
+// win, so that a forge lacking the value does not clear it elsewhere.
+func canonicalSetting(copies []forgeRepo, setting string, precedence []string) (forge.Repository, bool) {
+	ordered := append([]forgeRepo(nil), copies...)
+	rank := func(c forgeRepo) int {
+		for i, forgeType := range precedence {
+			if c.forge.Type() == forgeType {
+				return i
+			}
+		}
+		return len(precedence)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return rank(ordered[i]) < rank(ordered[j]) })
+
+	for _, c := range ordered {
+		if !c.forge.SupportsSetting(setting) {
+			continue
+		}
+		switch setting {
+		case config.SettingHomepage, config.SettingTopics, config.SettingDefaultBranch:
+			if settingValue(c.repo, setting) == "" {
+				continue
+			}
+		}
+		return c.repo, true
+	}
+	return forge.Repository{}, false
+}
+
+// settingValue returns a comparable string form of a repository setting
+func settingValue(repo forge.Repository, setting string) string {
+	switch setting {
+	case config.SettingHomepage:
+		return strings.TrimSpace(repo.Homepage)
+	case config.SettingTopics:
+		return strings.Join(normalizeTopics(repo.Topics), ",")
+	case config.SettingArchived:
+		return strconv.FormatBool(repo.Archived)
+	case config.SettingDefaultBranch:
+		return repo.DefaultBranch
+	case config.SettingHasIssues:
+		return strconv.FormatBool(repo.HasIssues)
+	case config.SettingHasWiki:
+		return strconv.FormatBool(repo.HasWiki)
+	case config.SettingVisibility:
+		if repo.Private {
+			return "private"
+		}
+		return "public"
+	}
+	return ""
+}
+
+// setSetting copies one setting of the canonical repository into an update
+func setSetting(settings *forge.RepoSettings, canonical forge.Repository, setting string) {
+	switch setting {
+	case config.SettingHomepage:
+		homepage := strings.TrimSpace(canonical.Homepage)
+		settings.Homepage = &homepage
+	case config.SettingTopics:
+		settings.Topics = normalizeTopics(canonical.Topics)
+	case config.SettingArchived:
+		archived := canonical.Archived
+		settings.Archived = &archived
+	case config.SettingDefaultBranch:
+		branch := canonical.DefaultBranch
+		settings.DefaultBranch = &branch
+	case config.SettingHasIssues:
+		hasIssues := canonical.HasIssues
+		settings.HasIssues = &hasIssues
+	case config.SettingHasWiki:
+		hasWiki := canonical.HasWiki
+		settings.HasWiki = &hasWiki
+	case config.SettingVisibility:
+		private := canonical.Private
+		settings.Private = &private
+	}
+}
+
+// normalizeTopics lowercases, deduplicates and sorts topics, as forges store
+// them case-insensitively and in no particular order
+func normalizeTopics(topics []string) []string {
+	seen := make(map[string]bool, len(topics))
+	result := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if topic != "" && !seen[topic] {
+			seen[topic] = true
+			result = append(result, topic)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// HandleSyncMetadata reconciles repository settings without syncing git data.
+// It works on the given repository, or on all configured and discovered ones.
+func HandleSyncMetadata(cfg *config.Config, flags *Flags) int {
+	if cfg.MetadataSync == nil {
+		// Running the command explicitly enables all settings with the default precedence
+		cfg.MetadataSync = &config.MetadataSync{}
+	}
+
+	repoNames := []string{flags.SyncRepo}
+	if flags.SyncRepo == "" {
+		var err error
+		repoNames, err = getAllRepositories(cfg, includePrivateRepos(cfg, flags))
+		if err != nil {
+			fmt.Printf("ERROR: Failed to get repositories: %v\n", err)
+			return 1
+		}
+		sort.Strings(repoNames)
+	}
+
+	topicsCache := loadTopicsCache(flags.WorkDir)
+	for i, repoName := range repoNames {
+		fmt.Printf("\n[%d/%d] Reconciling settings of %s...\n", i+1, len(repoNames), repoName)
+		syncRepoMetadata(cfg, flags.DryRun, repoName, "", topicsCache)
+	}
+	saveMetadataCaches(cfg, flags, topicsCache)
+	return 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// settingsForge is a stub forge with a fixed set of supported settings
+type settingsForge struct {
+	forge.Forge
+	forgeType string
+	supported map[string]bool // nil means all settings
+	org       *config.Organization
+}
+
+func (f *settingsForge) Type() string                       { return f.forgeType }
+func (f *settingsForge) DisplayName() string                { return f.forgeType }
+func (f *settingsForge) Organization() *config.Organization { return f.org }
+
+func (f *settingsForge) SupportsSetting(setting string) bool {
+	return f.supported == nil || f.supported[setting]
+}
+
+func TestPlanMetadataUpdates_UsesPerFieldPrecedence(t *testing.T) {
+	t.Parallel()
+
+	codeberg := &settingsForge{forgeType: forge.TypeCodeberg}
+	github := &settingsForge{forgeType: forge.TypeGitHub}
+	copies := []forgeRepo{
+		{forge: codeberg, repo: forge.Repository{Archived: true, Homepage: "", Topics: []string{"Go", "cli"}, HasIssues: true}},
+		{forge: github, repo: forge.Repository{Homepage: "https://example.org", Topics: []string{"cli", "go"}, HasIssues: false}},
+	}
+	ms := &config.MetadataSync{
+		Fields:     []string{config.SettingArchived, config.SettingHomepage, config.SettingTopics, config.SettingHasIssues},
+		Precedence: map[string][]string{config.SettingHasIssues: {forge.TypeGitHub}},
+	}
+
+	updates := planMetadataUpdates(&config.Config{}, copies, ms, "")
+	if len(updates) != 2 {
+		t.Fatalf("expected updates for both forges, got %#v", updates)
+	}
+
+	// Codeberg wins the archived flag by default and takes GitHub's homepage,
+	// since an empty homepage never wins. Topics only differ in case and order.
+	gh := updates[1]
+	if gh.forge != github || gh.settings.Archived == nil || !*gh.settings.Archived || gh.settings.Topics != nil {
+		t.Fatalf("unexpected GitHub update %#v", gh.settings)
+	}
+	cb := updates[0]
+	if cb.settings.Homepage == nil || *cb.settings.Homepage != "https://example.org" {
+		t.Fatalf("expected Codeberg to get the GitHub homepage, got %#v", cb.settings)
+	}
+	if cb.settings.HasIssues == nil || *cb.settings.HasIssues {
+		t.Fatalf("expected GitHub to win has_issues, got %#v", cb.settings)
+	}
+}
+
+func TestPlanMetadataUpdates_SkipsUnsupportedSettings(t *testing.T) {
+	t.Parallel()
+
+	github := &settingsForge{forgeType: forge.TypeGitHub}
+	sourcehut := &settingsForge{forgeType: forge.TypeSourceHut, supported: map[string]bool{config.SettingDefaultBranch: true}}
+	copies := []forgeRepo{
+		{forge: github, repo: forge.Repository{Archived: true, DefaultBranch: "main"}},
+		{forge: sourcehut, repo: forge.Repository{DefaultBranch: "master"}},
+	}
+
+	updates := planMetadataUpdates(&config.Config{}, copies, &config.MetadataSync{}, "")
+	if len(updates) != 1 || updates[0].forge != sourcehut {
+		t.Fatalf("expected a single SourceHut update, got %#v", updates)
+	}
+	want := []settingChange{{setting: config.SettingDefaultBranch, from: "master", to: "main"}}
+	if !reflect.DeepEqual(updates[0].changes, want) {
+		t.Fatalf("changes = %#v, want %#v", updates[0].changes, want)
+	}
+}
+
+func TestPlanMetadataUpdates_UnionTopicsPolicy(t *testing.T) {
+	t.Parallel()
+
+	codeberg := &settingsForge{forgeType: forge.TypeCodeberg}
+	github := &settingsForge{forgeType: forge.TypeGitHub}
+	copies := []forgeRepo{
+		{forge: codeberg, repo: forge.Repository{Topics: []string{"go", "cli"}}},
+		{forge: github, repo: forge.Repository{Topics: []string{"Go", "sync"}}},
+	}
+	ms := &config.MetadataSync{Fields: []string{config.SettingTopics}, TopicsPolicy: config.TopicsPolicyUnion}
+
+	updates := planMetadataUpdates(&config.Config{}, copies, ms, "")
+	if len(updates) != 2 {
+		t.Fatalf("expected both forges to be updated, got %#v", updates)
+	}
+	want := []string{"cli", "go", "sync"}
+	for _, update := range updates {
+		if !reflect.DeepEqual(update.settings.Topics, want) {
+			t.Fatalf("%s topics = %v, want %v", update.forge.DisplayName(), update.settings.Topics, want)
+		}
+	}
+
+	// Primary wins by default: Codeberg comes first
+	ms.TopicsPolicy = ""
+	updates = planMetadataUpdates(&config.Config{}, copies, ms, "")
+	if len(updates) != 1 || !reflect.DeepEqual(updates[0].settings.Topics, []string{"cli", "go"}) {
+		t.Fatalf("unexpected primary-wins updates %#v", updates)
+	}
+}
+
+func TestPlanMetadataUpdates_PrimaryDefaultBranchWins(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Organizations: []config.Organization{
+		{Host: "git@github.com", Name: "me"},
+		{Host: "git@codeberg.org", Name: "me"},
+	}}
+	github := &settingsForge{forgeType: forge.TypeGitHub, org: &cfg.Organizations[0]}
+	codeberg := &settingsForge{forgeType: forge.TypeCodeberg, org: &cfg.Organizations[1]}
+	// Copies come in registry order, Codeberg first
+	copies := []forgeRepo{
+		{forge: codeberg, repo: forge.Repository{DefaultBranch: "master"}},
+		{forge: github, repo: forge.Repository{DefaultBranch: "main"}},
+	}
+	ms := &config.MetadataSync{Fields: []string{config.SettingDefaultBranch}}
+
+	updates := planMetadataUpdates(cfg, copies, ms, "main")
+	if len(updates) != 1 || updates[0].forge != codeberg || *updates[0].settings.DefaultBranch != "main" {
+		t.Fatalf("expected Codeberg to take GitHub's default branch, got %#v", updates)
+	}
+
+	ms.Precedence = map[string][]string{config.SettingDefaultBranch: {forge.TypeCodeberg}}
+	updates = planMetadataUpdates(cfg, copies, ms, "main")
+	if len(updates) != 1 || updates[0].forge != github || *updates[0].settings.DefaultBranch != "master" {
+		t.Fatalf("expected the configured precedence to win, got %#v", updates)
+	}
+}
+
+func TestPlanMetadataUpdates_FallsBackToDetectedDefaultBranch(t *testing.T) {
+	t.Parallel()
+
+	github := &settingsForge{forgeType: forge.TypeGitHub}
+	sourcehut := &settingsForge{forgeType: forge.TypeSourceHut, supported: map[string]bool{config.SettingDefaultBranch: true}}
+	copies := []forgeRepo{
+		{forge: github, repo: forge.Repository{}},
+		{forge: sourcehut, repo: forge.Repository{}},
+	}
+	ms := effectiveMetadataSync(&config.Config{})
+
+	updates := planMetadataUpdates(&config.Config{}, copies, ms, "trunk")
+	if len(updates) != 2 {
+		t.Fatalf("expected both forges to get the detected branch, got %#v", updates)
+	}
+	for _, update := range updates {
+		if update.settings.DefaultBranch == nil || *update.settings.DefaultBranch != "trunk" {
+			t.Fatalf("%s update = %#v", update.forge.DisplayName(), update.settings)
+		}
+		if update.settings.Archived != nil || update.settings.HasIssues != nil {
+			t.Fatalf("expected only the default branch without metadata_sync, got %#v", update.settings)
+		}
+	}
+}
+
+func TestPlanMetadataUpdates_VisibilityOnlyBecomesPrivate(t *testing.T) {
+	t.Parallel()
+
+	codeberg := &settingsForge{forgeType: forge.TypeCodeberg}
+	github := &settingsForge{forgeType: forge.TypeGitHub}
+	ms := &config.MetadataSync{Fields: []string{config.SettingVisibility}}
+
+	// Codeberg wins by default, yet its public copy is never published
+	// elsewhere: the private GitHub copy makes Codeberg private instead
+	updates := planMetadataUpdates(&config.Config{}, []forgeRepo{
+		{forge: codeberg, repo: forge.Repository{}},
+		{forge: github, repo: forge.Repository{Private: true}},
+	}, ms, "")
+	if len(updates) != 1 || updates[0].forge != codeberg || updates[0].settings.Private == nil || !*updates[0].settings.Private {
+		t.Fatalf("expected Codeberg to be made private, got %#v", updates)
+	}
+
+	updates = planMetadataUpdates(&config.Config{}, []forgeRepo{
+		{forge: codeberg, repo: forge.Repository{}},
+		{forge: github, repo: forge.Repository{}},
+	}, ms, "")
+	if len(updates) != 0 {
+		t.Fatalf("expected no change for public copies, got %#v", updates)
+	}
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// syncNativeMirrors makes the Codeberg and Gitea/Forgejo copies of a
+// repository in native mirror mode pull mirrors of the source forge. Missing
+// mirrors are created and intervals adjusted; an existing regular repository
+// is only replaced by a mirror if convert is set, as that deletes its issues
+// and pull requests. Its git data is bundled to the work directory first.
+// Does nothing for repositories in push mode.
+func syncNativeMirrors(cfg *config.Config, flags *Flags, repoName string, convert bool) {
+	if cfg.MirrorModeOf(repoName) != config.MirrorModeNative {
+		return
+	}
+
+	sourceOrg := cfg.NativeMirrorSource()
+	if sourceOrg == nil {
+		fmt.Println("  Warning: No forge for native mirrors to pull from")
+		return
+	}
+	source, err := forge.New(sourceOrg)
+	if err != nil {
+		fmt.Printf("  Warning: %v\n", err)
+		return
+	}
+	sourceRepo, exists, err := source.GetRepo(repoName)
+	if err != nil || !exists {
+		fmt.Printf("  Warning: %s is not on %s, cannot set up native mirrors (%v)\n", repoName, source.DisplayName(), err)
+		return
+	}
+	if sourceRepo.Private {
+		fmt.Printf("  Warning: %s is private on %s; native mirrors need public sources\n", repoName, source.DisplayName())
+		return
+	}
+
+	opts := forge.PullMirrorOptions{
+		CloneURL:    forge.CloneURL(sourceOrg, repoName),
+		Description: sourceRepo.Description,
+		Interval:    cfg.MirrorInterval(),
+	}
+	if convert && !flags.DryRun {
+		if err := sync.CheckRemote(opts.CloneURL); err != nil {
+			fmt.Printf("  Warning: %v\n  Not replacing regular repositories with pull mirrors of an unreachable source\n", err)
+			convert = false
+		}
+	}
+	for _, f := range forge.Configured(cfg) {
+		target, ok := f.(forge.PullMirrorer)
+		if !ok || target.Organization().BackupLocation {
+			continue
+		}
+		bundle := func() (string, error) {
+			bundlePath := filepath.Join(flags.WorkDir, deletedBundleDir, fmt.Sprintf("%s-%s-%s.bundle", repoName, target.Type(), time.Now().Format("20060102-150405")))
+			return bundlePath, sync.BundleRemoteRepository(target.Organization(), repoName, bundlePath)
+		}
+		if err := ensurePullMirror(target, repoName, opts, flags.DryRun, convert, bundle); err != nil {
+			fmt.Printf("  Warning: Failed to set up the %s pull mirror of %s: %v\n", target.DisplayName(), repoName, err)
+		}
+	}
+}
+
+// ensurePullMirror creates, converts or adjusts the pull mirror on one forge.
+// Before a regular repository is deleted for conversion, bundle writes its
+// git data and returns the bundle path.
+func ensurePullMirror(target forge.PullMirrorer, repoName string, opts forge.PullMirrorOptions, dryRun, convert bool, bundle func() (string, error)) error {
+	name := target.DisplayName()
+	if !target.HasToken() {
+		return fmt.Errorf("no %s token", name)
+	}
+	current, exists, err := target.GetRepo(repoName)
+	if err != nil {
+		return err
+	}
+	lost := "" // what a failed conversion loses, set once the repository is deleted
+
+	switch {
+	case exists && current.Mirror:
+		if current.MirrorInterval == opts.Interval {
+			return nil
+		}
+		if dryRun {
+			fmt.Printf("  [DRY RUN] Would change the %s mirror interval of %s: %s -> %s\n", name, repoName, current.MirrorInterval, opts.Interval)
+			return nil
+		}
+		if err := target.SetPullMirrorInterval(repoName, opts.Interval); err != nil {
+			return err
+		}
+		fmt.Printf("  Changed the %s mirror interval of %s: %s -> %s\n", name, repoName, current.MirrorInterval, opts.Interval)
+		return nil
+
+	case exists && !convert:
+		fmt.Printf("  %s has a regular %s repository; run 'gitsyncer sync native-mirrors %s --convert' to replace it with a pull mirror\n", name, repoName, repoName)
+		return nil
+
+	case exists:
+		if dryRun {
+			fmt.Printf("  [DRY RUN] Would bundle and replace the %s repository %s with a pull mirror of %s\n", name, repoName, opts.CloneURL)
+			return nil
+		}
+		bundlePath, err := bundle()
+		switch {
+		case errors.Is(err, sync.ErrEmptyRepository):
+			lost = "it had no branches or tags"
+		case err != nil:
+			return fmt.Errorf("failed to bundle the regular repository, keeping it: %w", err)
+		default:
+			fmt.Printf("  Wrote a bundle of the %s repository %s to %s\n", name, repoName, bundlePath)
+			lost = fmt.Sprintf("its branches and tags are kept in %s", bundlePath)
+		}
+		if err := target.DeleteRepo(repoName); err != nil {
+			return fmt.Errorf("failed to delete the regular repository: %w", err)
+		}
+		fmt.Printf("  Deleted the regular %s repository %s\n", name, repoName)
+	}
+
+	if dryRun {
+		fmt.Printf("  [DRY RUN] Would create a %s pull mirror of %s every %s\n", name, opts.CloneURL, opts.Interval)
+		return nil
+	}
+	if err := target.CreatePullMirror(repoName, opts); err != nil {
+		if lost != "" {
+			return fmt.Errorf("the regular repository was deleted, but the pull mirror could not be created: %w; %s is gone from %s with its issues, pull requests and wiki, %s", err, repoName, name, lost)
+		}
+		return err
+	}
+	fmt.Printf("  Created a %s pull mirror of %s every %s\n", name, opts.CloneURL, opts.Interval)
+	return nil
+}
+
+// nativeMirrorRepos returns the repositories in native mirror mode
+func nativeMirrorRepos(cfg *config.Config) []string {
+	var repoNames []string
+	for repoName := range cfg.MirrorMode {
+		if cfg.MirrorModeOf(repoName) == config.MirrorModeNative {
+			repoNames = append(repoNames, repoName)
+		}
+	}
+	sort.Strings(repoNames)
+	return repoNames
+}
+
+// HandleSyncNativeMirrors sets up the pull mirrors of the given repository, or
+// of all repositories in native mirror mode, without syncing git data
+func HandleSyncNativeMirrors(cfg *config.Config, flags *Flags, convert bool) int {
+	repoNames := nativeMirrorRepos(cfg)
+	if flags.SyncRepo != "" {
+		if cfg.MirrorModeOf(flags.SyncRepo) != config.MirrorModeNative {
+			fmt.Printf("ERROR: %s is not in native mirror mode; set \"mirror_mode\": {%q: %q}\n", flags.SyncRepo, flags.SyncRepo, config.MirrorModeNative)
+			return 1
+		}
+		repoNames = []string{flags.SyncRepo}
+	}
+	if len(repoNames) == 0 {
+		fmt.Println("No repositories in native mirror mode.")
+		return 0
+	}
+
+	for i, repoName := range repoNames {
+		fmt.Printf("\n[%d/%d] Setting up native mirrors of %s...\n", i+1, len(repoNames), repoName)
+		syncNativeMirrors(cfg, flags, repoName, convert)
+	}
+	return 0
+}
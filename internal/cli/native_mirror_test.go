@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// mirrorForge is a stub forge holding a single repository
+type mirrorForge struct {
+	forge.Forge
+	repo      *forge.Repository
+	calls     []string
+	created   forge.PullMirrorOptions
+	createErr error // returned by CreatePullMirror if set
+}
+
+func (f *mirrorForge) DisplayName() string { return "Codeberg" }
+func (f *mirrorForge) HasToken() bool      { return true }
+
+func (f *mirrorForge) GetRepo(repoName string) (forge.Repository, bool, error) {
+	if f.repo == nil {
+		return forge.Repository{}, false, nil
+	}
+	return *f.repo, true, nil
+}
+
+func (f *mirrorForge) bundle() (string, error) {
+	f.calls = append(f.calls, "bundle")
+	return "/work/.gitsyncer-deleted/tool.bundle", nil
+}
+
+func (f *mirrorForge) DeleteRepo(repoName string) error {
+	f.calls = append(f.calls, "delete")
+	f.repo = nil
+	return nil
+}
+
+func (f *mirrorForge) CreatePullMirror(repoName string, opts forge.PullMirrorOptions) error {
+	f.calls = append(f.calls, "create")
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.created = opts
+	f.repo = &forge.Repository{Name: repoName, Mirror: true, MirrorURL: opts.CloneURL, MirrorInterval: opts.Interval}
+	return nil
+}
+
+func (f *mirrorForge) SetPullMirrorInterval(repoName string, interval time.Duration) error {
+	f.calls = append(f.calls, "interval")
+	f.repo.MirrorInterval = interval
+	return nil
+}
+
+func TestEnsurePullMirror(t *testing.T) {
+	t.Parallel()
+
+	opts := forge.PullMirrorOptions{CloneURL: "https://github.com/team/tool.git", Interval: 8 * time.Hour}
+	tests := []struct {
+		name      string
+		repo      *forge.Repository
+		dryRun    bool
+		convert   bool
+		wantCalls string
+	}{
+		{name: "missing", wantCalls: "create"},
+		{name: "missing dry run", dryRun: true},
+		{name: "up to date", repo: &forge.Repository{Mirror: true, MirrorInterval: 8 * time.Hour}},
+		{name: "other interval", repo: &forge.Repository{Mirror: true, MirrorInterval: time.Hour}, wantCalls: "interval"},
+		{name: "regular repo kept", repo: &forge.Repository{}},
+		{name: "regular repo converted", repo: &forge.Repository{}, convert: true, wantCalls: "bundle,delete,create"},
+		{name: "conversion dry run", repo: &forge.Repository{}, convert: true, dryRun: true},
+	}
+	for _, tt := range tests {
+		target := &mirrorForge{repo: tt.repo}
+		if err := ensurePullMirror(target, "tool", opts, tt.dryRun, tt.convert, target.bundle); err != nil {
+			t.Fatalf("%s: ensurePullMirror() error = %v", tt.name, err)
+		}
+		if got := strings.Join(target.calls, ","); got != tt.wantCalls {
+			t.Errorf("%s: calls = %q, want %q", tt.name, got, tt.wantCalls)
+		}
+		if strings.HasSuffix(tt.wantCalls, "create") && target.created != opts {
+			t.Errorf("%s: created mirror with %#v", tt.name, target.created)
+		}
+	}
+}
+
+func TestEnsurePullMirror_ConversionKeepsBundle(t *testing.T) {
+	t.Parallel()
+
+	opts := forge.PullMirrorOptions{CloneURL: "https://github.com/team/tool.git", Interval: 8 * time.Hour}
+
+	// Without a bundle the regular repository is not deleted
+	target := &mirrorForge{repo: &forge.Repository{}}
+	failingBundle := func() (string, error) { return "", errors.New("unreachable") }
+	if err := ensurePullMirror(target, "tool", opts, false, true, failingBundle); err == nil || target.repo == nil {
+		t.Fatalf("expected the repository to be kept, got %v, calls %v", err, target.calls)
+	}
+
+	// A failed mirror creation names what was lost and where the bundle is
+	target = &mirrorForge{repo: &forge.Repository{}, createErr: errors.New("quota exceeded")}
+	err := ensurePullMirror(target, "tool", opts, false, true, target.bundle)
+	if err == nil || !strings.Contains(err.Error(), "issues, pull requests and wiki") || !strings.Contains(err.Error(), "tool.bundle") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestDescribePullMirror(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	repo := forge.Repository{Mirror: true, MirrorURL: "https://github.com/team/tool.git", MirrorInterval: 8 * time.Hour, MirrorUpdated: now.Add(-90 * time.Minute)}
+	want := "pull mirror of https://github.com/team/tool.git every 8h0m0s, last updated 2026-10-18 10:30 (1h30m0s ago)"
+	if got := describePullMirror(repo, true, now); got != want {
+		t.Fatalf("describePullMirror() = %q, want %q", got, want)
+	}
+	if got := describePullMirror(forge.Repository{}, true, now); !strings.Contains(got, "not a pull mirror") {
+		t.Fatalf("describePullMirror(regular) = %q", got)
+	}
+}
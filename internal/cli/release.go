@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 	"codeberg.org/snonux/gitsyncer/internal/release"
 )
 
@@ -24,23 +25,12 @@ func isVersionTag(tag string) bool {
 // HandleCheckReleases checks for version tags without releases and creates them with confirmation
 func HandleCheckReleases(cfg *config.Config, flags *Flags) int {
 	// Get all repositories from work directory
-	entries, err := os.ReadDir(flags.WorkDir)
+	repositories, err := workDirRepositories(flags.WorkDir)
 	if err != nil {
 		fmt.Printf("Error reading work directory %s: %v\n", flags.WorkDir, err)
 		return 1
 	}
 
-	var repositories []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			// Check if it's a git repository
-			gitPath := filepath.Join(flags.WorkDir, entry.Name(), ".git")
-			if _, err := os.Stat(gitPath); err == nil {
-				repositories = append(repositories, entry.Name())
-			}
-		}
-	}
-
 	if len(repositories) == 0 {
 		fmt.Println("No repositories found in work directory")
 		return 1
@@ -63,23 +53,25 @@ func HandleCheckReleasesForRepos(cfg *config.Config, flags *Flags, repositories
 
 	// Load persistent AI release notes cache
 	cacheFile := filepath.Join(flags.WorkDir, ".gitsyncer-ai-release-notes-cache.json")
-	aiReleaseNotesCache := loadAIReleaseNotesCache(cacheFile)
-	initialCacheSize := len(aiReleaseNotesCache)
-
-	// Track failed AI generations
-	failedAIGenerations := []string{}
+	notes := &releaseNotesSource{
+		manager:   releaseManager,
+		flags:     flags,
+		cacheFile: cacheFile,
+		cache:     loadAIReleaseNotesCache(cacheFile),
+	}
+	initialCacheSize := len(notes.cache)
 
 	// Print summary at the end
 	defer func() {
-		if len(aiReleaseNotesCache) > initialCacheSize {
+		if len(notes.cache) > initialCacheSize {
 			fmt.Printf("\nAI release notes cache updated: %d new entries added (total: %d entries)\n",
-				len(aiReleaseNotesCache)-initialCacheSize, len(aiReleaseNotesCache))
+				len(notes.cache)-initialCacheSize, len(notes.cache))
 			fmt.Printf("Cache file: %s\n", cacheFile)
 		}
 
-		if len(failedAIGenerations) > 0 {
-			fmt.Printf("\n⚠️  AI release notes generation failed for %d releases:\n", len(failedAIGenerations))
-			for _, failed := range failedAIGenerations {
+		if len(notes.failed) > 0 {
+			fmt.Printf("\n⚠️  AI release notes generation failed for %d releases:\n", len(notes.failed))
+			for _, failed := range notes.failed {
 				fmt.Printf("  - %s\n", failed)
 			}
 			fmt.Println("\nThese releases were skipped. Their cache entries were cleared.")
@@ -87,68 +79,20 @@ func HandleCheckReleasesForRepos(cfg *config.Config, flags *Flags, repositories
 		}
 	}()
 
-	// Set tokens from config with fallback to environment variables and files
-	githubOrg := cfg.FindGitHubOrg()
-	if githubOrg != nil {
-		fmt.Printf("Found GitHub org: %s\n", githubOrg.Name)
-
-		// Try config token first, then fallback to env var and file
-		token := githubOrg.GitHubToken
-		if token == "" {
-			// Try environment variable
-			token = os.Getenv("GITHUB_TOKEN")
-			if token == "" {
-				// Try token file
-				home, err := os.UserHomeDir()
-				if err == nil {
-					tokenFile := filepath.Join(home, ".gitsyncer_github_token")
-					data, err := os.ReadFile(tokenFile)
-					if err == nil {
-						token = strings.TrimSpace(string(data))
-					}
-				}
-			}
+	// Tokens are loaded from config with fallback to environment variables and files
+	var forges []forge.Forge
+	for _, f := range forge.Configured(cfg) {
+		if f.Organization().Name == "" {
+			continue
 		}
-
-		if token != "" {
-			releaseManager.SetGitHubToken(token)
-		} else {
-			fmt.Println("WARNING: No GitHub token found - cannot create GitHub releases")
+		fmt.Printf("Found %s org: %s\n", f.DisplayName(), f.Organization().Name)
+		if !f.HasToken() {
+			fmt.Printf("WARNING: No %s token found - cannot create %s releases\n", f.DisplayName(), f.DisplayName())
 		}
-	} else {
-		fmt.Println("No GitHub organization found in config")
+		forges = append(forges, f)
 	}
-
-	codebergOrg := cfg.FindCodebergOrg()
-	if codebergOrg != nil {
-		fmt.Printf("Found Codeberg org: %s\n", codebergOrg.Name)
-
-		// Try config token first, then fallback to env var and file
-		token := codebergOrg.CodebergToken
-		if token == "" {
-			// Try environment variable
-			token = os.Getenv("CODEBERG_TOKEN")
-			if token == "" {
-				// Try token file
-				home, err := os.UserHomeDir()
-				if err == nil {
-					tokenFile := filepath.Join(home, ".gitsyncer_codeberg_token")
-					data, err := os.ReadFile(tokenFile)
-					if err == nil {
-						token = strings.TrimSpace(string(data))
-					}
-				}
-			}
-		}
-
-		if token != "" {
-			releaseManager.SetCodebergToken(token)
-			fmt.Printf("  Codeberg token loaded (length: %d)\n", len(token))
-		} else {
-			fmt.Println("WARNING: No Codeberg token found - cannot create Codeberg releases")
-		}
-	} else {
-		fmt.Println("No Codeberg organization found in config")
+	if len(forges) == 0 {
+		fmt.Println("No forge organizations found in config")
 	}
 
 	// Process the specified repositories
@@ -182,406 +126,199 @@ func HandleCheckReleasesForRepos(cfg *config.Config, flags *Flags, repositories
 			}
 		}
 
-		// Check GitHub releases if GitHub is configured
-		var missingGitHub []string
-		githubOrg := cfg.FindGitHubOrg()
-		if githubOrg != nil && githubOrg.Name != "" {
-			githubReleases, err := releaseManager.GetGitHubReleases(githubOrg.Name, repoName)
-			if err != nil {
-				fmt.Printf("  Error checking GitHub releases: %v\n", err)
-			} else {
-				missingGitHub = releaseManager.FindMissingReleases(localTags, githubReleases)
-				// Filter out tags that should be skipped per config
-				if len(missingGitHub) > 0 {
-					var filtered []string
-					var skipped []string
-					for _, t := range missingGitHub {
-						if cfg.ShouldSkipRelease(repoName, t) {
-							skipped = append(skipped, t)
-						} else {
-							filtered = append(filtered, t)
-						}
-					}
-					if len(skipped) > 0 {
-						fmt.Printf("  Skipping GitHub releases per config for tags: %s\n", strings.Join(skipped, ", "))
-					}
-					missingGitHub = filtered
-					if len(missingGitHub) > 0 {
-						fmt.Printf("  Missing GitHub releases: %s\n", strings.Join(missingGitHub, ", "))
-					}
-				}
+		repo := releaseRepo{name: repoName, path: repoPath, localTags: localTags}
+		for _, f := range forges {
+			createMissingReleases(cfg, flags, f, repo, notes)
+		}
+
+		// Update existing releases if requested
+		if flags.UpdateReleases {
+			for _, f := range forges {
+				updateExistingReleases(flags, f, repo, notes)
 			}
 		}
+	}
+
+	return 0
+}
+
+// releaseRepo describes the local clone whose tags are released
+type releaseRepo struct {
+	name      string
+	path      string
+	localTags []string
+}
+
+// createMissingReleases creates releases on a forge for local version tags without one
+func createMissingReleases(cfg *config.Config, flags *Flags, f forge.Forge, repo releaseRepo, notes *releaseNotesSource) {
+	name := f.DisplayName()
+	owner := f.Organization().Name
+
+	releases, err := f.ListReleases(repo.name)
+	if err != nil {
+		fmt.Printf("  Error checking %s releases: %v\n", name, err)
+		return
+	}
+
+	// Filter out tags that should be skipped per config
+	var missing, skipped []string
+	for _, t := range notes.manager.FindMissingReleases(repo.localTags, releases) {
+		if cfg.ShouldSkipRelease(repo.name, t) {
+			skipped = append(skipped, t)
+		} else {
+			missing = append(missing, t)
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("  Skipping %s releases per config for tags: %s\n", name, strings.Join(skipped, ", "))
+	}
+	if len(missing) == 0 {
+		return
+	}
+	fmt.Printf("  Missing %s releases: %s\n", name, strings.Join(missing, ", "))
+
+	for _, tag := range missing {
+		releaseNotes, ok := notes.forTag(repo, owner, tag, false)
+		if !ok {
+			continue
+		}
+
+		printReleaseNotes("Release Notes", owner, repo.name, tag, releaseNotes)
+
+		// Check if auto-create is enabled
+		createRelease := false
+		if flags.AutoCreateReleases {
+			fmt.Printf("  Auto-creating %s release for %s/%s tag %s\n", name, owner, repo.name, tag)
+			createRelease = true
+		} else {
+			createRelease = release.PromptConfirmation(fmt.Sprintf("Create %s release for %s/%s tag %s?", name, owner, repo.name, tag))
+		}
 
-		// Check Codeberg releases if Codeberg is configured
-		var missingCodeberg []string
-		codebergOrg := cfg.FindCodebergOrg()
-		if codebergOrg != nil && codebergOrg.Name != "" {
-			codebergReleases, err := releaseManager.GetCodebergReleases(codebergOrg.Name, repoName)
-			if err != nil {
-				fmt.Printf("  Error checking Codeberg releases: %v\n", err)
+		if createRelease {
+			if err := f.CreateRelease(repo.name, tag, releaseNotes); err != nil {
+				fmt.Printf("  Error creating %s release: %v\n", name, err)
 			} else {
-				missingCodeberg = releaseManager.FindMissingReleases(localTags, codebergReleases)
-				// Filter out tags that should be skipped per config
-				if len(missingCodeberg) > 0 {
-					var filtered []string
-					var skipped []string
-					for _, t := range missingCodeberg {
-						if cfg.ShouldSkipRelease(repoName, t) {
-							skipped = append(skipped, t)
-						} else {
-							filtered = append(filtered, t)
-						}
-					}
-					if len(skipped) > 0 {
-						fmt.Printf("  Skipping Codeberg releases per config for tags: %s\n", strings.Join(skipped, ", "))
-					}
-					missingCodeberg = filtered
-					if len(missingCodeberg) > 0 {
-						fmt.Printf("  Missing Codeberg releases: %s\n", strings.Join(missingCodeberg, ", "))
-					}
-				}
+				fmt.Printf("  Created %s release for tag %s\n", name, tag)
 			}
 		}
+	}
+}
 
-		// Create missing releases with confirmation
-		if len(missingGitHub) > 0 && githubOrg != nil {
-			for _, tag := range missingGitHub {
-				// Skip if configured to skip this repo/tag
-				if cfg.ShouldSkipRelease(repoName, tag) {
-					fmt.Printf("  Skipping GitHub release for %s:%s per config skip_releases\n", repoName, tag)
-					continue
-				}
-				// Get commits for this tag
-				commits, err := releaseManager.GetCommitsSinceTag(repoPath, "", tag)
-				if err != nil {
-					commits = []string{}
-				}
-
-				// Generate release notes
-				var releaseNotes string
-				if flags.AIReleaseNotes {
-					// Check cache first (unless --force is used)
-					cacheKey := fmt.Sprintf("%s:%s", repoName, tag)
-					if cachedNotes, exists := aiReleaseNotesCache[cacheKey]; exists && !flags.Force {
-						fmt.Printf("  Using cached AI release notes for %s\n", tag)
-						releaseNotes = cachedNotes
-					} else {
-						if flags.Force && aiReleaseNotesCache[cacheKey] != "" {
-							fmt.Printf("  Force regenerating AI release notes for %s (ignoring cache)\n", tag)
-						} else {
-							fmt.Printf("  Generating AI release notes for %s...\n", tag)
-						}
-						aiNotes, err := releaseManager.GenerateAIReleaseNotes(repoPath, repoName, tag, localTags, commits)
-						if err != nil {
-							fmt.Printf("  Warning: Failed to generate AI release notes: %v\n", err)
-							fmt.Printf("  Falling back to standard release notes\n")
-							releaseNotes = releaseManager.GenerateReleaseNotes(repoPath, tag, localTags)
-							// Clear cache on failure and track
-							delete(aiReleaseNotesCache, cacheKey)
-							failedAIGenerations = append(failedAIGenerations, fmt.Sprintf("%s/%s:%s", githubOrg.Name, repoName, tag))
-							// Save cache after clearing the failed entry
-							saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache)
-						} else {
-							releaseNotes = aiNotes
-							aiReleaseNotesCache[cacheKey] = aiNotes // Cache only on success
-							// Save cache immediately after successful generation
-							if err := saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache); err != nil {
-								fmt.Printf("  Warning: Failed to save cache: %v\n", err)
-							}
-							fmt.Printf("  AI release notes generated successfully and cached\n")
-						}
-					}
-				} else {
-					releaseNotes = releaseManager.GenerateReleaseNotes(repoPath, tag, localTags)
-				}
-
-				// Print release notes to stdout
-				fmt.Printf("\n%s\n", strings.Repeat("=", 70))
-				fmt.Printf("Release Notes for %s/%s tag %s:\n", githubOrg.Name, repoName, tag)
-				fmt.Printf("%s\n", strings.Repeat("-", 70))
-				fmt.Println(releaseNotes)
-				fmt.Printf("%s\n\n", strings.Repeat("=", 70))
-
-				msg := fmt.Sprintf("Create GitHub release for %s/%s tag %s?", githubOrg.Name, repoName, tag)
-
-				// Check if auto-create is enabled
-				createRelease := false
-				if flags.AutoCreateReleases {
-					fmt.Printf("  Auto-creating GitHub release for %s/%s tag %s\n", githubOrg.Name, repoName, tag)
-					createRelease = true
-				} else {
-					createRelease = release.PromptConfirmation(msg)
-				}
-
-				if createRelease {
-					if err := releaseManager.CreateGitHubRelease(githubOrg.Name, repoName, tag, releaseNotes); err != nil {
-						fmt.Printf("  Error creating GitHub release: %v\n", err)
-					} else {
-						fmt.Printf("  Created GitHub release for tag %s\n", tag)
-					}
-				}
-			}
+// updateExistingReleases regenerates the AI notes of existing version releases on a forge
+func updateExistingReleases(flags *Flags, f forge.Forge, repo releaseRepo, notes *releaseNotesSource) {
+	name := f.DisplayName()
+	owner := f.Organization().Name
+
+	releases, err := f.ListReleases(repo.name)
+	if err != nil || len(releases) == 0 {
+		return
+	}
+
+	fmt.Printf("\n  Updating existing %s releases...\n", name)
+	for _, tag := range releases {
+		// Only version tags get AI release notes
+		if !isVersionTag(tag) || !flags.AIReleaseNotes {
+			continue
 		}
 
-		if len(missingCodeberg) > 0 && codebergOrg != nil {
-			// Ensure Releases feature is enabled on Codeberg before creating releases
-			if err := releaseManager.EnsureCodebergReleasesEnabled(codebergOrg.Name, repoName); err != nil {
-				fmt.Printf("  Warning: Could not ensure Codeberg releases are enabled: %v\n", err)
-			}
-			for _, tag := range missingCodeberg {
-				// Skip if configured to skip this repo/tag
-				if cfg.ShouldSkipRelease(repoName, tag) {
-					fmt.Printf("  Skipping Codeberg release for %s:%s per config skip_releases\n", repoName, tag)
-					continue
-				}
-				// Get commits for this tag
-				commits, err := releaseManager.GetCommitsSinceTag(repoPath, "", tag)
-				if err != nil {
-					commits = []string{}
-				}
-
-				// Generate release notes
-				var releaseNotes string
-				if flags.AIReleaseNotes {
-					// Check cache first (unless --force is used)
-					cacheKey := fmt.Sprintf("%s:%s", repoName, tag)
-					if cachedNotes, exists := aiReleaseNotesCache[cacheKey]; exists && !flags.Force {
-						fmt.Printf("  Using cached AI release notes for %s\n", tag)
-						releaseNotes = cachedNotes
-					} else {
-						if flags.Force && aiReleaseNotesCache[cacheKey] != "" {
-							fmt.Printf("  Force regenerating AI release notes for %s (ignoring cache)\n", tag)
-						} else {
-							fmt.Printf("  Generating AI release notes for %s...\n", tag)
-						}
-						aiNotes, err := releaseManager.GenerateAIReleaseNotes(repoPath, repoName, tag, localTags, commits)
-						if err != nil {
-							fmt.Printf("  Warning: Failed to generate AI release notes: %v\n", err)
-							fmt.Printf("  Falling back to standard release notes\n")
-							releaseNotes = releaseManager.GenerateReleaseNotes(repoPath, tag, localTags)
-							// Clear cache on failure and track
-							delete(aiReleaseNotesCache, cacheKey)
-							failedAIGenerations = append(failedAIGenerations, fmt.Sprintf("%s/%s:%s", githubOrg.Name, repoName, tag))
-							// Save cache after clearing the failed entry
-							saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache)
-						} else {
-							releaseNotes = aiNotes
-							aiReleaseNotesCache[cacheKey] = aiNotes // Cache only on success
-							// Save cache immediately after successful generation
-							if err := saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache); err != nil {
-								fmt.Printf("  Warning: Failed to save cache: %v\n", err)
-							}
-							fmt.Printf("  AI release notes generated successfully and cached\n")
-						}
-					}
-				} else {
-					releaseNotes = releaseManager.GenerateReleaseNotes(repoPath, tag, localTags)
-				}
-
-				// Print release notes to stdout
-				fmt.Printf("\n%s\n", strings.Repeat("=", 70))
-				fmt.Printf("Release Notes for %s/%s tag %s:\n", codebergOrg.Name, repoName, tag)
-				fmt.Printf("%s\n", strings.Repeat("-", 70))
-				fmt.Println(releaseNotes)
-				fmt.Printf("%s\n\n", strings.Repeat("=", 70))
-
-				msg := fmt.Sprintf("Create Codeberg release for %s/%s tag %s?", codebergOrg.Name, repoName, tag)
-
-				// Check if auto-create is enabled
-				createRelease := false
-				if flags.AutoCreateReleases {
-					fmt.Printf("  Auto-creating Codeberg release for %s/%s tag %s\n", codebergOrg.Name, repoName, tag)
-					createRelease = true
-				} else {
-					createRelease = release.PromptConfirmation(msg)
-				}
-
-				if createRelease {
-					if err := releaseManager.CreateCodebergRelease(codebergOrg.Name, repoName, tag, releaseNotes); err != nil {
-						fmt.Printf("  Error creating Codeberg release: %v\n", err)
-					} else {
-						fmt.Printf("  Created Codeberg release for tag %s\n", tag)
-					}
-				}
-			}
+		aiNotes, ok := notes.forTag(repo, owner, tag, true)
+		if !ok {
+			continue
 		}
 
-		// Update existing releases if requested
-		if flags.UpdateReleases {
-			// Update GitHub releases
-			if githubOrg != nil && githubOrg.Name != "" {
-				githubReleases, err := releaseManager.GetGitHubReleases(githubOrg.Name, repoName)
-				if err == nil && len(githubReleases) > 0 {
-					fmt.Printf("\n  Updating existing GitHub releases...\n")
-					for _, tag := range githubReleases {
-						// Check if this is a version tag
-						if !isVersionTag(tag) {
-							continue
-						}
-
-						// Get commits for this tag
-						commits, err := releaseManager.GetCommitsSinceTag(repoPath, "", tag)
-						if err != nil {
-							commits = []string{}
-						}
-
-						// Generate AI release notes
-						if flags.AIReleaseNotes {
-							// Check cache first (unless --force is used)
-							cacheKey := fmt.Sprintf("%s:%s", repoName, tag)
-							var aiNotes string
-							if cachedNotes, exists := aiReleaseNotesCache[cacheKey]; exists && !flags.Force {
-								fmt.Printf("  Using cached AI release notes for existing release %s\n", tag)
-								aiNotes = cachedNotes
-							} else {
-								if flags.Force && aiReleaseNotesCache[cacheKey] != "" {
-									fmt.Printf("  Force regenerating AI release notes for existing release %s (ignoring cache)\n", tag)
-								} else {
-									fmt.Printf("  Generating AI release notes for existing release %s...\n", tag)
-								}
-								var err error
-								aiNotes, err = releaseManager.GenerateAIReleaseNotes(repoPath, repoName, tag, localTags, commits)
-								if err != nil {
-									fmt.Printf("  Warning: Failed to generate AI release notes: %v\n", err)
-									// Clear cache on failure and track
-									delete(aiReleaseNotesCache, cacheKey)
-									// Determine which org we're updating for the failure message
-									orgName := ""
-									if githubOrg != nil && githubOrg.Name != "" {
-										orgName = githubOrg.Name
-									} else if codebergOrg != nil && codebergOrg.Name != "" {
-										orgName = codebergOrg.Name
-									}
-									failedAIGenerations = append(failedAIGenerations, fmt.Sprintf("%s/%s:%s", orgName, repoName, tag))
-									// Save cache after clearing the failed entry
-									saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache)
-									continue
-								}
-								aiReleaseNotesCache[cacheKey] = aiNotes // Cache only on success
-								// Save cache immediately after successful generation
-								if err := saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache); err != nil {
-									fmt.Printf("  Warning: Failed to save cache: %v\n", err)
-								}
-							}
-
-							// Print release notes to stdout
-							fmt.Printf("\n%s\n", strings.Repeat("=", 70))
-							fmt.Printf("Updated Release Notes for %s/%s tag %s:\n", githubOrg.Name, repoName, tag)
-							fmt.Printf("%s\n", strings.Repeat("-", 70))
-							fmt.Println(aiNotes)
-							fmt.Printf("%s\n\n", strings.Repeat("=", 70))
-
-							msg := fmt.Sprintf("Update GitHub release for %s/%s tag %s?", githubOrg.Name, repoName, tag)
-
-							updateRelease := false
-							if flags.AutoCreateReleases {
-								fmt.Printf("  Auto-updating GitHub release for %s/%s tag %s\n", githubOrg.Name, repoName, tag)
-								updateRelease = true
-							} else {
-								updateRelease = release.PromptConfirmation(msg)
-							}
-
-							if updateRelease {
-								if err := releaseManager.UpdateGitHubRelease(githubOrg.Name, repoName, tag, aiNotes); err != nil {
-									fmt.Printf("  Error updating GitHub release: %v\n", err)
-								} else {
-									fmt.Printf("  Updated GitHub release for tag %s\n", tag)
-								}
-							}
-						}
-					}
-				}
-			}
+		printReleaseNotes("Updated Release Notes", owner, repo.name, tag, aiNotes)
 
-			// Update Codeberg releases
-			if codebergOrg != nil && codebergOrg.Name != "" {
-				codebergReleases, err := releaseManager.GetCodebergReleases(codebergOrg.Name, repoName)
-				if err == nil && len(codebergReleases) > 0 {
-					fmt.Printf("\n  Updating existing Codeberg releases...\n")
-					for _, tag := range codebergReleases {
-						// Check if this is a version tag
-						if !isVersionTag(tag) {
-							continue
-						}
-
-						// Get commits for this tag
-						commits, err := releaseManager.GetCommitsSinceTag(repoPath, "", tag)
-						if err != nil {
-							commits = []string{}
-						}
-
-						// Generate AI release notes
-						if flags.AIReleaseNotes {
-							// Check cache first (unless --force is used)
-							cacheKey := fmt.Sprintf("%s:%s", repoName, tag)
-							var aiNotes string
-							if cachedNotes, exists := aiReleaseNotesCache[cacheKey]; exists && !flags.Force {
-								fmt.Printf("  Using cached AI release notes for existing release %s\n", tag)
-								aiNotes = cachedNotes
-							} else {
-								if flags.Force && aiReleaseNotesCache[cacheKey] != "" {
-									fmt.Printf("  Force regenerating AI release notes for existing release %s (ignoring cache)\n", tag)
-								} else {
-									fmt.Printf("  Generating AI release notes for existing release %s...\n", tag)
-								}
-								var err error
-								aiNotes, err = releaseManager.GenerateAIReleaseNotes(repoPath, repoName, tag, localTags, commits)
-								if err != nil {
-									fmt.Printf("  Warning: Failed to generate AI release notes: %v\n", err)
-									// Clear cache on failure and track
-									delete(aiReleaseNotesCache, cacheKey)
-									// Determine which org we're updating for the failure message
-									orgName := ""
-									if githubOrg != nil && githubOrg.Name != "" {
-										orgName = githubOrg.Name
-									} else if codebergOrg != nil && codebergOrg.Name != "" {
-										orgName = codebergOrg.Name
-									}
-									failedAIGenerations = append(failedAIGenerations, fmt.Sprintf("%s/%s:%s", orgName, repoName, tag))
-									// Save cache after clearing the failed entry
-									saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache)
-									continue
-								}
-								aiReleaseNotesCache[cacheKey] = aiNotes // Cache only on success
-								// Save cache immediately after successful generation
-								if err := saveAIReleaseNotesCache(cacheFile, aiReleaseNotesCache); err != nil {
-									fmt.Printf("  Warning: Failed to save cache: %v\n", err)
-								}
-							}
-
-							// Print release notes to stdout
-							fmt.Printf("\n%s\n", strings.Repeat("=", 70))
-							fmt.Printf("Updated Release Notes for %s/%s tag %s:\n", codebergOrg.Name, repoName, tag)
-							fmt.Printf("%s\n", strings.Repeat("-", 70))
-							fmt.Println(aiNotes)
-							fmt.Printf("%s\n\n", strings.Repeat("=", 70))
-
-							msg := fmt.Sprintf("Update Codeberg release for %s/%s tag %s?", codebergOrg.Name, repoName, tag)
-
-							updateRelease := false
-							if flags.AutoCreateReleases {
-								fmt.Printf("  Auto-updating Codeberg release for %s/%s tag %s\n", codebergOrg.Name, repoName, tag)
-								updateRelease = true
-							} else {
-								updateRelease = release.PromptConfirmation(msg)
-							}
-
-							if updateRelease {
-								if err := releaseManager.UpdateCodebergRelease(codebergOrg.Name, repoName, tag, aiNotes); err != nil {
-									fmt.Printf("  Error updating Codeberg release: %v\n", err)
-								} else {
-									fmt.Printf("  Updated Codeberg release for tag %s\n", tag)
-								}
-							}
-						}
-					}
-				}
+		updateRelease := false
+		if flags.AutoCreateReleases {
+			fmt.Printf("  Auto-updating %s release for %s/%s tag %s\n", name, owner, repo.name, tag)
+			updateRelease = true
+		} else {
+			updateRelease = release.PromptConfirmation(fmt.Sprintf("Update %s release for %s/%s tag %s?", name, owner, repo.name, tag))
+		}
+
+		if updateRelease {
+			if err := f.UpdateRelease(repo.name, tag, aiNotes); err != nil {
+				fmt.Printf("  Error updating %s release: %v\n", name, err)
+			} else {
+				fmt.Printf("  Updated %s release for tag %s\n", name, tag)
 			}
 		}
 	}
+}
 
-	return 0
+func printReleaseNotes(title, owner, repoName, tag, releaseNotes string) {
+	fmt.Printf("\n%s\n", strings.Repeat("=", 70))
+	fmt.Printf("%s for %s/%s tag %s:\n", title, owner, repoName, tag)
+	fmt.Printf("%s\n", strings.Repeat("-", 70))
+	fmt.Println(releaseNotes)
+	fmt.Printf("%s\n\n", strings.Repeat("=", 70))
+}
+
+// releaseNotesSource generates release notes, caching AI notes per repo and tag
+// so that every forge receives the same notes
+type releaseNotesSource struct {
+	manager   *release.Manager
+	flags     *Flags
+	cacheFile string
+	cache     map[string]string
+	failed    []string // AI generations that failed, as owner/repo:tag
+}
+
+// forTag returns the release notes for a tag. Without AI release notes the
+// standard notes are generated. When AI generation fails, new releases fall
+// back to standard notes while existing releases are skipped (ok is false).
+func (s *releaseNotesSource) forTag(repo releaseRepo, owner, tag string, existing bool) (string, bool) {
+	if !s.flags.AIReleaseNotes {
+		return s.manager.GenerateReleaseNotes(repo.path, tag, repo.localTags), true
+	}
+
+	label := tag
+	if existing {
+		label = "existing release " + tag
+	}
+
+	// Check cache first (unless --force is used)
+	cacheKey := fmt.Sprintf("%s:%s", repo.name, tag)
+	if cachedNotes, exists := s.cache[cacheKey]; exists && !s.flags.Force {
+		fmt.Printf("  Using cached AI release notes for %s\n", label)
+		return cachedNotes, true
+	}
+	if s.flags.Force && s.cache[cacheKey] != "" {
+		fmt.Printf("  Force regenerating AI release notes for %s (ignoring cache)\n", label)
+	} else {
+		fmt.Printf("  Generating AI release notes for %s...\n", label)
+	}
+
+	// Get commits for this tag
+	commits, err := s.manager.GetCommitsSinceTag(repo.path, "", tag)
+	if err != nil {
+		commits = []string{}
+	}
+
+	aiNotes, err := s.manager.GenerateAIReleaseNotes(repo.path, repo.name, tag, repo.localTags, commits)
+	if err != nil {
+		fmt.Printf("  Warning: Failed to generate AI release notes: %v\n", err)
+		// Clear cache on failure and track
+		delete(s.cache, cacheKey)
+		s.failed = append(s.failed, fmt.Sprintf("%s/%s:%s", owner, repo.name, tag))
+		// Save cache after clearing the failed entry
+		saveAIReleaseNotesCache(s.cacheFile, s.cache)
+		if existing {
+			return "", false
+		}
+		fmt.Printf("  Falling back to standard release notes\n")
+		return s.manager.GenerateReleaseNotes(repo.path, tag, repo.localTags), true
+	}
+
+	s.cache[cacheKey] = aiNotes // Cache only on success
+	// Save cache immediately after successful generation
+	if err := saveAIReleaseNotesCache(s.cacheFile, s.cache); err != nil {
+		fmt.Printf("  Warning: Failed to save cache: %v\n", err)
+	}
+	if !existing {
+		fmt.Printf("  AI release notes generated successfully and cached\n")
+	}
+	return aiNotes, true
 }
 
 // loadAIReleaseNotesCache loads the AI release notes cache from disk
@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/issues"
+	"codeberg.org/snonux/gitsyncer/internal/showcase"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// HandleRenameRepo renames a repository on every configured forge and backup
+// location, moves its work-dir clone and carries the state and caches kept
+// under its name over to the new one. Forges are renamed first and the run
+// stops at the first failure; as forges already renamed are skipped, it can
+// simply be rerun once the problem is fixed.
+func HandleRenameRepo(cfg *config.Config, flags *Flags, oldName, newName string) int {
+	if err := validateRename(oldName, newName); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+
+	toRename, err := planForgeRenames(forge.Configured(cfg), oldName, newName)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
+	}
+	// Plain git locations: SSH and file:// hosts and S3 backups
+	var backups []*config.Organization
+	for i := range cfg.Organizations {
+		if forge.TypeOf(&cfg.Organizations[i]) == "" {
+			backups = append(backups, &cfg.Organizations[i])
+		}
+	}
+	_, statErr := os.Stat(filepath.Join(flags.WorkDir, oldName))
+	hasClone := statErr == nil
+
+	if len(toRename) == 0 && len(backups) == 0 && !hasClone {
+		fmt.Printf("Repository '%s' not found in any configured organization or the work directory.\n", oldName)
+		return 1
+	}
+
+	fmt.Printf("Renaming '%s' to '%s':\n", oldName, newName)
+	for _, f := range toRename {
+		fmt.Printf("  %s: %s\n", f.DisplayName(), f.Organization().GetGitURL())
+	}
+	for _, org := range backups {
+		fmt.Printf("  %s (if present)\n", org.Host)
+	}
+	if hasClone {
+		fmt.Printf("  Work directory: %s\n", filepath.Join(flags.WorkDir, oldName))
+	}
+	if mentions := configMentions(cfg, oldName); len(mentions) > 0 {
+		fmt.Printf("  Configuration: %s\n", strings.Join(mentions, ", "))
+	}
+	if flags.DryRun {
+		fmt.Println("\n[DRY RUN] Nothing was renamed.")
+		return 0
+	}
+
+	if !flags.Force {
+		fmt.Print("\nType 'yes' to confirm: ")
+		confirmation, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(confirmation) != "yes" {
+			fmt.Println("Rename cancelled.")
+			return 0
+		}
+	}
+
+	for _, f := range toRename {
+		fmt.Printf("  Renaming on %s... ", f.DisplayName())
+		if err := f.RenameRepo(oldName, newName); err != nil {
+			fmt.Printf("FAILED: %v\n", err)
+			fmt.Println("\nFix the problem and rerun the command; forges already renamed are skipped.")
+			return 1
+		}
+		fmt.Println("SUCCESS")
+	}
+
+	hasError := false
+	for _, org := range backups {
+		if org.IsS3() {
+			fmt.Printf("  Note: %s stores bundles under the repository name; the next backup uploads '%s', remove '%s' there once it succeeded\n", org.Host, newName, oldName)
+			continue
+		}
+		renamed, err := sync.RenameBackupRepository(org, oldName, newName)
+		switch {
+		case err != nil:
+			fmt.Printf("  Renaming on %s... FAILED: %v\n", org.Host, err)
+			hasError = true
+		case renamed:
+			fmt.Printf("  Renamed on %s\n", org.Host)
+		}
+	}
+
+	if err := sync.New(cfg, flags.WorkDir).RenameLocalRepository(oldName, newName); err != nil {
+		fmt.Printf("  Moving the work-dir clone... FAILED: %v\n", err)
+		hasError = true
+	} else if hasClone {
+		fmt.Printf("  Moved the work-dir clone to %s\n", filepath.Join(flags.WorkDir, newName))
+	}
+
+	if err := renameWorkDirData(flags.WorkDir, oldName, newName); err != nil {
+		fmt.Printf("  Carrying over state and caches... FAILED: %v\n", err)
+		hasError = true
+	}
+
+	if err := renameConfigEntries(cfg, flags, oldName, newName); err != nil {
+		fmt.Printf("  Updating the configuration... FAILED: %v\n", err)
+		fmt.Printf("\nUpdate these entries of your configuration to the new name: %s\n", strings.Join(configMentions(cfg, oldName), ", "))
+		hasError = true
+	}
+
+	if hasError {
+		fmt.Println("\n⚠️  Some steps failed. Check the errors above.")
+		return 1
+	}
+	fmt.Printf("\n✅ Repository '%s' has been renamed to '%s'.\n", oldName, newName)
+	return 0
+}
+
+// validateRepoName rejects names that would escape the work directory or a
+// backup location
+func validateRepoName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\ \t\n") {
+		return fmt.Errorf("invalid repository name %q", name)
+	}
+	return nil
+}
+
+func validateRename(oldName, newName string) error {
+	for _, name := range []string{oldName, newName} {
+		if err := validateRepoName(name); err != nil {
+			return err
+		}
+	}
+	if oldName == newName {
+		return fmt.Errorf("old and new repository name are the same")
+	}
+	return nil
+}
+
+// planForgeRenames returns the forges that still have the repository under
+// its old name. Forges that only have the new name were renamed by an earlier
+// run; forges with both names cannot be renamed.
+func planForgeRenames(forges []forge.Forge, oldName, newName string) ([]forge.Forge, error) {
+	var toRename []forge.Forge
+	for _, f := range forges {
+		oldExists, err := f.RepoExists(oldName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s on %s: %w", oldName, f.DisplayName(), err)
+		}
+		if !oldExists {
+			continue
+		}
+		newExists, err := f.RepoExists(newName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s on %s: %w", newName, f.DisplayName(), err)
+		}
+		if newExists {
+			return nil, fmt.Errorf("%s already has both %s and %s", f.DisplayName(), oldName, newName)
+		}
+		toRename = append(toRename, f)
+	}
+	return toRename, nil
+}
+
+// renameWorkDirData moves the state, caches and issue mapping kept under the
+// old repository name to the new one
+func renameWorkDirData(workDir, oldName, newName string) error {
+	manager, st, err := loadSyncState(workDir)
+	if err != nil {
+		return err
+	}
+	st.RenameRepo(oldName, newName)
+	if err := manager.Save(st); err != nil {
+		return err
+	}
+
+	descriptions := loadDescriptionCache(workDir)
+	if description, ok := descriptions[oldName]; ok {
+		descriptions[newName] = description
+		delete(descriptions, oldName)
+		if err := saveDescriptionCache(workDir, descriptions); err != nil {
+			return err
+		}
+	}
+
+	topics := loadTopicsCache(workDir)
+	if repoTopics, ok := topics[oldName]; ok {
+		topics[newName] = repoTopics
+		delete(topics, oldName)
+		if err := saveTopicsCache(workDir, topics); err != nil {
+			return err
+		}
+	}
+
+	mapping, err := issues.LoadMapping(workDir)
+	if err != nil {
+		return err
+	}
+	if mapping.RenameRepo(oldName, newName) {
+		if err := mapping.Save(workDir); err != nil {
+			return err
+		}
+	}
+
+	return showcase.RenameRepo(workDir, oldName, newName)
+}
+
+// renameConfigEntries replaces the old name with the new one in the
+// configuration file, keeping its layout. Nothing is done when the loaded
+// configuration does not mention the repository.
+func renameConfigEntries(cfg *config.Config, flags *Flags, oldName, newName string) error {
+	if len(configMentions(cfg, oldName)) == 0 {
+		return nil
+	}
+	configPath, err := config.ResolvePath(flags.ConfigPath)
+	if err != nil {
+		return err
+	}
+	keys, err := config.RenameRepository(configPath, oldName, newName)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		fmt.Printf("  Updated %s in %s\n", strings.Join(keys, ", "), configPath)
+	}
+	return nil
+}
+
+// configMentions names the configuration entries referring to a repository
+func configMentions(cfg *config.Config, repoName string) []string {
+	var mentions []string
+	if contains(cfg.Repositories, repoName) {
+		mentions = append(mentions, "repositories")
+	}
+	if contains(cfg.ExcludeFromShowcase, repoName) {
+		mentions = append(mentions, "exclude_from_showcase")
+	}
+	if _, ok := cfg.ShowcaseStatsBranches[repoName]; ok {
+		mentions = append(mentions, "showcase_stats_branches")
+	}
+	if _, ok := cfg.SkipReleases[repoName]; ok {
+		mentions = append(mentions, "skip_releases")
+	}
+	if _, ok := cfg.MirrorMode[repoName]; ok {
+		mentions = append(mentions, "mirror_mode")
+	}
+	sort.Strings(mentions)
+	return mentions
+}
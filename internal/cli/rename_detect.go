@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+	"codeberg.org/snonux/gitsyncer/internal/sync"
+)
+
+// detectedRename is a repository renamed on the source forge, identified by
+// a root commit shared between its old work-dir clone and its new name
+type detectedRename struct {
+	oldName string
+	newName string
+	root    string
+}
+
+// handleDetectedRenames reports repositories renamed on the source forge and
+// returns the repository names left to sync. Without --propagate-renames the
+// new names are skipped, as syncing them would create a second copy next to
+// the old name on every other organization.
+func handleDetectedRenames(cfg *config.Config, flags *Flags, source forge.Forge, repoNames []string) []string {
+	_, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
+	}
+	renames := detectRenames(flags.WorkDir, source, repoNames, syncState)
+	if len(renames) == 0 {
+		return repoNames
+	}
+
+	skip := make(map[string]bool)
+	for _, rename := range renames {
+		fmt.Printf("\nDetected rename on %s: %s → %s (shared root commit %s)\n", source.DisplayName(), rename.oldName, rename.newName, shortCommit(rename.root))
+		if !flags.PropagateRenames {
+			fmt.Printf("  Skipping %s to avoid creating a duplicate. Run 'gitsyncer manage rename-repo %s %s' or sync with --propagate-renames.\n", rename.newName, rename.oldName, rename.newName)
+			skip[rename.newName] = true
+			continue
+		}
+
+		renameFlags := *flags
+		renameFlags.Force = true
+		if HandleRenameRepo(cfg, &renameFlags, rename.oldName, rename.newName) != 0 {
+			fmt.Printf("  Failed to propagate the rename, skipping %s\n", rename.newName)
+			skip[rename.newName] = true
+		}
+	}
+
+	remaining := make([]string, 0, len(repoNames))
+	for _, name := range repoNames {
+		if !skip[name] {
+			remaining = append(remaining, name)
+		}
+	}
+	return remaining
+}
+
+// detectRenames matches repositories that are new on the source forge with
+// known repositories that are gone from it. Known repositories are the
+// work-dir clones and those recorded in the sync state; only clones can be
+// compared. Nothing is fetched unless there are both new and gone names.
+func detectRenames(workDir string, source forge.Forge, repoNames []string, st *state.State) []detectedRename {
+	clones, err := workDirRepositories(workDir)
+	if err != nil {
+		return nil
+	}
+	known := make(map[string]bool)
+	for _, name := range clones {
+		known[name] = true
+	}
+	if st != nil {
+		for name := range st.LastRepoSync {
+			known[name] = true
+		}
+	}
+
+	var newNames []string
+	discovered := make(map[string]bool)
+	for _, name := range repoNames {
+		discovered[name] = true
+		if !known[name] {
+			newNames = append(newNames, name)
+		}
+	}
+	if len(newNames) == 0 {
+		return nil
+	}
+
+	// A clone is only a rename candidate if the source forge no longer has
+	// it at all, not merely because it is private or archived
+	rootOwners := make(map[string][]string)
+	for _, name := range clones {
+		if discovered[name] {
+			continue
+		}
+		if _, archived := st.RepoArchived(name); archived {
+			continue
+		}
+		if exists, err := source.RepoExists(name); err != nil || exists {
+			continue
+		}
+		roots, err := sync.RootCommits(filepath.Join(workDir, name))
+		if err != nil {
+			continue
+		}
+		for _, root := range roots {
+			rootOwners[root] = append(rootOwners[root], name)
+		}
+	}
+	if len(rootOwners) == 0 {
+		return nil
+	}
+
+	var renames []detectedRename
+	claimed := make(map[string]bool)
+	for _, newName := range newNames {
+		roots, err := sync.RemoteRootCommits(source.Organization(), newName)
+		if err != nil {
+			fmt.Printf("Warning: Failed to check whether %s was renamed: %v\n", newName, err)
+			continue
+		}
+		matches := make(map[string]string)
+		for _, root := range roots {
+			for _, oldName := range rootOwners[root] {
+				if !claimed[oldName] {
+					matches[oldName] = root
+				}
+			}
+		}
+		if len(matches) > 1 {
+			fmt.Printf("Warning: %s shares its history with several gone repositories, not treating it as a rename\n", newName)
+			continue
+		}
+		for oldName, root := range matches {
+			claimed[oldName] = true
+			renames = append(renames, detectedRename{oldName: oldName, newName: newName, root: root})
+		}
+	}
+	return renames
+}
+
+func shortCommit(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
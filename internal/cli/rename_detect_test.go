@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/state"
+)
+
+// identityForge is a stub source forge backed by a file:// directory
+type identityForge struct {
+	renameForge
+	org *config.Organization
+}
+
+func (f *identityForge) Organization() *config.Organization { return f.org }
+
+func TestDetectRenames_MatchesSharedRootCommit(t *testing.T) {
+	workDir := t.TempDir()
+	orgDir := t.TempDir()
+	for _, name := range []string{"old-name", "unrelated", "kept"} {
+		gitRun(t, workDir, "init", "-q", "-b", "main", name)
+		gitRun(t, filepath.Join(workDir, name), "commit", "-q", "--allow-empty", "-m", name)
+	}
+	// old-name was renamed to new-name, brand-new has its own history
+	gitRun(t, "", "clone", "-q", "--bare", filepath.Join(workDir, "old-name"), filepath.Join(orgDir, "new-name.git"))
+	gitRun(t, "", "clone", "-q", "--bare", filepath.Join(workDir, "kept"), filepath.Join(orgDir, "kept.git"))
+	gitRun(t, "", "init", "-q", "--bare", filepath.Join(orgDir, "brand-new.git"))
+
+	source := &identityForge{
+		renameForge: renameForge{repos: map[string]bool{"new-name": true, "kept": true, "brand-new": true}},
+		org:         &config.Organization{Host: "file://" + orgDir},
+	}
+	st := &state.State{}
+	st.SetLastRepoSync("synced-before", time.Now())
+
+	renames := detectRenames(workDir, source, []string{"kept", "new-name", "brand-new", "synced-before"}, st)
+	if len(renames) != 1 || renames[0].oldName != "old-name" || renames[0].newName != "new-name" || renames[0].root == "" {
+		t.Fatalf("detectRenames() = %+v, want old-name → new-name", renames)
+	}
+
+	// An old name still on the source forge is not a rename, e.g. a copy
+	source.repos["old-name"] = true
+	if renames := detectRenames(workDir, source, []string{"kept", "new-name"}, st); len(renames) != 0 {
+		t.Fatalf("detectRenames() = %+v, want none while old-name still exists", renames)
+	}
+}
+
+func TestHandleDetectedRenames_SkipsRenamedRepoWithoutPropagation(t *testing.T) {
+	workDir := t.TempDir()
+	orgDir := t.TempDir()
+	gitRun(t, workDir, "init", "-q", "-b", "main", "old-name")
+	gitRun(t, filepath.Join(workDir, "old-name"), "commit", "-q", "--allow-empty", "-m", "initial")
+	gitRun(t, "", "clone", "-q", "--bare", filepath.Join(workDir, "old-name"), filepath.Join(orgDir, "new-name.git"))
+
+	source := &identityForge{
+		renameForge: renameForge{repos: map[string]bool{"new-name": true, "other": true}},
+		org:         &config.Organization{Host: "file://" + orgDir},
+	}
+	remaining := handleDetectedRenames(&config.Config{}, &Flags{WorkDir: workDir}, source, []string{"new-name", "other"})
+	if len(remaining) != 1 || remaining[0] != "other" {
+		t.Fatalf("handleDetectedRenames() = %v, want [other]", remaining)
+	}
+}
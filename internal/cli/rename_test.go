@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+	"codeberg.org/snonux/gitsyncer/internal/issues"
+)
+
+// renameForge is a stub forge holding a set of repository names
+type renameForge struct {
+	forge.Forge
+	repos map[string]bool
+}
+
+func (f *renameForge) DisplayName() string { return "Stub" }
+
+func (f *renameForge) RepoExists(repoName string) (bool, error) {
+	return f.repos[repoName], nil
+}
+
+func TestPlanForgeRenames(t *testing.T) {
+	t.Parallel()
+
+	pending := &renameForge{repos: map[string]bool{"old": true}}
+	done := &renameForge{repos: map[string]bool{"new": true}}
+	absent := &renameForge{repos: map[string]bool{}}
+	toRename, err := planForgeRenames([]forge.Forge{pending, done, absent}, "old", "new")
+	if err != nil {
+		t.Fatalf("planForgeRenames() error = %v", err)
+	}
+	if len(toRename) != 1 || toRename[0] != pending {
+		t.Fatalf("planForgeRenames() = %v, want only the forge with the old name", toRename)
+	}
+
+	both := &renameForge{repos: map[string]bool{"old": true, "new": true}}
+	if _, err := planForgeRenames([]forge.Forge{pending, both}, "old", "new"); err == nil {
+		t.Fatal("expected an error when a forge has both names")
+	}
+}
+
+func TestValidateRename(t *testing.T) {
+	t.Parallel()
+
+	for _, names := range [][2]string{{"old", ""}, {"old", "old"}, {"old", "a/b"}, {"..", "new"}, {"old", "new name"}} {
+		if err := validateRename(names[0], names[1]); err == nil {
+			t.Errorf("validateRename(%q, %q) = nil, want an error", names[0], names[1])
+		}
+	}
+	if err := validateRename("old", "new-name"); err != nil {
+		t.Errorf("validateRename() error = %v", err)
+	}
+}
+
+func TestRenameWorkDirData(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	manager, st, _ := loadSyncState(workDir)
+	synced := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	st.SetLastRepoSync("old", synced)
+	st.SetBackupVerified("old", "nas", synced)
+	st.SetRepoPrivate("old", true)
+	if err := manager.Save(st); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := saveDescriptionCache(workDir, map[string]string{"old": "A tool", "other": "Other"}); err != nil {
+		t.Fatalf("saveDescriptionCache() error = %v", err)
+	}
+	if err := saveTopicsCache(workDir, map[string][]string{"old": {"go"}}); err != nil {
+		t.Fatalf("saveTopicsCache() error = %v", err)
+	}
+	mapping := &issues.Mapping{Repos: map[string][]*issues.Link{"old": {{Origin: "github", OriginNumber: 1, Mirror: "codeberg", MirrorNumber: 2}}}}
+	if err := mapping.Save(workDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := renameWorkDirData(workDir, "old", "new"); err != nil {
+		t.Fatalf("renameWorkDirData() error = %v", err)
+	}
+
+	_, st, err := loadSyncState(workDir)
+	if err != nil {
+		t.Fatalf("loadSyncState() error = %v", err)
+	}
+	if !st.GetLastRepoSync("new").Equal(synced) || !st.GetLastRepoSync("old").IsZero() {
+		t.Errorf("last sync not moved: %v", st.LastRepoSync)
+	}
+	if st.GetBackupRecord("new", "nas").LastVerified.IsZero() {
+		t.Errorf("backup record not moved: %v", st.Backups)
+	}
+	if got := st.PrivateRepoNames(); !reflect.DeepEqual(got, []string{"new"}) {
+		t.Errorf("private repos = %v, want [new]", got)
+	}
+	if got := loadDescriptionCache(workDir); !reflect.DeepEqual(got, map[string]string{"new": "A tool", "other": "Other"}) {
+		t.Errorf("descriptions cache = %v", got)
+	}
+	if got := loadTopicsCache(workDir); !reflect.DeepEqual(got, map[string][]string{"new": {"go"}}) {
+		t.Errorf("topics cache = %v", got)
+	}
+	mapping, err = issues.LoadMapping(workDir)
+	if err != nil {
+		t.Fatalf("LoadMapping() error = %v", err)
+	}
+	if len(mapping.Repos["new"]) != 1 || mapping.Repos["old"] != nil {
+		t.Errorf("issue mapping = %v", mapping.Repos)
+	}
+}
+
+func TestConfigMentions(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Repositories:        []string{"other", "old"},
+		ExcludeFromShowcase: []string{"other"},
+		SkipReleases:        map[string][]string{"old": {"v1.0.0"}},
+		MirrorMode:          map[string]string{"old": config.MirrorModeNative},
+	}
+	if got := strings.Join(configMentions(cfg, "old"), ","); got != "mirror_mode,repositories,skip_releases" {
+		t.Fatalf("configMentions() = %q", got)
+	}
+	if got := configMentions(cfg, "unknown"); len(got) != 0 {
+		t.Fatalf("configMentions() = %v, want none", got)
+	}
+}
@@ -22,11 +22,10 @@ func HandleShowcase(cfg *config.Config, flags *Flags) int {
 	}
 
 	// Create showcase generator
-	generator := showcase.New(cfg, flags.WorkDir)
-
-	// Set AI tool if specified
-	if flags.AITool != "" {
-		generator.SetAITool(flags.AITool)
+	generator, err := newShowcaseGenerator(cfg, flags)
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+		return 1
 	}
 
 	// Generate showcase with optional filter
@@ -38,3 +37,23 @@ func HandleShowcase(cfg *config.Config, flags *Flags) int {
 	fmt.Println("Showcase generated successfully!")
 	return 0
 }
+
+// newShowcaseGenerator creates a showcase generator that uses the configured
+// AI tool and only includes repositories recorded as public in the sync state.
+// Without the state no repository is known to be public, so it fails rather
+// than risk publishing private ones.
+func newShowcaseGenerator(cfg *config.Config, flags *Flags) (*showcase.Generator, error) {
+	_, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync state, which records which repositories are public: %w", err)
+	}
+
+	generator := showcase.New(cfg, flags.WorkDir)
+
+	// Set AI tool if specified
+	if flags.AITool != "" {
+		generator.SetAITool(flags.AITool)
+	}
+	generator.SetRepoVisibility(syncState.RepoVisibilities())
+	return generator, nil
+}
@@ -4,10 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"codeberg.org/snonux/gitsyncer/internal/codeberg"
 	"codeberg.org/snonux/gitsyncer/internal/config"
-	"codeberg.org/snonux/gitsyncer/internal/github"
-	"codeberg.org/snonux/gitsyncer/internal/showcase"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 	"codeberg.org/snonux/gitsyncer/internal/sync"
 )
 
@@ -17,6 +15,9 @@ func HandleShowcaseOnly(cfg *config.Config, flags *Flags) int {
 	// If a specific repo is requested, only generate for that repo
 	if flags.SyncRepo != "" {
 		repo := flags.SyncRepo
+		if err := recordConfiguredVisibility(cfg, flags.WorkDir, []string{repo}); err != nil {
+			fmt.Printf("Warning: Failed to record the visibility of %s: %v\n", repo, err)
+		}
 
 		// Ensure the repository is cloned
 		syncer := sync.New(cfg, flags.WorkDir)
@@ -28,9 +29,10 @@ func HandleShowcaseOnly(cfg *config.Config, flags *Flags) int {
 
 		// Generate showcase for just this repository
 		fmt.Printf("\nGenerating showcase for repository: %s...\n", repo)
-		generator := showcase.New(cfg, flags.WorkDir)
-		if flags.AITool != "" {
-			generator.SetAITool(flags.AITool)
+		generator, err := newShowcaseGenerator(cfg, flags)
+		if err != nil {
+			log.Printf("ERROR: %v\n", err)
+			return 1
 		}
 		if err := generator.GenerateShowcase([]string{repo}, flags.Force); err != nil {
 			log.Printf("ERROR: Failed to generate showcase for %s: %v\n", repo, err)
@@ -41,7 +43,7 @@ func HandleShowcaseOnly(cfg *config.Config, flags *Flags) int {
 	}
 
 	// Otherwise, process all repositories
-	allRepos, err := getAllRepositories(cfg)
+	allRepos, err := getAllRepositories(cfg, false)
 	if err != nil {
 		log.Printf("ERROR: Failed to get repositories: %v\n", err)
 		return 1
@@ -51,6 +53,9 @@ func HandleShowcaseOnly(cfg *config.Config, flags *Flags) int {
 		return 1
 	}
 	fmt.Printf("Found %d repositories total\n", len(allRepos))
+	if err := recordConfiguredVisibility(cfg, flags.WorkDir, allRepos); err != nil {
+		fmt.Printf("Warning: Failed to record repository visibility: %v\n", err)
+	}
 
 	// Create a minimal syncer just for cloning
 	syncer := sync.New(cfg, flags.WorkDir)
@@ -67,11 +72,10 @@ func HandleShowcaseOnly(cfg *config.Config, flags *Flags) int {
 
 	// Generate showcase for all repositories
 	fmt.Println("\nGenerating showcase for all repositories...")
-	generator := showcase.New(cfg, flags.WorkDir)
-
-	// Set AI tool if specified
-	if flags.AITool != "" {
-		generator.SetAITool(flags.AITool)
+	generator, err := newShowcaseGenerator(cfg, flags)
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+		return 1
 	}
 
 	// Pass empty filter to process all repos
@@ -84,8 +88,9 @@ func HandleShowcaseOnly(cfg *config.Config, flags *Flags) int {
 	return 0
 }
 
-// getAllRepositories collects all unique repository names from all sources
-func getAllRepositories(cfg *config.Config) ([]string, error) {
+// getAllRepositories collects all unique repository names from all sources.
+// Private repositories are only included if includePrivate is set.
+func getAllRepositories(cfg *config.Config, includePrivate bool) ([]string, error) {
 	repoMap := make(map[string]bool)
 
 	// Add configured repositories
@@ -93,44 +98,19 @@ func getAllRepositories(cfg *config.Config) ([]string, error) {
 		repoMap[repo] = true
 	}
 
-	// Add Codeberg public repos if configured
-	if codebergOrg := cfg.FindCodebergOrg(); codebergOrg != nil {
-		fmt.Printf("Fetching public repositories from Codeberg user/org: %s...\n", codebergOrg.Name)
-		client := codeberg.NewClient(codebergOrg.Name, codebergOrg.CodebergToken)
-
-		repos, err := client.ListPublicRepos()
+	// Add the repos of every configured forge
+	for _, f := range forge.Configured(cfg) {
+		fmt.Printf("Fetching repositories from %s user/org: %s...\n", f.DisplayName(), f.Organization().Name)
+		repos, err := f.ListRepos(includePrivate)
 		if err != nil {
-			// Try as user
-			repos, err = client.ListUserPublicRepos()
-			if err != nil {
-				fmt.Printf("Warning: Failed to fetch Codeberg repos: %v\n", err)
-			}
+			fmt.Printf("Warning: Failed to fetch %s repos: %v\n", f.DisplayName(), err)
+			continue
 		}
-
 		for _, repo := range repos {
 			repoMap[repo.Name] = true
 		}
 	}
 
-	// Add GitHub public repos if configured
-	if githubOrg := cfg.FindGitHubOrg(); githubOrg != nil {
-		fmt.Printf("Fetching public repositories from GitHub user/org: %s...\n", githubOrg.Name)
-		client := github.NewClient(githubOrg.GitHubToken, githubOrg.Name)
-
-		if client.HasToken() {
-			repos, err := client.ListPublicRepos()
-			if err != nil {
-				fmt.Printf("Warning: Failed to fetch GitHub repos: %v\n", err)
-			} else {
-				for _, repo := range repos {
-					repoMap[repo.Name] = true
-				}
-			}
-		} else {
-			fmt.Println("Warning: No GitHub token found, skipping GitHub repos")
-		}
-	}
-
 	// Convert map to slice
 	var allRepos []string
 	for repo := range repoMap {
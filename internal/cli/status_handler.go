@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// HandleStatus reports the mirror mode and last sync of the given repository,
+// or of all configured ones. For native mirrors it asks every Codeberg and
+// Gitea/Forgejo forge when it last pulled.
+func HandleStatus(cfg *config.Config, flags *Flags, repoName string) int {
+	repoNames := []string{repoName}
+	if repoName == "" {
+		repoNames = statusRepos(cfg)
+	}
+	if len(repoNames) == 0 {
+		fmt.Println("No repositories configured.")
+		return 0
+	}
+
+	_, syncState, err := loadSyncState(flags.WorkDir)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
+	}
+
+	now := time.Now()
+	fmt.Println("=== Repository Status ===")
+	for _, name := range repoNames {
+		mode := cfg.MirrorModeOf(name)
+		fmt.Printf("%s (%s)\n", name, mode)
+		fmt.Printf("  Last sync: %s\n", formatLastTime(syncState.GetLastRepoSync(name), now))
+		if mode != config.MirrorModeNative {
+			continue
+		}
+		for _, f := range forge.Configured(cfg) {
+			if _, ok := f.(forge.PullMirrorer); !ok || f.Organization().BackupLocation {
+				continue
+			}
+			repo, exists, err := f.GetRepo(name)
+			if err != nil {
+				fmt.Printf("  %s: lookup failed: %v\n", f.DisplayName(), err)
+				continue
+			}
+			fmt.Printf("  %s: %s\n", f.DisplayName(), describePullMirror(repo, exists, now))
+		}
+	}
+	return 0
+}
+
+// statusRepos returns the configured repositories and those with a mirror mode
+func statusRepos(cfg *config.Config) []string {
+	seen := make(map[string]bool)
+	var repoNames []string
+	for _, name := range cfg.Repositories {
+		if !seen[name] {
+			seen[name] = true
+			repoNames = append(repoNames, name)
+		}
+	}
+	for name := range cfg.MirrorMode {
+		if !seen[name] {
+			seen[name] = true
+			repoNames = append(repoNames, name)
+		}
+	}
+	sort.Strings(repoNames)
+	return repoNames
+}
+
+// describePullMirror summarizes the pull mirror state of a repository on a forge
+func describePullMirror(repo forge.Repository, exists bool, now time.Time) string {
+	switch {
+	case !exists:
+		return "missing, run 'gitsyncer sync native-mirrors' to create the mirror"
+	case !repo.Mirror:
+		return "regular repository, not a pull mirror"
+	default:
+		return fmt.Sprintf("pull mirror of %s every %s, last updated %s", repo.MirrorURL, repo.MirrorInterval, formatLastTime(repo.MirrorUpdated, now))
+	}
+}
+
+func formatLastTime(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Format("2006-01-02 15:04"), now.Sub(t).Round(time.Minute))
+}
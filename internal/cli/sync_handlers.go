@@ -5,9 +5,8 @@ import (
 	"math/rand"
 	"strings"
 
-	"codeberg.org/snonux/gitsyncer/internal/codeberg"
 	"codeberg.org/snonux/gitsyncer/internal/config"
-	"codeberg.org/snonux/gitsyncer/internal/github"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 	"codeberg.org/snonux/gitsyncer/internal/state"
 	"codeberg.org/snonux/gitsyncer/internal/sync"
 )
@@ -41,20 +40,12 @@ func HandleSync(cfg *config.Config, flags *Flags) int {
 		return 0
 	}
 
-	// If create-github-repos is enabled, create the repo if needed
-	if flags.CreateGitHubRepos {
-		if err := createGitHubRepoIfNeeded(cfg, flags.SyncRepo); err != nil {
-			fmt.Printf("ERROR: %v\n", err)
-			return 1
-		}
-	}
-
-	// If create-codeberg-repos is enabled, create the repo if needed
-	if flags.CreateCodebergRepos {
-		if err := createCodebergRepoIfNeeded(cfg, flags.SyncRepo); err != nil {
-			fmt.Printf("ERROR: %v\n", err)
-			return 1
-		}
+	// If --create-*-repos is enabled, create the repo where needed
+	createForges := initCreateForges(cfg, flags)
+	syncNativeMirrors(cfg, flags, flags.SyncRepo, false)
+	if err := prepareConfiguredRepo(cfg, createForges, syncState, flags.SyncRepo, true); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		return 1
 	}
 
 	syncer := sync.New(cfg, flags.WorkDir)
@@ -66,6 +57,7 @@ func HandleSync(cfg *config.Config, flags *Flags) int {
 
 	if stateManager != nil {
 		recordRepoSync(flags.SyncRepo, syncState, flags.Throttle)
+		recordBackupPushes(flags.SyncRepo, syncState, syncer.BackupPushes())
 		if err := stateManager.Save(syncState); err != nil {
 			fmt.Printf("Warning: Failed to save sync state: %v\n", err)
 		}
@@ -73,10 +65,14 @@ func HandleSync(cfg *config.Config, flags *Flags) int {
 
 	// Also sync descriptions for this single repository
 	descCache := loadDescriptionCache(flags.WorkDir)
-	syncRepoDescriptions(cfg, flags.DryRun, flags.SyncRepo, "", "", descCache)
+	syncRepoDescriptions(cfg, flags.DryRun, flags.SyncRepo, nil, "", descCache)
 	if err := saveDescriptionCache(flags.WorkDir, descCache); err != nil {
 		fmt.Printf("Warning: Failed to save descriptions cache: %v\n", err)
 	}
+	topicsCache := loadTopicsCache(flags.WorkDir)
+	syncRepoMetadata(cfg, flags.DryRun, flags.SyncRepo, syncer.DefaultBranch(), topicsCache)
+	syncBranchProtection(cfg, flags.DryRun, flags.SyncRepo)
+	saveMetadataCaches(cfg, flags, topicsCache)
 	return 0
 }
 
@@ -94,31 +90,15 @@ func HandleSyncAll(cfg *config.Config, flags *Flags) int {
 		fmt.Printf("Warning: Failed to load sync state: %v\n", err)
 	}
 
-	// Initialize GitHub client if needed
-	var githubClient github.Client
-	var hasGithubClient bool
-	if flags.CreateGitHubRepos {
-		if client := initGitHubClient(cfg); client != nil {
-			githubClient = *client
-			hasGithubClient = true
-		}
-	}
-
-	// Initialize Codeberg client if needed
-	var codebergClient codeberg.Client
-	var hasCodebergClient bool
-	if flags.CreateCodebergRepos {
-		if client := initCodebergClient(cfg); client != nil {
-			codebergClient = *client
-			hasCodebergClient = true
-		}
-	}
+	// Initialize forges on which missing repos are created
+	createForges := initCreateForges(cfg, flags)
 
 	syncer := sync.New(cfg, flags.WorkDir)
 	syncer.SetBackupEnabled(shouldEnableBackupSync(flags))
 	successCount := 0
-	// Load descriptions cache
+	// Load descriptions and topics caches
 	descCache := loadDescriptionCache(flags.WorkDir)
+	topicsCache := loadTopicsCache(flags.WorkDir)
 
 	for i, repo := range repoNames {
 		fmt.Printf("\n[%d/%d] Syncing %s...\n", i+1, len(repoNames), repo)
@@ -137,21 +117,12 @@ func HandleSyncAll(cfg *config.Config, flags *Flags) int {
 			continue
 		}
 
-		// Create GitHub repo if needed
-		if hasGithubClient {
-			if err := createRepoWithClient(&githubClient, repo, fmt.Sprintf("Mirror of %s", repo)); err != nil {
-				fmt.Printf("ERROR: Failed to create GitHub repo %s: %v\n", repo, err)
-				fmt.Printf("Stopping sync due to error.\n")
-				return 1
-			}
-		}
-
-		// Create Codeberg repo if needed
-		if hasCodebergClient {
-			fmt.Printf("Checking/creating Codeberg repository %s...\n", repo)
-			if err := codebergClient.CreateRepo(repo, fmt.Sprintf("Mirror of %s", repo), false); err != nil {
-				fmt.Printf("Warning: Failed to create Codeberg repo %s: %v\n", repo, err)
-			}
+		// Create missing repos if needed
+		syncNativeMirrors(cfg, flags, repo, false)
+		if err := prepareConfiguredRepo(cfg, createForges, syncState, repo, false); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			fmt.Printf("Stopping sync due to error.\n")
+			return 1
 		}
 
 		if err := syncer.SyncRepository(repo); err != nil {
@@ -161,18 +132,22 @@ func HandleSyncAll(cfg *config.Config, flags *Flags) int {
 		}
 		if stateManager != nil {
 			recordRepoSync(repo, syncState, flags.Throttle)
+			recordBackupPushes(repo, syncState, syncer.BackupPushes())
 			if err := stateManager.Save(syncState); err != nil {
 				fmt.Printf("Warning: Failed to save sync state: %v\n", err)
 			}
 		}
 		successCount++
 		// Sync descriptions after repo sync
-		syncRepoDescriptions(cfg, flags.DryRun, repo, "", "", descCache)
+		syncRepoDescriptions(cfg, flags.DryRun, repo, nil, "", descCache)
+		syncRepoMetadata(cfg, flags.DryRun, repo, syncer.DefaultBranch(), topicsCache)
+		syncBranchProtection(cfg, flags.DryRun, repo)
 	}
-	// Save descriptions cache
+	// Save descriptions and topics caches
 	if err := saveDescriptionCache(flags.WorkDir, descCache); err != nil {
 		fmt.Printf("Warning: Failed to save descriptions cache: %v\n", err)
 	}
+	saveMetadataCaches(cfg, flags, topicsCache)
 
 	fmt.Printf("\nSuccessfully synced all %d repositories!\n", successCount)
 
@@ -188,92 +163,66 @@ func HandleSyncAll(cfg *config.Config, flags *Flags) int {
 
 // HandleSyncCodebergPublic handles syncing all public Codeberg repositories
 func HandleSyncCodebergPublic(cfg *config.Config, flags *Flags) int {
-	codebergOrg := cfg.FindCodebergOrg()
-	if codebergOrg == nil {
-		fmt.Println("No Codeberg organization found in configuration")
-		return 1
-	}
-
-	fmt.Printf("Fetching public repositories from Codeberg user/org: %s...\n", codebergOrg.Name)
-
-	client := codeberg.NewClient(codebergOrg.Name, codebergOrg.CodebergToken)
-
-	// Try fetching as organization first, then as user
-	repos, err := client.ListPublicRepos()
-	if err != nil {
-		fmt.Println("Trying as user account...")
-		repos, err = client.ListUserPublicRepos()
-		if err != nil {
-			fmt.Printf("ERROR: Failed to fetch repositories: %v\n", err)
-			return 1
-		}
-	}
-
-	repoNames := codeberg.GetRepoNames(repos)
-	fmt.Printf("Found %d public repositories on Codeberg\n", len(repoNames))
-
-	if len(repoNames) == 0 {
-		fmt.Println("No public repositories found")
-		return 0
-	}
-
-	if flags.DryRun {
-		repoNames = filterDryRunRepoNames(repoNames, flags)
-	}
-
-	repoNames = shuffledRepoNames(repoNames)
-
-	// Show the repositories that will be synced
-	showReposToSync(repoNames)
+	return handleSyncPublic(cfg, flags, forge.TypeCodeberg)
+}
 
-	if flags.DryRun {
-		fmt.Printf("\n[DRY RUN] Would sync %d repositories from Codeberg to GitHub\n", len(repoNames))
-		if flags.CreateGitHubRepos {
-			fmt.Println("Would create missing GitHub repositories")
-		}
-		if !flags.SyncGitHubPublic {
-			return 0
-		}
-	}
+// HandleSyncGiteaPublic handles syncing all public repositories of a self-hosted Gitea/Forgejo instance
+func HandleSyncGiteaPublic(cfg *config.Config, flags *Flags) int {
+	return handleSyncPublic(cfg, flags, forge.TypeGitea)
+}
 
-	if !flags.DryRun {
-		return syncCodebergRepos(cfg, flags, repos, repoNames)
-	}
+// HandleSyncGitLabPublic handles syncing all public projects of a GitLab user or group
+func HandleSyncGitLabPublic(cfg *config.Config, flags *Flags) int {
+	return handleSyncPublic(cfg, flags, forge.TypeGitLab)
+}
 
-	return 0
+// HandleSyncSourceHutPublic handles syncing all public repositories of a SourceHut user
+func HandleSyncSourceHutPublic(cfg *config.Config, flags *Flags) int {
+	return handleSyncPublic(cfg, flags, forge.TypeSourceHut)
 }
 
 // HandleSyncGitHubPublic handles syncing all public GitHub repositories
 func HandleSyncGitHubPublic(cfg *config.Config, flags *Flags) int {
-	githubOrg := cfg.FindGitHubOrg()
-	if githubOrg == nil {
-		fmt.Println("No GitHub organization found in configuration")
+	return handleSyncPublic(cfg, flags, forge.TypeGitHub)
+}
+
+// handleSyncPublic syncs all public repositories of a source forge to the other organizations
+func handleSyncPublic(cfg *config.Config, flags *Flags, sourceType string) int {
+	source := forge.Find(cfg, sourceType)
+	if source == nil {
+		fmt.Printf("No %s organization found in configuration\n", sourceType)
 		return 1
 	}
+	sourceName := source.DisplayName()
 
-	fmt.Printf("Fetching public repositories from GitHub user/org: %s...\n", githubOrg.Name)
-
-	client := github.NewClient(githubOrg.GitHubToken, githubOrg.Name)
-	if !client.HasToken() {
-		fmt.Println("ERROR: GitHub token required to list repositories")
-		fmt.Println("Set GITHUB_TOKEN env var or create ~/.gitsyncer_github_token file")
-		return 1
+	includePrivate := includePrivateRepos(cfg, flags)
+	kind := "public"
+	if includePrivate {
+		if !source.HasToken() {
+			fmt.Printf("ERROR: Listing private repositories requires a %s token\n", sourceName)
+			return 1
+		}
+		kind = "public and private"
 	}
 
-	repos, err := client.ListPublicRepos()
+	fmt.Printf("Fetching %s repositories from %s user/org: %s...\n", kind, sourceName, source.Organization().Name)
+
+	repos, err := source.ListRepos(includePrivate)
 	if err != nil {
 		fmt.Printf("ERROR: Failed to fetch repositories: %v\n", err)
 		return 1
 	}
 
-	repoNames := github.GetRepoNames(repos)
-	fmt.Printf("Found %d public repositories on GitHub\n", len(repoNames))
+	repoNames := forge.RepoNames(repos)
+	fmt.Printf("Found %d %s repositories on %s\n", len(repoNames), kind, sourceName)
 
 	if len(repoNames) == 0 {
-		fmt.Println("No public repositories found")
+		fmt.Printf("No %s repositories found\n", kind)
 		return 0
 	}
 
+	repoNames = handleDetectedRenames(cfg, flags, source, repoNames)
+
 	if flags.DryRun {
 		repoNames = filterDryRunRepoNames(repoNames, flags)
 	}
@@ -284,95 +233,179 @@ func HandleSyncGitHubPublic(cfg *config.Config, flags *Flags) int {
 	showReposToSync(repoNames)
 
 	if flags.DryRun {
-		fmt.Printf("\n[DRY RUN] Would sync %d repositories from GitHub to Codeberg\n", len(repoNames))
-		if flags.CreateCodebergRepos {
-			fmt.Println("Would create missing Codeberg repositories")
+		var targets []string
+		for _, f := range forge.Configured(cfg) {
+			if f.Organization() != source.Organization() {
+				targets = append(targets, f.DisplayName())
+				if createReposEnabled(flags, f) {
+					fmt.Printf("Would create missing %s repositories\n", f.DisplayName())
+				}
+			}
 		}
+		fmt.Printf("\n[DRY RUN] Would sync %d repositories from %s to %s\n", len(repoNames), sourceName, strings.Join(targets, ", "))
 		return 0
 	}
 
-	if !flags.DryRun {
-		return syncGitHubRepos(cfg, flags, repos, repoNames)
-	}
-
-	return 0
+	return syncDiscoveredRepos(cfg, flags, source, repos, repoNames)
 }
 
 // Helper functions
 
-func createGitHubRepoIfNeeded(cfg *config.Config, repoName string) error {
-	githubOrg := cfg.FindGitHubOrg()
-	if githubOrg == nil {
-		return nil
-	}
+// includePrivateRepos reports whether discovery should also list private
+// repositories, either via --include-private or the include_private setting
+func includePrivateRepos(cfg *config.Config, flags *Flags) bool {
+	return flags.IncludePrivate || cfg.IncludePrivate
+}
 
-	fmt.Printf("Initializing GitHub client for organization: %s\n", githubOrg.Name)
-	githubClient := github.NewClient(githubOrg.GitHubToken, githubOrg.Name)
-	if !githubClient.HasToken() {
-		fmt.Println("Warning: No GitHub token found. Cannot create repository.")
-		return nil
+// createReposEnabled reports whether missing repositories should be created on a forge
+func createReposEnabled(flags *Flags, f forge.Forge) bool {
+	switch f.Type() {
+	case forge.TypeGitHub:
+		return flags.CreateGitHubRepos
+	case forge.TypeCodeberg:
+		return flags.CreateCodebergRepos
+	case forge.TypeGitea:
+		return flags.CreateGiteaRepos
+	case forge.TypeGitLab:
+		return flags.CreateGitLabRepos
+	case forge.TypeSourceHut:
+		return flags.CreateSourceHutRepos
+	default:
+		return false
 	}
-
-	fmt.Println("Checking/creating GitHub repository...")
-	return githubClient.CreateRepo(repoName, fmt.Sprintf("Mirror of %s", repoName), false)
 }
 
-func createCodebergRepoIfNeeded(cfg *config.Config, repoName string) error {
-	codebergOrg := cfg.FindCodebergOrg()
-	if codebergOrg == nil {
-		return nil
-	}
+// initCreateForges returns the forges on which missing repositories are created
+func initCreateForges(cfg *config.Config, flags *Flags) []forge.Forge {
+	var forges []forge.Forge
+	for _, f := range forge.Configured(cfg) {
+		if !createReposEnabled(flags, f) {
+			continue
+		}
 
-	fmt.Printf("Initializing Codeberg client for organization: %s\n", codebergOrg.Name)
-	codebergClient := codeberg.NewClient(codebergOrg.Name, codebergOrg.CodebergToken)
-	if !codebergClient.HasToken() {
-		fmt.Println("Warning: No Codeberg token found. Cannot create repository.")
-		return nil
+		fmt.Printf("Initializing %s client for organization: %s\n", f.DisplayName(), f.Organization().Name)
+		if !f.HasToken() {
+			fmt.Printf("Warning: No %s token found. Cannot create repositories.\n", f.DisplayName())
+			continue
+		}
+		forges = append(forges, f)
 	}
-
-	fmt.Println("Checking/creating Codeberg repository...")
-	return codebergClient.CreateRepo(repoName, fmt.Sprintf("Mirror of %s", repoName), false)
+	return forges
 }
 
-func initGitHubClient(cfg *config.Config) *github.Client {
-	githubOrg := cfg.FindGitHubOrg()
-	if githubOrg == nil {
-		fmt.Println("Warning: --create-github-repos specified but no GitHub organization found in config")
-		return nil
+// createMissingRepo creates a repository on every given forge except the
+// source. The mirror gets the description and visibility of the source repo.
+func createMissingRepo(forges []forge.Forge, source forge.Forge, repo forge.Repository) error {
+	for _, f := range forges {
+		if source != nil && f.Organization() == source.Organization() {
+			continue
+		}
+
+		desc := repo.Description
+		if desc == "" {
+			desc = fmt.Sprintf("Mirror of %s", repo.Name)
+			if source != nil {
+				desc = fmt.Sprintf("Mirror of %s from %s", repo.Name, source.DisplayName())
+			}
+		}
+
+		visibility := "public"
+		if repo.Private {
+			visibility = "private"
+		}
+		fmt.Printf("Checking/creating %s %s repository %s...\n", visibility, f.DisplayName(), repo.Name)
+		if err := f.CreateRepo(repo.Name, desc, repo.Private); err != nil {
+			return fmt.Errorf("failed to create %s repo %s: %w", f.DisplayName(), repo.Name, err)
+		}
 	}
+	return nil
+}
 
-	fmt.Printf("Initializing GitHub client for organization: %s\n", githubOrg.Name)
-	githubClient := github.NewClient(githubOrg.GitHubToken, githubOrg.Name)
-	if !githubClient.HasToken() {
-		fmt.Println("Warning: No GitHub token found. Cannot create repositories.")
-		return nil
+// prepareConfiguredRepo records the visibility of a configured repository and
+// creates its missing mirrors. Unless strict is set, a failure to create the
+// repository on a forge other than the primary organization is only a
+// warning.
+func prepareConfiguredRepo(cfg *config.Config, createForges []forge.Forge, st *state.State, repoName string, strict bool) error {
+	existing, found := findExistingRepo(cfg, repoName)
+	if found {
+		recordRepoVisibility(st, existing)
 	}
 
-	fmt.Println("GitHub client initialized successfully with token")
-	return &githubClient
+	primary := cfg.PrimaryOrganization()
+	for _, f := range withoutNativeMirrors(cfg, createForges, repoName) {
+		err := createMissingRepo([]forge.Forge{f}, nil, existing)
+		if err == nil {
+			continue
+		}
+		if strict || f.Organization() == primary {
+			return err
+		}
+		fmt.Printf("Warning: %v\n", err)
+	}
+	return nil
 }
 
-func createRepoWithClient(client *github.Client, repoName, description string) error {
-	fmt.Printf("Checking/creating GitHub repository %s...\n", repoName)
-	return client.CreateRepo(repoName, description, false)
+// withoutNativeMirrors drops the forges that hold a native pull mirror of a
+// repository; syncNativeMirrors creates those instead
+func withoutNativeMirrors(cfg *config.Config, forges []forge.Forge, repoName string) []forge.Forge {
+	var result []forge.Forge
+	for _, f := range forges {
+		if !cfg.IsNativeMirror(f.Organization(), repoName) {
+			result = append(result, f)
+		}
+	}
+	return result
 }
 
-func initCodebergClient(cfg *config.Config) *codeberg.Client {
-	codebergOrg := cfg.FindCodebergOrg()
-	if codebergOrg == nil {
-		fmt.Println("Warning: --create-codeberg-repos specified but no Codeberg organization found in config")
-		return nil
+// findExistingRepo returns the first existing copy of a configured repository
+// in forge precedence order, so that new mirrors inherit its visibility.
+// Forges without a token are asked anonymously, which finds public
+// repositories only. If no forge has the repository, a repository with only
+// the name is returned and found is false. It is public only if every forge
+// was asked with a token; a failed or anonymous lookup may have missed a
+// private repository, so it is private otherwise.
+func findExistingRepo(cfg *config.Config, repoName string) (repo forge.Repository, found bool) {
+	checkedAll := true
+	for _, f := range forge.Configured(cfg) {
+		repo, exists, err := f.GetRepo(repoName)
+		if err != nil {
+			fmt.Printf("Warning: Failed to look up %s on %s: %v\n", repoName, f.DisplayName(), err)
+			checkedAll = false
+			continue
+		}
+		if exists {
+			repo.Name = repoName
+			return repo, true
+		}
+		if !f.HasToken() {
+			checkedAll = false
+		}
+	}
+	if !checkedAll {
+		fmt.Printf("Warning: Could not check every forge for %s; new copies are created private\n", repoName)
 	}
+	return forge.Repository{Name: repoName, Private: !checkedAll}, false
+}
 
-	fmt.Printf("Initializing Codeberg client for organization: %s\n", codebergOrg.Name)
-	codebergClient := codeberg.NewClient(codebergOrg.Name, codebergOrg.CodebergToken)
-	if !codebergClient.HasToken() {
-		fmt.Println("Warning: No Codeberg token found. Cannot create repositories.")
-		return nil
+// recordConfiguredVisibility looks up and records the visibility of
+// repositories in the sync state, for commands that do not sync them
+func recordConfiguredVisibility(cfg *config.Config, workDir string, repoNames []string) error {
+	stateManager, syncState, err := loadSyncState(workDir)
+	if err != nil {
+		return err
 	}
+	for _, name := range repoNames {
+		if repo, found := findExistingRepo(cfg, name); found {
+			recordRepoVisibility(syncState, repo)
+		}
+	}
+	return stateManager.Save(syncState)
+}
 
-	fmt.Println("Codeberg client initialized successfully with token")
-	return &codebergClient
+// recordRepoVisibility remembers whether a repository is private, so that
+// public-only output such as the showcase can leave it out
+func recordRepoVisibility(st *state.State, repo forge.Repository) {
+	st.SetRepoPrivate(repo.Name, repo.Private)
 }
 
 func showReposToSync(repoNames []string) {
@@ -420,14 +453,16 @@ func printFullSyncSeparator() {
 type syncExecution struct {
 	syncer       *sync.Syncer
 	descCache    map[string]string
+	topicsCache  map[string][]string
 	stateManager *state.Manager
 	syncState    *state.State
 }
 
 func newSyncExecution(cfg *config.Config, flags *Flags) *syncExecution {
 	execution := &syncExecution{
-		descCache: loadDescriptionCache(flags.WorkDir),
-		syncer:    sync.New(cfg, flags.WorkDir),
+		descCache:   loadDescriptionCache(flags.WorkDir),
+		topicsCache: loadTopicsCache(flags.WorkDir),
+		syncer:      sync.New(cfg, flags.WorkDir),
 	}
 	execution.syncer.SetBackupEnabled(shouldEnableBackupSync(flags))
 
@@ -462,15 +497,17 @@ func (e *syncExecution) markRepoSynced(repoName string, flags *Flags) {
 	}
 
 	recordRepoSync(repoName, e.syncState, flags.Throttle)
+	recordBackupPushes(repoName, e.syncState, e.syncer.BackupPushes())
 	if err := e.stateManager.Save(e.syncState); err != nil {
 		fmt.Printf("Warning: Failed to save sync state: %v\n", err)
 	}
 }
 
-func (e *syncExecution) finishDiscoveredSync(successCount int, flags *Flags) {
+func (e *syncExecution) finishDiscoveredSync(cfg *config.Config, successCount int, flags *Flags) {
 	if err := saveDescriptionCache(flags.WorkDir, e.descCache); err != nil {
 		fmt.Printf("Warning: Failed to save descriptions cache: %v\n", err)
 	}
+	saveMetadataCaches(cfg, flags, e.topicsCache)
 
 	fmt.Printf("\n=== Summary ===\n")
 	fmt.Printf("Successfully synced: %d repositories\n", successCount)
@@ -482,6 +519,16 @@ func (e *syncExecution) finishDiscoveredSync(successCount int, flags *Flags) {
 	printDeleteScript(e.syncer)
 }
 
+// saveMetadataCaches saves the topics cache when metadata sync is enabled
+func saveMetadataCaches(cfg *config.Config, flags *Flags, topicsCache map[string][]string) {
+	if cfg.MetadataSync == nil || flags.DryRun {
+		return
+	}
+	if err := saveTopicsCache(flags.WorkDir, topicsCache); err != nil {
+		fmt.Printf("Warning: Failed to save topics cache: %v\n", err)
+	}
+}
+
 func printDeleteScript(syncer *sync.Syncer) {
 	if scriptPath, err := syncer.GenerateDeleteScript(); err != nil {
 		fmt.Printf("\n⚠️  Failed to generate script: %v\n", err)
@@ -510,16 +557,10 @@ func printDeleteScript(syncer *sync.Syncer) {
 	}
 }
 
-func syncCodebergRepos(cfg *config.Config, flags *Flags, repos []codeberg.Repository, repoNames []string) int {
-	// Initialize GitHub client if needed
-	var githubClient github.Client
-	var hasGithubClient bool
-	if flags.CreateGitHubRepos {
-		if client := initGitHubClient(cfg); client != nil {
-			githubClient = *client
-			hasGithubClient = true
-		}
-	}
+// syncDiscoveredRepos syncs repositories discovered on a source forge
+func syncDiscoveredRepos(cfg *config.Config, flags *Flags, source forge.Forge, repos []forge.Repository, repoNames []string) int {
+	// Initialize forges on which missing repos are created
+	createForges := initCreateForges(cfg, flags)
 
 	fmt.Printf("\nStarting sync of %d repositories...\n", len(repoNames))
 
@@ -527,7 +568,7 @@ func syncCodebergRepos(cfg *config.Config, flags *Flags, repos []codeberg.Reposi
 	successCount := 0
 
 	// Create map for descriptions
-	repoMap := make(map[string]codeberg.Repository)
+	repoMap := make(map[string]forge.Repository)
 	for _, repo := range repos {
 		repoMap[repo.Name] = repo
 	}
@@ -539,20 +580,12 @@ func syncCodebergRepos(cfg *config.Config, flags *Flags, repos []codeberg.Reposi
 			continue
 		}
 
-		// Create GitHub repo if needed
-		if hasGithubClient && flags.CreateGitHubRepos {
-			codebergRepo := repoMap[repoName]
-			description := codebergRepo.Description
-			if description == "" {
-				description = fmt.Sprintf("Mirror of %s from Codeberg", repoName)
-			}
-
-			fmt.Printf("Checking/creating GitHub repository %s...\n", repoName)
-			err := githubClient.CreateRepo(repoName, description, false)
-			if err != nil {
-				fmt.Printf("Warning: Failed to create GitHub repo %s: %v\n", repoName, err)
-			}
+		// Create missing repos on the other forges if needed
+		syncNativeMirrors(cfg, flags, repoName, false)
+		if err := createMissingRepo(withoutNativeMirrors(cfg, createForges, repoName), source, repoMap[repoName]); err != nil {
+			fmt.Printf("Warning: %v\n", err)
 		}
+		recordRepoVisibility(execution.syncState, repoMap[repoName])
 
 		if err := execution.syncer.SyncRepository(repoName); err != nil {
 			fmt.Printf("ERROR: Failed to sync %s: %v\n", repoName, err)
@@ -563,86 +596,17 @@ func syncCodebergRepos(cfg *config.Config, flags *Flags, repos []codeberg.Reposi
 		successCount++
 
 		// After syncing, sync descriptions according to precedence
-		if cbRepo, ok := repoMap[repoName]; ok {
-			syncRepoDescriptions(cfg, flags.DryRun, repoName, cbRepo.Description, "", execution.descCache)
-		} else {
-			syncRepoDescriptions(cfg, flags.DryRun, repoName, "", "", execution.descCache)
-		}
+		syncRepoDescriptions(cfg, flags.DryRun, repoName, source, repoMap[repoName].Description, execution.descCache)
+		syncRepoMetadata(cfg, flags.DryRun, repoName, execution.syncer.DefaultBranch(), execution.topicsCache)
+		syncBranchProtection(cfg, flags.DryRun, repoName)
 	}
 
-	execution.finishDiscoveredSync(successCount, flags)
-
-	if !flags.SyncGitHubPublic {
-		return 0
-	}
+	execution.finishDiscoveredSync(cfg, successCount, flags)
 
 	// Print separator for full sync
-	printFullSyncSeparator()
-	return 0
-}
-
-func syncGitHubRepos(cfg *config.Config, flags *Flags, repos []github.Repository, repoNames []string) int {
-	// Initialize Codeberg client if needed
-	var codebergClient codeberg.Client
-	var hasCodebergClient bool
-	if flags.CreateCodebergRepos {
-		if client := initCodebergClient(cfg); client != nil {
-			codebergClient = *client
-			hasCodebergClient = true
-		}
-	}
-
-	fmt.Printf("\nStarting sync of %d repositories...\n", len(repoNames))
-
-	execution := newSyncExecution(cfg, flags)
-	successCount := 0
-
-	// Create map for descriptions
-	repoMap := make(map[string]github.Repository)
-	for _, repo := range repos {
-		repoMap[repo.Name] = repo
+	if source.Type() == forge.TypeCodeberg && flags.SyncGitHubPublic {
+		printFullSyncSeparator()
 	}
-
-	for i, repoName := range repoNames {
-		fmt.Printf("\n[%d/%d] Syncing %s...\n", i+1, len(repoNames), repoName)
-
-		if execution.maybeSkipRepo(repoName, flags) {
-			continue
-		}
-
-		// Create Codeberg repo if needed
-		if hasCodebergClient && flags.CreateCodebergRepos {
-			githubRepo := repoMap[repoName]
-			description := githubRepo.Description
-			if description == "" {
-				description = fmt.Sprintf("Mirror of %s from GitHub", repoName)
-			}
-
-			fmt.Printf("Checking/creating Codeberg repository %s...\n", repoName)
-			err := codebergClient.CreateRepo(repoName, description, false)
-			if err != nil {
-				fmt.Printf("Warning: Failed to create Codeberg repo %s: %v\n", repoName, err)
-			}
-		}
-
-		if err := execution.syncer.SyncRepository(repoName); err != nil {
-			fmt.Printf("ERROR: Failed to sync %s: %v\n", repoName, err)
-			fmt.Printf("Stopping sync due to error.\n")
-			return 1
-		}
-		execution.markRepoSynced(repoName, flags)
-		successCount++
-
-		// After syncing, sync descriptions according to precedence
-		if ghRepo, ok := repoMap[repoName]; ok {
-			syncRepoDescriptions(cfg, flags.DryRun, repoName, "", ghRepo.Description, execution.descCache)
-		} else {
-			syncRepoDescriptions(cfg, flags.DryRun, repoName, "", "", execution.descCache)
-		}
-	}
-
-	execution.finishDiscoveredSync(successCount, flags)
-
 	return 0
 }
 
@@ -1,6 +1,16 @@
 package cli
 
-import "testing"
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
 
 func TestShouldEnableBackupSync_FullSyncImplicitlyEnablesBackup(t *testing.T) {
 	t.Parallel()
@@ -17,3 +27,130 @@ func TestShouldEnableBackupSync_FullSyncImplicitlyEnablesBackup(t *testing.T) {
 		t.Fatal("did not expect backup sync to be enabled by default")
 	}
 }
+
+// recordingForge records the repositories created on it
+type recordingForge struct {
+	forge.Forge
+	org     *config.Organization
+	created map[string]bool // repo name -> private
+	err     error           // returned by CreateRepo if set
+}
+
+func (f *recordingForge) DisplayName() string                { return "Stub" }
+func (f *recordingForge) Organization() *config.Organization { return f.org }
+
+func (f *recordingForge) RepoExists(repoName string) (bool, error) {
+	_, ok := f.created[repoName]
+	return ok, nil
+}
+
+func (f *recordingForge) CreateRepo(repoName, description string, private bool) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.created[repoName] = private
+	return nil
+}
+
+func TestCreateMissingRepo_KeepsSourceVisibility(t *testing.T) {
+	source := &recordingForge{org: &config.Organization{Name: "source"}, created: map[string]bool{}}
+	target := &recordingForge{org: &config.Organization{Name: "target"}, created: map[string]bool{}}
+	forges := []forge.Forge{source, target}
+
+	if err := createMissingRepo(forges, source, forge.Repository{Name: "secret", Private: true}); err != nil {
+		t.Fatalf("createMissingRepo() error = %v", err)
+	}
+	if err := createMissingRepo(forges, source, forge.Repository{Name: "tool"}); err != nil {
+		t.Fatalf("createMissingRepo() error = %v", err)
+	}
+
+	if len(source.created) != 0 {
+		t.Fatalf("expected nothing to be created on the source, got %v", source.created)
+	}
+	if private, ok := target.created["secret"]; !ok || !private {
+		t.Fatalf("expected a private mirror of secret, got %v", target.created)
+	}
+	if private, ok := target.created["tool"]; !ok || private {
+		t.Fatalf("expected a public mirror of tool, got %v", target.created)
+	}
+}
+
+func TestPrepareConfiguredRepo_OnlyPrimaryFailureAborts(t *testing.T) {
+	cfg := &config.Config{Organizations: []config.Organization{
+		{Host: "file:///primary"},
+		{Host: "file:///secondary"},
+	}}
+	primary := &recordingForge{org: &cfg.Organizations[0], created: map[string]bool{}}
+	secondary := &recordingForge{org: &cfg.Organizations[1], created: map[string]bool{}, err: errors.New("quota exceeded")}
+	forges := []forge.Forge{secondary, primary}
+
+	if err := prepareConfiguredRepo(cfg, forges, nil, "tool", false); err != nil {
+		t.Fatalf("expected a secondary failure to be a warning, got %v", err)
+	}
+	if _, ok := primary.created["tool"]; !ok {
+		t.Fatal("expected the primary repository to be created after the secondary failed")
+	}
+	if err := prepareConfiguredRepo(cfg, forges, nil, "tool", true); err == nil {
+		t.Fatal("expected a secondary failure to abort in strict mode")
+	}
+
+	primary.err = errors.New("quota exceeded")
+	secondary.err = nil
+	if err := prepareConfiguredRepo(cfg, forges, nil, "other", false); err == nil {
+		t.Fatal("expected a primary failure to abort")
+	}
+}
+
+func TestPrepareConfiguredRepo_UncheckedForgesCreatePrivateCopies(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GITEA_TOKEN", "")
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	// Gitea answers 404 for private repositories asked without a token
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	tests := []struct {
+		name string
+		org  config.Organization
+	}{
+		{"lookup error", config.Organization{Host: "git@git.example.com", Name: "me", Type: config.TypeGitea, APIURL: failing.URL, GiteaToken: "secret"}},
+		{"anonymous lookup", config.Organization{Host: "git@git.example.com", Name: "me", Type: config.TypeGitea, APIURL: notFound.URL}},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Organizations: []config.Organization{tt.org, {Host: "file:///backup"}}}
+		target := &recordingForge{org: &cfg.Organizations[1], created: map[string]bool{}}
+		if err := prepareConfiguredRepo(cfg, []forge.Forge{target}, nil, "tool", true); err != nil {
+			t.Fatalf("%s: prepareConfiguredRepo() error = %v", tt.name, err)
+		}
+		if private, ok := target.created["tool"]; !ok || !private {
+			t.Fatalf("%s: expected a private copy, got %v", tt.name, target.created)
+		}
+	}
+}
+
+func TestIncludePrivateRepos_FlagOrConfig(t *testing.T) {
+	t.Parallel()
+
+	if includePrivateRepos(&config.Config{}, &Flags{}) {
+		t.Fatal("did not expect private repositories to be included by default")
+	}
+	if !includePrivateRepos(&config.Config{IncludePrivate: true}, &Flags{}) {
+		t.Fatal("expected include_private to include private repositories")
+	}
+	if !includePrivateRepos(&config.Config{}, &Flags{IncludePrivate: true}) {
+		t.Fatal("expected --include-private to include private repositories")
+	}
+}
+
+func TestNewShowcaseGenerator_FailsWithoutState(t *testing.T) {
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, ".gitsyncer-state.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newShowcaseGenerator(&config.Config{}, &Flags{WorkDir: workDir}); err == nil {
+		t.Fatal("expected an unreadable sync state to stop the showcase")
+	}
+}
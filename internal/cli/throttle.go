@@ -39,6 +39,19 @@ type syncDecision struct {
 }
 
 func evaluateSyncPolicy(repoName string, st *state.State, dryRun bool, force bool, throttle bool) syncDecision {
+	// Archived repositories are read-only on the forges, so not even --force syncs them
+	if archived, ok := st.RepoArchived(repoName); ok {
+		skipAction := "Skipping"
+		if dryRun {
+			skipAction = "[DRY RUN] Would skip"
+		}
+		return syncDecision{
+			Skip: true,
+			Message: fmt.Sprintf("%s %s: archived at %s. Run 'gitsyncer manage unarchive-repo %s' to sync it again.",
+				skipAction, repoName, archived.Format("2006-01-02 15:04"), repoName),
+		}
+	}
+
 	if force {
 		return syncDecision{}
 	}
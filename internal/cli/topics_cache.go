@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// loadTopicsCache loads the per-repo canonical topics cache
+func loadTopicsCache(workDir string) map[string][]string {
+	cache := make(map[string][]string)
+	cacheFile := filepath.Join(workDir, ".gitsyncer-topics-cache.json")
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		fmt.Printf("Warning: Failed to parse topics cache: %v\n", err)
+		return make(map[string][]string)
+	}
+	fmt.Printf("Loaded topics cache with %d entries\n", len(cache))
+	return cache
+}
+
+// saveTopicsCache saves the per-repo canonical topics cache
+func saveTopicsCache(workDir string, cache map[string][]string) error {
+	cacheFile := filepath.Join(workDir, ".gitsyncer-topics-cache.json")
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal topics cache: %w", err)
+	}
+	if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write topics cache: %w", err)
+	}
+	return nil
+}
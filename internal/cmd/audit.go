@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+
+	"codeberg.org/snonux/gitsyncer/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var auditShowAll bool
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit repositories across organizations",
+	Long:  `Commands for finding inconsistencies between the configured organizations.`,
+}
+
+var auditReposCmd = &cobra.Command{
+	Use:   "repos",
+	Short: "Report repositories missing on some organizations",
+	Long: `List the repositories of every configured organization, including forks,
+archived repositories and pull mirrors, and build the matrix of which repository
+exists where. Forges are listed through their API, SSH, file:// and S3 backup
+locations through their directory listing. Every mismatch is classified, e.g.
+repositories that only exist as a fork, archived on one forge, mirrors whose
+source is gone or backups whose forge copies are gone, and an action is
+suggested. Exits non-zero if any mismatch is found.`,
+	Args: cobra.NoArgs,
+	Example: `  # Report mismatched repositories
+  gitsyncer audit repos
+
+  # Show the full repository × organization matrix
+  gitsyncer audit repos --all`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(cli.HandleAuditRepos(cfg, buildFlags(), auditShowAll))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditReposCmd)
+
+	auditReposCmd.Flags().BoolVar(&auditShowAll, "all", false, "show every repository in the matrix, not only mismatched ones")
+}
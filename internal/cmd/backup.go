@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreFrom        string
+	restoreTo          string
+	restoreAll         bool
+	restoreCreateRepos bool
+	verifyFsck         bool
+	reportMaxAge       time.Duration
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Work with backup locations",
+	Long:  `Commands for restoring and inspecting repositories stored on backup locations.`,
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore [repo]",
+	Short: "Restore repositories from a backup location",
+	Long: `Clone a repository (or bundle) from a backup location and push every
+branch and tag to the target organization. Use --all to rebuild every
+repository stored on the backup location, e.g. after losing a forge account.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Restore one repository from the NAS to GitHub
+  gitsyncer backup restore myproject --from paul@nas:git --to git@github.com
+
+  # Create missing repositories on Codeberg while restoring
+  gitsyncer backup restore myproject --from paul@nas:git --to git@codeberg.org --create-repos
+
+  # Preview a full rebuild from an S3 backup
+  gitsyncer backup restore --all --from s3://my-bucket/gitsyncer --to git@codeberg.org --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+
+		opts := cli.RestoreOptions{
+			From:        restoreFrom,
+			To:          restoreTo,
+			All:         restoreAll,
+			CreateRepos: restoreCreateRepos,
+		}
+		if len(args) > 0 {
+			opts.RepoName = args[0]
+		}
+
+		os.Exit(cli.HandleBackupRestore(cfg, flags, opts))
+	},
+}
+
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify [repo]",
+	Short: "Verify backup locations against the work directory",
+	Long: `Compare the branches and tags of every backup copy with the work-dir
+clone and report missing or stale backups. Successful checks are recorded
+in the state file. Exits non-zero if any backup is unhealthy.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Verify all work-dir repositories on all backup locations
+  gitsyncer backup verify
+
+  # Verify one repository and run git fsck on the backup copies
+  gitsyncer backup verify myproject --fsck`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+
+		opts := cli.VerifyOptions{Fsck: verifyFsck}
+		if len(args) > 0 {
+			opts.RepoName = args[0]
+		}
+
+		os.Exit(cli.HandleBackupVerify(cfg, flags, opts))
+	},
+}
+
+var backupReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report backups that have not been pushed recently",
+	Long: `List every synced repository whose last successful push to a backup
+location is older than --max-age, or which was never pushed there. The
+report only reads the state file, so it is cheap enough for monitoring.
+Exits non-zero if any backup is stale.`,
+	Args: cobra.NoArgs,
+	Example: `  # Report backups older than a week (default)
+  gitsyncer backup report
+
+  # Allow up to 30 days between backup pushes
+  gitsyncer backup report --max-age 720h`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		os.Exit(cli.HandleBackupReport(cfg, flags, reportMaxAge))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	backupCmd.AddCommand(backupVerifyCmd)
+	backupCmd.AddCommand(backupReportCmd)
+
+	backupCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "preview what would be done")
+
+	backupRestoreCmd.Flags().StringVar(&restoreFrom, "from", "", "backup location host to restore from")
+	backupRestoreCmd.Flags().StringVar(&restoreTo, "to", "", "organization host to restore to")
+	backupRestoreCmd.Flags().BoolVar(&restoreAll, "all", false, "restore every repository found on the backup location")
+	backupRestoreCmd.Flags().BoolVar(&restoreCreateRepos, "create-repos", false, "create missing repositories on the target forge")
+	backupRestoreCmd.MarkFlagRequired("from")
+	backupRestoreCmd.MarkFlagRequired("to")
+
+	backupVerifyCmd.Flags().BoolVar(&verifyFsck, "fsck", false, "run git fsck --connectivity-only on backup copies")
+
+	backupReportCmd.Flags().DurationVar(&reportMaxAge, "max-age", 7*24*time.Hour, "maximum age of the last backup push")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+
+	"codeberg.org/snonux/gitsyncer/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import repositories from a list of clone URLs",
+	Long: `Import existing repositories from any host. The file lists one clone URL
+per line; blank lines and lines starting with # are ignored. Each repository is
+created on every configured forge with the description and visibility of the
+source, or as private if the source cannot be looked up, all branches and tags
+are pushed, the work-dir clone is set up and the
+names are appended to the repositories of the configuration file, keeping its
+formatting. Already configured repositories are imported again, so a failed
+import can simply be rerun.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # Import the repositories listed in repos.txt
+  gitsyncer import repos.txt
+
+  # Preview the import
+  gitsyncer import repos.txt --dry-run
+
+  # Also push to backup locations
+  gitsyncer import repos.txt --backup`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(cli.HandleImport(cfg, buildFlags(), args[0]))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be imported")
+	importCmd.Flags().BoolVar(&backup, "backup", false, "also push to backup locations")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+
+	"codeberg.org/snonux/gitsyncer/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var issuesReportOnly bool
+
+var issuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Mirror issues between forges",
+	Long:  `Commands for mirroring issues and their comments between GitHub and Codeberg.`,
+}
+
+var issuesSyncCmd = &cobra.Command{
+	Use:   "sync [repo]",
+	Short: "Mirror issues and comments between GitHub and Codeberg",
+	Long: `Copy open issues filed on GitHub to Codeberg and vice versa, with a
+backlink to the original. New comments on either copy are copied to the other
+one, and a mirrored issue is closed once its original is closed. Mirrored
+issues are recorded in .gitsyncer-issues.json in the work directory, so reruns
+never mirror an issue twice. Without a name, all configured and discovered
+repositories are processed.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Report which issues and comments would be mirrored
+  gitsyncer issues sync --report-only
+
+  # Mirror the issues of one repository
+  gitsyncer issues sync myproject`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+
+		opts := cli.IssuesSyncOptions{ReportOnly: issuesReportOnly}
+		if len(args) > 0 {
+			opts.RepoName = args[0]
+		}
+
+		os.Exit(cli.HandleIssuesSync(cfg, flags, opts))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(issuesCmd)
+	issuesCmd.AddCommand(issuesSyncCmd)
+
+	issuesSyncCmd.Flags().BoolVar(&issuesReportOnly, "report-only", false, "only report what would be mirrored, without writing anything")
+	issuesSyncCmd.Flags().BoolVar(&includePrivate, "include-private", false, "also mirror the issues of private repositories")
+}
@@ -18,35 +18,108 @@ var manageCmd = &cobra.Command{
 	Long:  `Commands for managing repositories, workspace, and automated operations.`,
 }
 
+var keepBackups bool
+
 var deleteRepoCmd = &cobra.Command{
 	Use:   "delete-repo [name]",
 	Short: "Delete repository from all organizations",
-	Long:  `Delete a specified repository from all configured organizations with confirmation.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Delete a specified repository from all configured organizations with confirmation:
+forges through their API, SSH and file:// locations by removing the bare
+repository and S3 locations by removing the bundle. A git bundle of the
+work-directory clone is always written to .gitsyncer-deleted/ first, and every
+deletion is appended to .gitsyncer-audit.log in the work directory.`,
+	Args: cobra.ExactArgs(1),
 	Example: `  # Delete a repository from all organizations
-  gitsyncer manage delete-repo old-project`,
+  gitsyncer manage delete-repo old-project
+
+  # Show where it would be deleted
+  gitsyncer manage delete-repo old-project --dry-run
+
+  # Delete it from the forges only
+  gitsyncer manage delete-repo old-project --keep-backups`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		flags.Force = force
+		os.Exit(cli.HandleDeleteRepo(cfg, flags, args[0], keepBackups))
+	},
+}
+
+var renameRepoCmd = &cobra.Command{
+	Use:   "rename-repo [old] [new]",
+	Short: "Rename repository on all organizations",
+	Long: `Rename a repository on all configured forges and SSH/file backup locations,
+move its work-directory clone, update the clone's remote URLs and carry over
+the sync state, metadata caches, issue mapping and showcase data.
+Configuration entries mentioning the old name are listed for manual editing.`,
+	Args: cobra.ExactArgs(2),
+	Example: `  # Preview the rename
+  gitsyncer manage rename-repo old-name new-name --dry-run
+
+  # Rename without confirmation
+  gitsyncer manage rename-repo old-name new-name --force`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		flags.Force = force
+		os.Exit(cli.HandleRenameRepo(cfg, flags, args[0], args[1]))
+	},
+}
+
+var archiveRepoCmd = &cobra.Command{
+	Use:   "archive-repo [name]",
+	Short: "Archive repository on all organizations",
+	Long: `Archive a repository instead of deleting it: write a final git bundle of all
+branches and tags to .gitsyncer-archive/ in the work directory, prefix the
+description with "[archived]", archive the repository on every forge that
+supports it and skip it in all future syncs.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # Archive a repository everywhere
+  gitsyncer manage archive-repo old-project
+
+  # Show what would be archived
+  gitsyncer manage archive-repo old-project --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		flags.Force = force
+		os.Exit(cli.HandleArchiveRepo(cfg, flags, args[0]))
+	},
+}
+
+var unarchiveRepoCmd = &cobra.Command{
+	Use:   "unarchive-repo [name]",
+	Short: "Unarchive repository on all organizations",
+	Long: `Reverse archive-repo: unarchive the repository on every forge, remove the
+"[archived]" note from the description and sync the repository again.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # Bring an archived repository back
+  gitsyncer manage unarchive-repo old-project`,
 	Run: func(cmd *cobra.Command, args []string) {
-		os.Exit(cli.HandleDeleteRepo(cfg, args[0]))
+		os.Exit(cli.HandleUnarchiveRepo(cfg, buildFlags(), args[0]))
 	},
 }
 
+var gc bool
+
 var cleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Clean work directory",
-	Long:  `Delete all repositories in the work directory with confirmation.`,
+	Long: `List the clones in the work directory and flag orphans: clones that are
+neither configured nor discovered on any forge, and configured ones that no
+forge has any more. Orphans are deleted after confirmation, or all at once with
+--force; clones with uncommitted changes, unpushed commits or stashes are never
+deleted.`,
 	Example: `  # Clean the work directory
   gitsyncer manage clean
   
   # Force clean without confirmation
-  gitsyncer manage clean --force`,
+  gitsyncer manage clean --force
+
+  # Also garbage collect the remaining clones
+  gitsyncer manage clean --gc`,
 	Run: func(cmd *cobra.Command, args []string) {
 		flags := buildFlags()
 		flags.Clean = true
 		flags.Force = force
-
-		// TODO: Implement clean handler
-		fmt.Println("Clean command not yet implemented")
-		os.Exit(1)
+		os.Exit(cli.HandleClean(cfg, flags, gc))
 	},
 }
 
@@ -133,10 +206,23 @@ This is designed for automated weekly synchronization from cron jobs or shell sc
 func init() {
 	rootCmd.AddCommand(manageCmd)
 	manageCmd.AddCommand(deleteRepoCmd)
+	manageCmd.AddCommand(renameRepoCmd)
+	manageCmd.AddCommand(archiveRepoCmd)
+	manageCmd.AddCommand(unarchiveRepoCmd)
 	manageCmd.AddCommand(cleanCmd)
 	manageCmd.AddCommand(batchRunCmd)
 
 	// Manage-specific flags
+	deleteRepoCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show where the repository would be deleted without deleting")
+	deleteRepoCmd.Flags().BoolVar(&keepBackups, "keep-backups", false, "keep the repository on backup locations")
+	deleteRepoCmd.Flags().BoolVarP(&force, "force", "f", false, "delete without confirmation")
+	renameRepoCmd.Flags().BoolVarP(&force, "force", "f", false, "rename without confirmation")
+	renameRepoCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be renamed without renaming")
+	archiveRepoCmd.Flags().BoolVarP(&force, "force", "f", false, "archive again even if already archived")
+	archiveRepoCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be archived without archiving")
+	unarchiveRepoCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be unarchived without unarchiving")
 	cleanCmd.Flags().BoolVarP(&force, "force", "f", false, "force operation without confirmation")
+	cleanCmd.Flags().BoolVar(&gc, "gc", false, "run git gc --prune=now on the remaining clones")
+	cleanCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show orphans without deleting them")
 	batchRunCmd.Flags().BoolVarP(&force, "force", "f", false, "force run even if already run this week")
 }
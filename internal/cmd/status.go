@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+
+	"codeberg.org/snonux/gitsyncer/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [repo]",
+	Short: "Show the mirror mode and last sync of repositories",
+	Long: `Show the mirror mode and the time of the last local sync of the given
+repository, or of all configured ones. For repositories in native mirror mode,
+the pull mirror of every Codeberg and Gitea/Forgejo organization is looked up
+and the time of its last update is reported.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Show the status of all repositories
+  gitsyncer status
+
+  # Show when the native mirrors of myproject last pulled
+  gitsyncer status myproject`,
+	Run: func(cmd *cobra.Command, args []string) {
+		repoName := ""
+		if len(args) == 1 {
+			repoName = args[0]
+		}
+		os.Exit(cli.HandleStatus(cfg, buildFlags(), repoName))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -17,6 +17,9 @@ var (
 	syncAITool       string
 	throttle         bool
 	syncForce        bool
+	includePrivate   bool
+	convertMirrors   bool
+	propagateRenames bool
 )
 
 var syncCmd = &cobra.Command{
@@ -85,6 +88,76 @@ var syncAllCmd = &cobra.Command{
 	},
 }
 
+var syncMetadataCmd = &cobra.Command{
+	Use:   "metadata [name]",
+	Short: "Reconcile repository settings across forges",
+	Long: `Reconcile homepage, topics, archived flag, default branch and the
+issues/wiki toggles of a repository across all forges, without syncing git
+data. Without a name, all configured and discovered repositories are processed.
+Fields and per-field precedence are read from metadata_sync in the config.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Preview the settings changes for all repositories
+  gitsyncer sync metadata --dry-run
+
+  # Reconcile the settings of one repository
+  gitsyncer sync metadata myproject`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		if len(args) == 1 {
+			flags.SyncRepo = args[0]
+		}
+		os.Exit(cli.HandleSyncMetadata(cfg, flags))
+	},
+}
+
+var syncProtectionCmd = &cobra.Command{
+	Use:   "protection [name]",
+	Short: "Mirror branch protection rules across forges",
+	Long: `Mirror the branch protection rules of the source forge (the first
+configured organization, or branch_protection_sync.source) to all other forges,
+without syncing git data. Required reviews, force push and deletion settings are
+translated; other options are reported as not mirrored. Without a name, all
+configured and discovered repositories are processed.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Preview the branch protection changes for all repositories
+  gitsyncer sync protection --dry-run
+
+  # Mirror the branch protection of one repository
+  gitsyncer sync protection myproject`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		if len(args) == 1 {
+			flags.SyncRepo = args[0]
+		}
+		os.Exit(cli.HandleSyncProtection(cfg, flags))
+	},
+}
+
+var syncNativeMirrorsCmd = &cobra.Command{
+	Use:   "native-mirrors [name]",
+	Short: "Set up Codeberg/Gitea pull mirrors of native-mode repositories",
+	Long: `Make the Codeberg and Gitea/Forgejo copies of repositories with
+"mirror_mode": "native" pull mirrors of the source forge (the first GitHub,
+GitLab or SourceHut organization). Missing mirrors are created and their pull
+interval is set to native_mirror_interval. Existing regular repositories are
+only replaced by mirrors with --convert, which deletes them first, including
+their issues and pull requests. Without a name, all native-mode repositories
+are processed.`,
+	Args: cobra.MaximumNArgs(1),
+	Example: `  # Preview which mirrors would be created
+  gitsyncer sync native-mirrors --dry-run
+
+  # Replace the regular Codeberg repository of myproject with a pull mirror
+  gitsyncer sync native-mirrors myproject --convert`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+		if len(args) == 1 {
+			flags.SyncRepo = args[0]
+		}
+		os.Exit(cli.HandleSyncNativeMirrors(cfg, flags, convertMirrors))
+	},
+}
+
 var syncCodebergToGitHubCmd = &cobra.Command{
 	Use:   "codeberg-to-github",
 	Short: "Sync public Codeberg repos to GitHub",
@@ -141,6 +214,79 @@ var syncGitHubToCodebergCmd = &cobra.Command{
 	},
 }
 
+var syncGiteaPublicCmd = &cobra.Command{
+	Use:   "gitea-public",
+	Short: "Sync public repos of a self-hosted Gitea/Forgejo instance",
+	Long: `Synchronize all public repositories of the first self-hosted Gitea or
+Forgejo organization (type "gitea" or "forgejo" in the config) to all other
+configured organizations.`,
+	Example: `  # Sync public Forgejo repos to GitHub and Codeberg
+  gitsyncer sync gitea-public
+
+  # Auto-create missing repos on the other forges
+  gitsyncer sync gitea-public --create-repos
+
+  # Preview what would be synced
+  gitsyncer sync gitea-public --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+
+		exitCode := cli.HandleSyncGiteaPublic(cfg, flags)
+		if exitCode == 0 && !noReleases {
+			cli.HandleCheckReleases(cfg, flags)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+var syncGitLabPublicCmd = &cobra.Command{
+	Use:   "gitlab-public",
+	Short: "Sync public GitLab projects",
+	Long: `Synchronize all public projects of the first GitLab user or group
+(gitlab.com or type "gitlab" in the config) to all other configured organizations.`,
+	Example: `  # Sync public GitLab projects to GitHub and Codeberg
+  gitsyncer sync gitlab-public
+
+  # Auto-create missing repos on the other forges
+  gitsyncer sync gitlab-public --create-repos
+
+  # Preview what would be synced
+  gitsyncer sync gitlab-public --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+
+		exitCode := cli.HandleSyncGitLabPublic(cfg, flags)
+		if exitCode == 0 && !noReleases {
+			cli.HandleCheckReleases(cfg, flags)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+var syncSourceHutPublicCmd = &cobra.Command{
+	Use:   "sourcehut-public",
+	Short: "Sync public SourceHut repos",
+	Long: `Synchronize all public repositories of the first SourceHut user
+(git.sr.ht or type "sourcehut" in the config) to all other configured organizations.`,
+	Example: `  # Sync public sr.ht repos to GitHub and Codeberg
+  gitsyncer sync sourcehut-public
+
+  # Auto-create missing repos on the other forges
+  gitsyncer sync sourcehut-public --create-repos
+
+  # Preview what would be synced
+  gitsyncer sync sourcehut-public --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := buildFlags()
+
+		exitCode := cli.HandleSyncSourceHutPublic(cfg, flags)
+		if exitCode == 0 && !noReleases {
+			cli.HandleCheckReleases(cfg, flags)
+		}
+		os.Exit(exitCode)
+	},
+}
+
 var syncBidirectionalCmd = &cobra.Command{
 	Use:   "bidirectional",
 	Short: "Full bidirectional sync of all public repos",
@@ -161,6 +307,9 @@ repositories between GitHub and Codeberg. This is equivalent to the old --full f
 		flags.SyncGitHubPublic = true
 		flags.CreateGitHubRepos = true
 		flags.CreateCodebergRepos = true
+		flags.CreateGiteaRepos = true
+		flags.CreateGitLabRepos = true
+		flags.CreateSourceHutRepos = true
 
 		// First sync Codeberg to GitHub
 		exitCode := cli.HandleSyncCodebergPublic(cfg, flags)
@@ -185,7 +334,15 @@ func init() {
 	syncCmd.AddCommand(syncAllCmd)
 	syncCmd.AddCommand(syncCodebergToGitHubCmd)
 	syncCmd.AddCommand(syncGitHubToCodebergCmd)
+	syncCmd.AddCommand(syncGiteaPublicCmd)
+	syncCmd.AddCommand(syncGitLabPublicCmd)
+	syncCmd.AddCommand(syncSourceHutPublicCmd)
 	syncCmd.AddCommand(syncBidirectionalCmd)
+	syncCmd.AddCommand(syncMetadataCmd)
+	syncCmd.AddCommand(syncProtectionCmd)
+	syncCmd.AddCommand(syncNativeMirrorsCmd)
+
+	syncNativeMirrorsCmd.Flags().BoolVar(&convertMirrors, "convert", false, "delete existing regular repositories and recreate them as pull mirrors")
 
 	// Sync flags (available for all sync subcommands)
 	syncCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "preview what would be synced")
@@ -197,21 +354,28 @@ func init() {
 	syncCmd.PersistentFlags().StringVar(&syncAITool, "ai-tool", "opencode", "AI tool to use for release notes when auto-creating (opencode, amp, claude, or hexai; opencode is tried first if available)")
 	syncCmd.PersistentFlags().BoolVarP(&syncForce, "force", "f", false, "force sync even if normal sync interval checks would skip a repository")
 	syncCmd.PersistentFlags().BoolVar(&throttle, "throttle", false, "throttle syncing based on local repo activity")
+	syncCmd.PersistentFlags().BoolVar(&includePrivate, "include-private", false, "also discover and mirror private repositories (requires tokens)")
+	syncCmd.PersistentFlags().BoolVar(&propagateRenames, "propagate-renames", false, "rename repositories on all organizations when discovery detects a rename")
 }
 
 func buildFlags() *cli.Flags {
 	return &cli.Flags{
-		ConfigPath:          cfgFile,
-		WorkDir:             workDir,
-		DryRun:              dryRun,
-		Backup:              backup,
-		NoCheckReleases:     noReleases,
-		AutoCreateReleases:  autoCreate,
-		AIReleaseNotes:      !noAIReleaseNotes,
-		AITool:              syncAITool,
-		Force:               syncForce,
-		Throttle:            throttle,
-		CreateGitHubRepos:   createRepos,
-		CreateCodebergRepos: createRepos,
+		ConfigPath:           cfgFile,
+		WorkDir:              workDir,
+		DryRun:               dryRun,
+		Backup:               backup,
+		NoCheckReleases:      noReleases,
+		AutoCreateReleases:   autoCreate,
+		AIReleaseNotes:       !noAIReleaseNotes,
+		AITool:               syncAITool,
+		Force:                syncForce,
+		Throttle:             throttle,
+		IncludePrivate:       includePrivate,
+		PropagateRenames:     propagateRenames,
+		CreateGitHubRepos:    createRepos,
+		CreateCodebergRepos:  createRepos,
+		CreateGiteaRepos:     createRepos,
+		CreateGitLabRepos:    createRepos,
+		CreateSourceHutRepos: createRepos,
 	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"codeberg.org/snonux/gitsyncer/internal/cli"
 	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +22,7 @@ var testGitHubCmd = &cobra.Command{
 	Example: `  # Test GitHub token authentication
   gitsyncer test github-token`,
 	Run: func(cmd *cobra.Command, args []string) {
-		os.Exit(cli.HandleTestGitHubToken())
+		os.Exit(cli.HandleTestToken(cfg, forge.TypeGitHub))
 	},
 }
 
@@ -31,9 +32,37 @@ var testCodebergCmd = &cobra.Command{
 	Example: `  # Test Codeberg token authentication
   gitsyncer test codeberg-token`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// TODO: Implement Codeberg token test
-		fmt.Println("Codeberg token test not yet implemented")
-		os.Exit(1)
+		os.Exit(cli.HandleTestToken(cfg, forge.TypeCodeberg))
+	},
+}
+
+var testGiteaCmd = &cobra.Command{
+	Use:   "gitea-token",
+	Short: "Test self-hosted Gitea/Forgejo authentication",
+	Example: `  # Test the token of the first Gitea/Forgejo organization
+  gitsyncer test gitea-token`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(cli.HandleTestToken(cfg, forge.TypeGitea))
+	},
+}
+
+var testGitLabCmd = &cobra.Command{
+	Use:   "gitlab-token",
+	Short: "Test GitLab authentication",
+	Example: `  # Test the token of the first GitLab organization
+  gitsyncer test gitlab-token`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(cli.HandleTestToken(cfg, forge.TypeGitLab))
+	},
+}
+
+var testSourceHutCmd = &cobra.Command{
+	Use:   "sourcehut-token",
+	Short: "Test SourceHut authentication",
+	Example: `  # Test the token of the first SourceHut organization
+  gitsyncer test sourcehut-token`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(cli.HandleTestToken(cfg, forge.TypeSourceHut))
 	},
 }
 
@@ -58,25 +87,15 @@ var testConfigCmd = &cobra.Command{
 		fmt.Printf("  Repositories: %d\n", len(cfg.Repositories))
 
 		// Check for common issues
-		hasGitHub := false
-		hasCodeberg := false
-		for _, org := range cfg.Organizations {
-			if org.Host == "git@github.com" {
-				hasGitHub = true
-				if org.GitHubToken == "" {
-					fmt.Println("  ⚠️  Warning: GitHub organization without token")
-				}
-			}
-			if org.Host == "git@codeberg.org" {
-				hasCodeberg = true
-				if org.CodebergToken == "" {
-					fmt.Println("  ⚠️  Warning: Codeberg organization without token")
-				}
+		forges := forge.Configured(cfg)
+		for _, f := range forges {
+			if !f.HasToken() {
+				fmt.Printf("  ⚠️  Warning: %s organization %s without token\n", f.DisplayName(), f.Organization().Name)
 			}
 		}
 
-		if !hasGitHub && !hasCodeberg {
-			fmt.Println("  ⚠️  Warning: No GitHub or Codeberg organizations configured")
+		if len(forges) == 0 {
+			fmt.Println("  ⚠️  Warning: No forge organizations configured")
 		}
 
 		os.Exit(0)
@@ -87,5 +106,8 @@ func init() {
 	rootCmd.AddCommand(testCmd)
 	testCmd.AddCommand(testGitHubCmd)
 	testCmd.AddCommand(testCodebergCmd)
+	testCmd.AddCommand(testGiteaCmd)
+	testCmd.AddCommand(testGitLabCmd)
+	testCmd.AddCommand(testSourceHutCmd)
 	testCmd.AddCommand(testConfigCmd)
 }
@@ -0,0 +1,57 @@
+package codeberg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// jsonRequest sends an authenticated API request with an optional JSON
+// payload and decodes the response into out if it is non-nil. what names the
+// requested resource in error messages.
+func (c *Client) jsonRequest(method, endpoint, what string, payload, out interface{}) error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token required for %s", c.name, what)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	req, cancel, err := httpclient.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.Header.Set("Authorization", "token "+c.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s request failed: %s - %s", c.name, what, resp.Status, string(b))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", what, err)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"codeberg.org/snonux/gitsyncer/internal/httpclient"
@@ -15,59 +16,88 @@ import (
 
 // Repository represents a Codeberg/Gitea repository
 type Repository struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	FullName    string    `json:"full_name"`
-	Description string    `json:"description"`
-	Private     bool      `json:"private"`
-	Fork        bool      `json:"fork"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CloneURL    string    `json:"clone_url"`
-	SSHURL      string    `json:"ssh_url"`
-	Size        int       `json:"size"`
-	Archived    bool      `json:"archived"`
-	Empty       bool      `json:"empty"`
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Description   string    `json:"description"`
+	Private       bool      `json:"private"`
+	Fork          bool      `json:"fork"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	CloneURL      string    `json:"clone_url"`
+	SSHURL        string    `json:"ssh_url"`
+	Size          int       `json:"size"`
+	Archived      bool      `json:"archived"`
+	Empty         bool      `json:"empty"`
+	Website       string    `json:"website"`
+	Topics        []string  `json:"topics"`
+	DefaultBranch string    `json:"default_branch"`
+	HasIssues     bool      `json:"has_issues"`
+	HasWiki       bool      `json:"has_wiki"`
+	// Pull mirror state, see MigrateMirror
+	Mirror         bool      `json:"mirror"`
+	MirrorInterval string    `json:"mirror_interval"`
+	MirrorUpdated  time.Time `json:"mirror_updated"`
+	OriginalURL    string    `json:"original_url"`
 }
 
-// Client handles Codeberg API operations
+// Client handles Codeberg API operations. It speaks the Gitea API and is
+// therefore also used for self-hosted Gitea and Forgejo instances.
 type Client struct {
 	baseURL string
+	name    string
 	org     string
 	token   string
 }
 
 // NewClient creates a new Codeberg API client
 func NewClient(org, token string) Client {
-	c := Client{
+	return Client{
 		baseURL: "https://codeberg.org/api/v1",
+		name:    "Codeberg",
 		org:     org,
+		token:   loadToken(token, "CODEBERG_TOKEN", ".gitsyncer_codeberg_token"),
 	}
-	c.loadToken(token)
-	return c
 }
 
-// loadToken loads the Codeberg API token from config, env, or file
-func (c *Client) loadToken(tokenFromConfig string) {
+// NewGiteaClient creates an API client for a self-hosted Gitea or Forgejo
+// instance. apiURL may be the instance root or its /api/v1 endpoint; name is
+// used in error messages.
+func NewGiteaClient(apiURL, name, org, token string) Client {
+	baseURL := strings.TrimRight(strings.TrimSpace(apiURL), "/")
+	if !strings.HasSuffix(baseURL, "/api/v1") {
+		baseURL += "/api/v1"
+	}
+	return Client{
+		baseURL: baseURL,
+		name:    name,
+		org:     org,
+		token:   loadToken(token, "GITEA_TOKEN", ".gitsyncer_gitea_token"),
+	}
+}
+
+// loadToken loads an API token from config, the environment variable or the
+// token file in the home directory, in that order
+func loadToken(tokenFromConfig, envVar, fileName string) string {
 	if tokenFromConfig != "" {
-		c.token = tokenFromConfig
-		return
+		return strings.TrimSpace(tokenFromConfig)
 	}
 
 	// Check environment variable
-	if token := os.Getenv("CODEBERG_TOKEN"); token != "" {
-		c.token = token
-		return
+	if token := os.Getenv(envVar); token != "" {
+		return strings.TrimSpace(token)
 	}
 
 	// Check token file
 	home, err := os.UserHomeDir()
-	if err == nil {
-		tokenFile := filepath.Join(home, ".gitsyncer_codeberg_token")
-		if data, err := os.ReadFile(tokenFile); err == nil {
-			c.token = string(data)
-		}
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(home, fileName))
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSpace(string(data))
 }
 
 // HasToken returns true if a token is loaded
@@ -108,91 +138,58 @@ func (c *Client) GetRepo(repoName string) (Repository, bool, error) {
 	return repo, true, nil
 }
 
-// UpdateRepoDescription updates a repository description on Codeberg
+// UpdateRepoDescription updates a repository description
 func (c *Client) UpdateRepoDescription(repoName, description string) error {
-	if !c.HasToken() {
-		return fmt.Errorf("Codeberg token required to update repository")
-	}
-
-	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
-	payload := map[string]interface{}{
-		"description": description,
-	}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-
-	req, cancel, err := httpclient.NewRequest(http.MethodPatch, url, bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
-	defer cancel()
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "token "+c.token)
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update Codeberg description: %s - %s", resp.Status, string(b))
-	}
-	return nil
+	return c.editRepo(repoName, map[string]interface{}{"description": description}, "description")
 }
 
-// ListPublicRepos lists all public repositories for an organization
-func (c *Client) ListPublicRepos() ([]Repository, error) {
-	var allRepos []Repository
-	page := 1
-	perPage := 50
-
-	for {
-		url := fmt.Sprintf("%s/orgs/%s/repos?page=%d&limit=%d", c.baseURL, c.org, page, perPage)
-
-		repos, err := c.listReposPage(url)
-		if err != nil {
-			return nil, err
-		}
+// RenameRepo renames a repository
+func (c *Client) RenameRepo(oldName, newName string) error {
+	return c.editRepo(oldName, map[string]interface{}{"name": newName}, "repository name")
+}
 
-		// Filter only public, non-fork, non-archived, non-empty repos
-		for _, repo := range repos {
-			if !repo.Private && !repo.Fork && !repo.Archived && !repo.Empty {
-				allRepos = append(allRepos, repo)
-			}
-		}
+// ListRepos lists the repositories of an organization. Private repositories
+// are only included if includePrivate is set.
+func (c *Client) ListRepos(includePrivate bool) ([]Repository, error) {
+	return c.listRepos(fmt.Sprintf("%s/orgs/%s/repos", c.baseURL, c.org), includePrivate, false)
+}
 
-		// If we got fewer repos than requested, we've reached the end
-		if len(repos) < perPage {
-			break
-		}
+// ListAllRepos is ListRepos including forks, archived and empty repositories
+func (c *Client) ListAllRepos(includePrivate bool) ([]Repository, error) {
+	return c.listRepos(fmt.Sprintf("%s/orgs/%s/repos", c.baseURL, c.org), includePrivate, true)
+}
 
-		page++
-	}
+// ListUserRepos lists the repositories of a user. Private repositories are
+// only included if includePrivate is set.
+func (c *Client) ListUserRepos(includePrivate bool) ([]Repository, error) {
+	return c.listRepos(fmt.Sprintf("%s/users/%s/repos", c.baseURL, c.org), includePrivate, false)
+}
 
-	return allRepos, nil
+// ListAllUserRepos is ListUserRepos including forks, archived and empty
+// repositories
+func (c *Client) ListAllUserRepos(includePrivate bool) ([]Repository, error) {
+	return c.listRepos(fmt.Sprintf("%s/users/%s/repos", c.baseURL, c.org), includePrivate, true)
 }
 
-// ListUserPublicRepos lists all public repositories for a user
-func (c *Client) ListUserPublicRepos() ([]Repository, error) {
+func (c *Client) listRepos(listURL string, includePrivate, all bool) ([]Repository, error) {
 	var allRepos []Repository
 	page := 1
 	perPage := 50
 
 	for {
-		url := fmt.Sprintf("%s/users/%s/repos?page=%d&limit=%d", c.baseURL, c.org, page, perPage)
+		url := fmt.Sprintf("%s?page=%d&limit=%d", listURL, page, perPage)
 
 		repos, err := c.listReposPage(url)
 		if err != nil {
 			return nil, err
 		}
 
-		// Filter only public, non-fork, non-archived, non-empty repos
+		// Filter only non-fork, non-archived, non-empty repos unless all are wanted
 		for _, repo := range repos {
-			if !repo.Private && !repo.Fork && !repo.Archived && !repo.Empty {
+			if repo.Private && !includePrivate {
+				continue
+			}
+			if all || !repo.Fork && !repo.Archived && !repo.Empty {
 				allRepos = append(allRepos, repo)
 			}
 		}
@@ -208,21 +205,15 @@ func (c *Client) ListUserPublicRepos() ([]Repository, error) {
 	return allRepos, nil
 }
 
-// GetRepoNames returns just the repository names
-func GetRepoNames(repos []Repository) []string {
-	names := make([]string, 0, len(repos))
-	for _, repo := range repos {
-		names = append(names, repo.Name)
-	}
-	return names
-}
-
 func (c *Client) listReposPage(url string) ([]Repository, error) {
 	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer cancel()
+	if c.HasToken() {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
 
 	resp, err := httpclient.Do(req)
 	if err != nil {
@@ -242,7 +233,7 @@ func (c *Client) listReposPage(url string) ([]Repository, error) {
 	return repos, nil
 }
 
-// RepoExists checks if a repository exists on Codeberg
+// RepoExists checks if a repository exists
 func (c *Client) RepoExists(repoName string) (bool, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
 	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
@@ -261,10 +252,18 @@ func (c *Client) RepoExists(repoName string) (bool, error) {
 	}
 	defer resp.Body.Close()
 
-	return resp.StatusCode == 200, nil
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return false, fmt.Errorf("failed to check repo: status %d: %s", resp.StatusCode, string(body))
 }
 
-// CreateRepo creates a new repository on Codeberg
+// CreateRepo creates a new repository in the organization, falling back to
+// the authenticated user's namespace when the owner is not an organization
 func (c *Client) CreateRepo(repoName, description string, private bool) error {
 	exists, err := c.RepoExists(repoName)
 	if err != nil {
@@ -274,8 +273,6 @@ func (c *Client) CreateRepo(repoName, description string, private bool) error {
 		return nil // Repository already exists
 	}
 
-	url := fmt.Sprintf("%s/user/repos", c.baseURL)
-
 	payload := map[string]interface{}{
 		"name":        repoName,
 		"description": description,
@@ -287,50 +284,65 @@ func (c *Client) CreateRepo(repoName, description string, private bool) error {
 		return err
 	}
 
-	req, cancel, err := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	status, respBody, err := c.postRepo(fmt.Sprintf("%s/orgs/%s/repos", c.baseURL, c.org), body)
 	if err != nil {
 		return err
 	}
-	defer cancel()
-
-	req.Header.Set("Content-Type", "application/json")
-	if c.HasToken() {
-		req.Header.Set("Authorization", "token "+c.token)
-	}
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		// Read the response body to get more detailed error information
-		body, err := io.ReadAll(resp.Body)
+	if status == http.StatusNotFound {
+		// Not an organization, so create it for the authenticated user
+		status, respBody, err = c.postRepo(fmt.Sprintf("%s/user/repos", c.baseURL), body)
 		if err != nil {
-			return fmt.Errorf("failed to create repository: status code %d (could not read response)", resp.StatusCode)
+			return err
 		}
+	}
 
+	if status != http.StatusCreated {
 		// Try to parse as JSON error response
 		var errorResp map[string]interface{}
-		if err := json.Unmarshal(body, &errorResp); err == nil {
+		if err := json.Unmarshal(respBody, &errorResp); err == nil {
 			// If we can parse the JSON, extract the message
 			if msg, ok := errorResp["message"].(string); ok {
-				return fmt.Errorf("failed to create repository: %s (status code %d)", msg, resp.StatusCode)
+				return fmt.Errorf("failed to create repository: %s (status code %d)", msg, status)
 			}
 		}
 
 		// If we can't parse JSON, return the raw response
-		return fmt.Errorf("failed to create repository: %s (status code %d)", string(body), resp.StatusCode)
+		return fmt.Errorf("failed to create repository: %s (status code %d)", string(respBody), status)
 	}
 
 	return nil
 }
 
-// DeleteRepo deletes a repository from Codeberg
+// postRepo sends a repository creation request and returns status and body
+func (c *Client) postRepo(url string, jsonData []byte) (int, []byte, error) {
+	req, cancel, err := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cancel()
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.HasToken() {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create repository: status code %d (could not read response)", resp.StatusCode)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// DeleteRepo deletes a repository
 func (c *Client) DeleteRepo(repoName string) error {
 	if !c.HasToken() {
-		return fmt.Errorf("Codeberg token required to delete repository")
+		return fmt.Errorf("%s token required to delete repository", c.name)
 	}
 
 	// First check if the repo exists
@@ -376,3 +388,30 @@ func (c *Client) DeleteRepo(repoName string) error {
 	body, _ := io.ReadAll(resp.Body)
 	return fmt.Errorf("failed to delete repository: status %d: %s", resp.StatusCode, string(body))
 }
+
+// TestAuth verifies the token by fetching the authenticated user
+func (c *Client) TestAuth() error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token required", c.name)
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, c.baseURL+"/user", nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "token "+c.token)
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("authentication failed (%d): %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package codeberg
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Issue is a Gitea issue
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// IssueComment is a comment on a Gitea issue
+type IssueComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// ListIssues returns all open and closed issues of a repository, without
+// pull requests
+func (c *Client) ListIssues(repoName string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/issues?state=all&type=issues&limit=50&page=%d", c.baseURL, c.org, repoName, page)
+		var batch []Issue
+		if err := c.jsonRequest(http.MethodGet, url, "issues", nil, &batch); err != nil {
+			return nil, err
+		}
+		issues = append(issues, batch...)
+		if len(batch) < 50 {
+			return issues, nil
+		}
+	}
+}
+
+// ListIssueComments returns the comments of an issue, oldest first
+func (c *Client) ListIssueComments(repoName string, number int) ([]IssueComment, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.baseURL, c.org, repoName, number)
+	var comments []IssueComment
+	err := c.jsonRequest(http.MethodGet, url, "issue comments", nil, &comments)
+	return comments, err
+}
+
+// CreateIssue opens an issue
+func (c *Client) CreateIssue(repoName, title, body string) (Issue, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues", c.baseURL, c.org, repoName)
+	var issue Issue
+	err := c.jsonRequest(http.MethodPost, url, "issue", map[string]string{"title": title, "body": body}, &issue)
+	return issue, err
+}
+
+// CreateIssueComment comments on an issue
+func (c *Client) CreateIssueComment(repoName string, number int, body string) (IssueComment, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.baseURL, c.org, repoName, number)
+	var comment IssueComment
+	err := c.jsonRequest(http.MethodPost, url, "issue comment", map[string]string{"body": body}, &comment)
+	return comment, err
+}
+
+// CloseIssue closes an issue
+func (c *Client) CloseIssue(repoName string, number int) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.baseURL, c.org, repoName, number)
+	return c.jsonRequest(http.MethodPatch, url, "issue", map[string]string{"state": "closed"}, nil)
+}
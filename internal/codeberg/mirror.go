@@ -0,0 +1,29 @@
+package codeberg
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// MirrorOptions describes a pull mirror to create
+type MirrorOptions struct {
+	CloneURL    string // HTTPS clone URL of the source repository
+	Description string
+	Private     bool
+	Interval    string // e.g. "8h0m0s"
+}
+
+// MigrateMirror creates a repository that pulls from CloneURL on its own at
+// the given interval
+func (c *Client) MigrateMirror(repoName string, opts MirrorOptions) error {
+	payload := map[string]interface{}{
+		"clone_addr":      opts.CloneURL,
+		"repo_name":       repoName,
+		"repo_owner":      c.org,
+		"description":     opts.Description,
+		"private":         opts.Private,
+		"mirror":          true,
+		"mirror_interval": opts.Interval,
+	}
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf("%s/repos/migrate", c.baseURL), "mirror migration", payload, nil)
+}
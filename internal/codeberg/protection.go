@@ -0,0 +1,70 @@
+package codeberg
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// BranchProtection is a Gitea branch protection rule. Protected branches can
+// never be deleted on Gitea.
+type BranchProtection struct {
+	RuleName               string   `json:"rule_name"`
+	BranchName             string   `json:"branch_name"`
+	EnablePush             bool     `json:"enable_push"`
+	RequiredApprovals      int      `json:"required_approvals"`
+	EnableForcePush        bool     `json:"enable_force_push"`
+	EnableStatusCheck      bool     `json:"enable_status_check"`
+	RequireSignedCommits   bool     `json:"require_signed_commits"`
+	BlockOnOutdatedBranch  bool     `json:"block_on_outdated_branch"`
+	DismissStaleApprovals  bool     `json:"dismiss_stale_approvals"`
+	ProtectedFilePatterns  string   `json:"protected_file_patterns"`
+	PushWhitelistUsernames []string `json:"push_whitelist_usernames"`
+}
+
+// Branch returns the branch or branch pattern the rule protects
+func (p BranchProtection) Branch() string {
+	if p.RuleName != "" {
+		return p.RuleName
+	}
+	return p.BranchName
+}
+
+// ListBranchProtections returns the branch protection rules of a repository
+func (c *Client) ListBranchProtections(repoName string) ([]BranchProtection, error) {
+	var rules []BranchProtection
+	err := c.jsonRequest(http.MethodGet, c.protectionsURL(repoName), "branch protection", nil, &rules)
+	return rules, err
+}
+
+// SetBranchProtection creates the rule for its branch, or updates the
+// existing one. New rules allow pushes without a whitelist so that gitsyncer
+// can keep mirroring; the push settings of existing rules, including their
+// whitelists, are left unchanged.
+func (c *Client) SetBranchProtection(repoName string, rule BranchProtection) error {
+	payload := map[string]interface{}{
+		"required_approvals": rule.RequiredApprovals,
+		"enable_force_push":  rule.EnableForcePush,
+	}
+
+	existing, err := c.ListBranchProtections(repoName)
+	if err != nil {
+		return err
+	}
+	for _, current := range existing {
+		if current.Branch() == rule.Branch() {
+			endpoint := c.protectionsURL(repoName) + "/" + url.PathEscape(current.Branch())
+			return c.jsonRequest(http.MethodPatch, endpoint, "branch protection", payload, nil)
+		}
+	}
+
+	payload["rule_name"] = rule.Branch()
+	payload["branch_name"] = rule.Branch()
+	payload["enable_push"] = true
+	payload["enable_push_whitelist"] = false
+	return c.jsonRequest(http.MethodPost, c.protectionsURL(repoName), "branch protection", payload, nil)
+}
+
+func (c *Client) protectionsURL(repoName string) string {
+	return fmt.Sprintf("%s/repos/%s/%s/branch_protections", c.baseURL, c.org, repoName)
+}
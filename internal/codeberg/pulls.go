@@ -0,0 +1,26 @@
+package codeberg
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ListOpenPullRequests returns the numbers of all open pull requests
+func (c *Client) ListOpenPullRequests(repoName string) ([]int, error) {
+	var numbers []int
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/pulls?state=open&limit=50&page=%d", c.baseURL, c.org, repoName, page)
+		var batch []struct {
+			Number int `json:"number"`
+		}
+		if err := c.jsonRequest(http.MethodGet, url, "pull requests", nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, pull := range batch {
+			numbers = append(numbers, pull.Number)
+		}
+		if len(batch) < 50 {
+			return numbers, nil
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package codeberg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// Release represents a Codeberg/Gitea release
+type Release struct {
+	ID      int64  `json:"id,omitempty"`
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+}
+
+// EnsureReleasesEnabled ensures that the repository has the Releases feature
+// enabled. If it's disabled, attempts to enable it via API.
+func (c *Client) EnsureReleasesEnabled(repoName string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token is required to manage repository settings", c.name)
+	}
+
+	hasReleases, err := c.hasReleases(repoName)
+	if err != nil {
+		return err
+	}
+	if hasReleases {
+		return nil
+	}
+
+	// Enable releases via PATCH
+	body, err := json.Marshal(map[string]any{"has_releases": true})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
+	patchReq, patchCancel, err := httpclient.NewRequest(http.MethodPatch, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer patchCancel()
+	patchReq.Header.Set("Authorization", "token "+c.token)
+	patchReq.Header.Set("Content-Type", "application/json")
+	patchResp, err := httpclient.Do(patchReq)
+	if err != nil {
+		return err
+	}
+	defer patchResp.Body.Close()
+	if patchResp.StatusCode != 200 {
+		pbody, _ := io.ReadAll(patchResp.Body)
+		return fmt.Errorf("failed to enable releases: %s - %s", patchResp.Status, string(pbody))
+	}
+	return nil
+}
+
+// hasReleases reports whether the Releases feature is enabled for a repository
+func (c *Client) hasReleases(repoName string) (bool, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+	if c.HasToken() {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("failed to get repo info: %s - %s", resp.Status, string(body))
+	}
+
+	var repoInfo struct {
+		HasReleases bool `json:"has_releases"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
+		return false, fmt.Errorf("failed to parse repo info: %w", err)
+	}
+	return repoInfo.HasReleases, nil
+}
+
+// ListReleases returns the tag names of all releases of a repository
+func (c *Client) ListReleases(repoName string) ([]string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", c.baseURL, c.org, repoName)
+
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	// Add token if available
+	if c.HasToken() {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		// Repository might not exist on the instance
+		return []string{}, nil
+	}
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s API error: %s - %s", c.name, resp.Status, string(body))
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+
+	return tags, nil
+}
+
+// CreateRelease creates a release for an existing tag. If the repository has
+// the Releases feature disabled, it is enabled and the creation is retried.
+func (c *Client) CreateRelease(repoName, tag, releaseNotes string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token is required for creating releases", c.name)
+	}
+
+	// Use provided release notes or default
+	body := releaseNotes
+	if body == "" {
+		body = fmt.Sprintf("Release %s", tag)
+	}
+
+	// According to Gitea API docs, only tag_name is required
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"tag_name":   tag,
+		"name":       tag, // Use simple tag name like working releases
+		"body":       body,
+		"draft":      false,
+		"prerelease": false,
+	})
+	if err != nil {
+		return err
+	}
+
+	status, respBody, err := c.postRelease(repoName, jsonData)
+	if err != nil {
+		return err
+	}
+	if status == 201 {
+		return nil
+	}
+
+	// Provide a more actionable hint when the repository is missing or owner/repo is wrong
+	if status == 404 {
+		return c.diagnoseMissingRelease(repoName, jsonData, respBody)
+	}
+
+	// Special handling for known Gitea issue
+	if status == 409 && strings.Contains(respBody, "Release is has no Tag") {
+		// This is a known Gitea bug - the tag exists but Gitea can't create a release for it
+		fmt.Printf("\nWARNING: %s returned 'Release is has no Tag' error for tag %s\n", c.name, tag)
+		fmt.Printf("This is a known issue with some old tags. The tag exists but cannot have a release created via API.\n")
+		fmt.Printf("You may need to create this release manually through the %s web interface.\n\n", c.name)
+		return fmt.Errorf("cannot create release for tag %s due to Gitea API limitation", tag)
+	}
+
+	return fmt.Errorf("failed to create %s release: status %d - %s", c.name, status, respBody)
+}
+
+// diagnoseMissingRelease handles a 404 on release creation: it enables the
+// Releases feature if it is disabled and retries, or explains the likely cause
+func (c *Client) diagnoseMissingRelease(repoName string, jsonData []byte, respBody string) error {
+	hasReleases, err := c.hasReleases(repoName)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to create %s release: repository %s/%s not found (404). Verify your %s owner ('organizations[].name') matches the actual owner for this repo and that the repository exists. If needed, create it first, e.g.: gitsyncer sync repo %s --create-repos. Raw response: %s",
+			c.name, c.org, repoName, c.name, repoName, respBody,
+		)
+	}
+
+	if hasReleases {
+		// Repo exists and has releases; likely permission/scope issue
+		return fmt.Errorf(
+			"failed to create %s release: repo %s/%s exists but returned 404 on release creation. This usually indicates the token lacks write permissions to this repository or owner. Ensure the token belongs to '%s' (or a collaborator/maintainer) and has repository write access. Raw response: %s",
+			c.name, c.org, repoName, c.org, respBody,
+		)
+	}
+
+	// Try to enable releases automatically and retry creation
+	if err := c.EnsureReleasesEnabled(repoName); err != nil {
+		return fmt.Errorf(
+			"failed to create %s release: releases are disabled for %s/%s and enabling via API failed: %v. Raw response: %s",
+			c.name, c.org, repoName, err, respBody,
+		)
+	}
+
+	status, retryBody, err := c.postRelease(repoName, jsonData)
+	if err != nil {
+		return err
+	}
+	if status != 201 {
+		return fmt.Errorf("failed to create %s release after enabling releases: status %d - %s", c.name, status, retryBody)
+	}
+	return nil
+}
+
+// postRelease sends a release creation request and returns status and body
+func (c *Client) postRelease(repoName string, jsonData []byte) (int, string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", c.baseURL, c.org, repoName)
+	req, cancel, err := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return 0, "", err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body), nil
+}
+
+// UpdateRelease replaces the notes of the release for a tag
+func (c *Client) UpdateRelease(repoName, tag, releaseNotes string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token is required for updating releases", c.name)
+	}
+
+	// First, get the release ID
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", c.baseURL, c.org, repoName, tag)
+
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "token "+c.token)
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get release: %s - %s", resp.Status, string(body))
+	}
+
+	var releaseInfo Release
+	if err := json.NewDecoder(resp.Body).Decode(&releaseInfo); err != nil {
+		return err
+	}
+
+	// Now update the release
+	updateURL := fmt.Sprintf("%s/repos/%s/%s/releases/%d", c.baseURL, c.org, repoName, releaseInfo.ID)
+
+	jsonData, err := json.Marshal(Release{TagName: tag, Name: tag, Body: releaseNotes})
+	if err != nil {
+		return err
+	}
+
+	updateReq, updateCancel, err := httpclient.NewRequest(http.MethodPatch, updateURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	defer updateCancel()
+
+	updateReq.Header.Set("Authorization", "token "+c.token)
+	updateReq.Header.Set("Content-Type", "application/json")
+
+	updateResp, err := httpclient.Do(updateReq)
+	if err != nil {
+		return err
+	}
+	defer updateResp.Body.Close()
+
+	if updateResp.StatusCode != 200 {
+		body, _ := io.ReadAll(updateResp.Body)
+		return fmt.Errorf("failed to update %s release: %s - %s", c.name, updateResp.Status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package codeberg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// editRepo applies a partial update to a repository. what names the updated
+// settings in error messages.
+func (c *Client) editRepo(repoName string, payload map[string]interface{}, what string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token required to update repository", c.name)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodPatch, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "token "+c.token)
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update %s %s: %s - %s", c.name, what, resp.Status, string(b))
+	}
+	return nil
+}
+
+// UpdateRepoSettings applies repository settings such as website,
+// default_branch, has_issues, has_wiki or archived
+func (c *Client) UpdateRepoSettings(repoName string, settings map[string]interface{}) error {
+	return c.editRepo(repoName, settings, "settings")
+}
+
+// ListTopics returns the topics of a repository
+func (c *Client) ListTopics(repoName string) ([]string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/topics", c.baseURL, c.org, repoName)
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	if c.HasToken() {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list %s topics: status %d: %s", c.name, resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Topics []string `json:"topics"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse topics: %w", err)
+	}
+	return result.Topics, nil
+}
+
+// ReplaceTopics replaces all topics of a repository
+func (c *Client) ReplaceTopics(repoName string, topics []string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("%s token required to update repository", c.name)
+	}
+	if topics == nil {
+		topics = []string{}
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/topics", c.baseURL, c.org, repoName)
+	body, err := json.Marshal(map[string]interface{}{"topics": topics})
+	if err != nil {
+		return err
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "token "+c.token)
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update %s topics: %s - %s", c.name, resp.Status, string(b))
+	}
+	return nil
+}
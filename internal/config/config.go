@@ -6,17 +6,129 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
+// Forge types accepted in Organization.Type
+const (
+	TypeGitHub    = "github"
+	TypeGitea     = "gitea"
+	TypeForgejo   = "forgejo"
+	TypeGitLab    = "gitlab"
+	TypeSourceHut = "sourcehut"
+)
+
+// Repository settings that metadata_sync reconciles across forges
+const (
+	SettingHomepage      = "homepage"
+	SettingTopics        = "topics"
+	SettingArchived      = "archived"
+	SettingDefaultBranch = "default_branch"
+	SettingHasIssues     = "has_issues"
+	SettingHasWiki       = "has_wiki"
+	SettingVisibility    = "visibility"
+)
+
+// AllSettings lists every setting metadata_sync can reconcile
+var AllSettings = []string{
+	SettingHomepage,
+	SettingTopics,
+	SettingArchived,
+	SettingDefaultBranch,
+	SettingHasIssues,
+	SettingHasWiki,
+	SettingVisibility,
+}
+
+// Topic merge policies of metadata_sync
+const (
+	TopicsPolicyPrimary = "primary" // the topics of the first forge in precedence order win
+	TopicsPolicyUnion   = "union"   // all forges get the union of their topics
+)
+
+// Mirror modes of a repository
+const (
+	MirrorModePush   = "push"   // gitsyncer pushes to every forge (default)
+	MirrorModeNative = "native" // Codeberg and Gitea/Forgejo pull-mirror the repository themselves
+)
+
+// DefaultNativeMirrorInterval is the pull interval of native mirrors
+const DefaultNativeMirrorInterval = 8 * time.Hour
+
+// precedenceForges are the forge types that may appear in a metadata_sync precedence list
+var precedenceForges = []string{"codeberg", TypeGitea, TypeGitHub, TypeGitLab, TypeSourceHut}
+
+// protectionForges are the forge types that support branch protection
+var protectionForges = []string{"codeberg", TypeGitea, TypeGitHub, TypeGitLab}
+
+// MetadataSync configures reconciling repository settings across forges
+type MetadataSync struct {
+	// Fields lists the settings to reconcile; empty means all of AllSettings
+	Fields []string `json:"fields,omitempty"`
+	// Precedence maps a setting to the forge types whose value wins, in order.
+	// Forges not listed follow in the default forge precedence order.
+	Precedence map[string][]string `json:"precedence,omitempty"`
+	// TopicsPolicy selects how topics are merged: "primary" (default) or "union"
+	TopicsPolicy string `json:"topics_policy,omitempty"`
+}
+
+// EnabledFields returns the settings to reconcile
+func (m *MetadataSync) EnabledFields() []string {
+	if m == nil || len(m.Fields) == 0 {
+		return AllSettings
+	}
+	return m.Fields
+}
+
+// PrecedenceFor returns the configured forge precedence of a setting
+func (m *MetadataSync) PrecedenceFor(setting string) []string {
+	if m == nil {
+		return nil
+	}
+	return m.Precedence[setting]
+}
+
+// BranchProtectionSync configures mirroring branch protection rules
+type BranchProtectionSync struct {
+	// Source is the forge type whose rules are mirrored to the other forges.
+	// Empty means the first configured organization that supports branch
+	// protection.
+	Source string `json:"source,omitempty"`
+}
+
+// PullRequestMirror configures mirroring the heads of open pull requests as
+// branches
+type PullRequestMirror struct {
+	// Prefix namespaces the mirrored branches: <prefix>/<forge>/<number>.
+	// Empty means "pr".
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// BranchPrefix returns the prefix of mirrored pull request branches
+func (p *PullRequestMirror) BranchPrefix() string {
+	if p == nil || p.Prefix == "" {
+		return "pr"
+	}
+	return p.Prefix
+}
+
 // Organization represents a git organization with its host and name
 type Organization struct {
 	Host                string `json:"host"`
 	Name                string `json:"name"`
 	GitHubToken         string `json:"github_token,omitempty"`
 	CodebergToken       string `json:"codeberg_token,omitempty"`
+	Type                string `json:"type,omitempty"`                // Forge software: "github", "gitea", "forgejo", "gitlab" or "sourcehut"
+	APIURL              string `json:"api_url,omitempty"`             // API base URL of a self-hosted instance, e.g. https://git.example.com/api/v1
+	GiteaToken          string `json:"gitea_token,omitempty"`         // API token for a self-hosted Gitea/Forgejo instance
+	GitLabToken         string `json:"gitlab_token,omitempty"`        // API token for GitLab
+	AccountType         string `json:"account_type,omitempty"`        // GitHub owner kind: "user" or "org" (detected if empty)
+	SourceHutToken      string `json:"sourcehut_token,omitempty"`     // Personal access token for SourceHut
 	BackupLocation      bool   `json:"backupLocation,omitempty"`      // Mark this as a backup-only destination
 	DescriptionSyncHost string `json:"descriptionSyncHost,omitempty"` // SSH host with shell access for updating backup descriptions
 	DescriptionSyncRoot string `json:"descriptionSyncRoot,omitempty"` // Filesystem path on DescriptionSyncHost where bare repos live
+	S3Endpoint          string `json:"s3Endpoint,omitempty"`          // S3-compatible endpoint URL for s3:// backup locations
+	S3Region            string `json:"s3Region,omitempty"`            // Signing region for s3:// backup locations (default: us-east-1)
 }
 
 // Config holds the application configuration
@@ -30,25 +142,58 @@ type Config struct {
 	// SkipReleases maps a repository name to a list of tag names for which
 	// releases should NOT be created on any platform (GitHub/Codeberg)
 	SkipReleases map[string][]string `json:"skip_releases,omitempty"`
+	// IncludePrivate makes public repository discovery also list private
+	// repositories, using authenticated listing. Missing mirrors are created
+	// with the visibility of the source repository.
+	IncludePrivate bool `json:"include_private,omitempty"`
+	// MetadataSync enables reconciling repository settings such as homepage,
+	// topics and the archived flag across forges. When nil, only the default
+	// branch is reconciled.
+	MetadataSync *MetadataSync `json:"metadata_sync,omitempty"`
+	// BranchProtectionSync enables mirroring branch protection rules from one
+	// forge to the others. Disabled when nil.
+	BranchProtectionSync *BranchProtectionSync `json:"branch_protection_sync,omitempty"`
+	// PullRequestMirror enables pushing the heads of open GitHub and
+	// Codeberg/Gitea pull requests to the other forges as branches.
+	// Disabled when nil.
+	PullRequestMirror *PullRequestMirror `json:"pull_request_mirror,omitempty"`
+	// MirrorMode maps repository names to "push" (default) or "native". The
+	// Codeberg and Gitea/Forgejo copies of a native repository are pull
+	// mirrors of the first other forge and never receive pushes.
+	MirrorMode map[string]string `json:"mirror_mode,omitempty"`
+	// NativeMirrorInterval is how often native mirrors pull, e.g. "1h".
+	// Empty means 8h.
+	NativeMirrorInterval string `json:"native_mirror_interval,omitempty"`
 }
 
-// Load reads and parses the configuration file
-func Load(path string) (*Config, error) {
+// ResolvePath returns the configuration file Load reads for path: the
+// default location if path is empty, with "~/" expanded otherwise
+func ResolvePath(path string) (string, error) {
 	// If no path provided, use default
 	if path == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
+			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
 		// Use XDG config directory with .json extension
-		path = filepath.Join(home, ".config", "gitsyncer", "config.json")
-	} else if len(path) >= 2 && path[:2] == "~/" {
+		return filepath.Join(home, ".config", "gitsyncer", "config.json"), nil
+	}
+	if len(path) >= 2 && path[:2] == "~/" {
 		// Expand home directory if needed
 		home, err := os.UserHomeDir()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
+			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
-		path = filepath.Join(home, path[2:])
+		return filepath.Join(home, path[2:]), nil
+	}
+	return path, nil
+}
+
+// Load reads and parses the configuration file
+func Load(path string) (*Config, error) {
+	path, err := ResolvePath(path)
+	if err != nil {
+		return nil, err
 	}
 
 	// Read config file
@@ -99,10 +244,33 @@ func (c *Config) Validate() error {
 		if org.Host == "" {
 			return fmt.Errorf("organization %d: missing host", i)
 		}
-		// Name can be empty for file:// URLs or SSH backup locations
-		if org.Name == "" && !strings.HasPrefix(org.Host, "file://") && !org.IsSSH() {
+		// Name can be empty for file:// URLs, SSH or S3 backup locations
+		if org.Name == "" && !strings.HasPrefix(org.Host, "file://") && !org.IsSSH() && !org.IsS3() {
 			return fmt.Errorf("organization %d: missing name", i)
 		}
+		if org.IsS3() {
+			if !org.BackupLocation {
+				return fmt.Errorf("organization %d: s3:// hosts are only supported as backup locations", i)
+			}
+			if bucket, _ := org.S3Location(); bucket == "" {
+				return fmt.Errorf("organization %d: missing bucket in s3 host %q", i, org.Host)
+			}
+		}
+		switch org.Type {
+		case "", TypeGitHub, TypeGitLab, TypeSourceHut:
+		case TypeGitea, TypeForgejo:
+			if strings.TrimSpace(org.APIURL) == "" {
+				return fmt.Errorf("organization %d: api_url is required for type %q", i, org.Type)
+			}
+		default:
+			return fmt.Errorf("organization %d: unknown type %q", i, org.Type)
+		}
+		if org.AccountType != "" && org.AccountType != "user" && org.AccountType != "org" {
+			return fmt.Errorf("organization %d: account_type must be \"user\" or \"org\", got %q", i, org.AccountType)
+		}
+		if org.APIURL != "" && !strings.HasPrefix(org.APIURL, "https://") && !strings.HasPrefix(org.APIURL, "http://") {
+			return fmt.Errorf("organization %d: api_url must be an http(s) URL, got %q", i, org.APIURL)
+		}
 		hasDescriptionSyncHost := strings.TrimSpace(org.DescriptionSyncHost) != ""
 		hasDescriptionSyncRoot := strings.TrimSpace(org.DescriptionSyncRoot) != ""
 		if hasDescriptionSyncHost != hasDescriptionSyncRoot {
@@ -110,6 +278,21 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.MetadataSync.validate(); err != nil {
+		return fmt.Errorf("metadata_sync: %w", err)
+	}
+	if bp := c.BranchProtectionSync; bp != nil && bp.Source != "" && !contains(protectionForges, bp.Source) {
+		return fmt.Errorf("branch_protection_sync: unknown or unsupported source forge %q", bp.Source)
+	}
+
+	if pm := c.PullRequestMirror; pm != nil && (strings.Trim(pm.Prefix, "/") != pm.Prefix || strings.ContainsAny(pm.Prefix, " ~^:?*[\\")) {
+		return fmt.Errorf("pull_request_mirror: invalid branch prefix %q", pm.Prefix)
+	}
+
+	if err := c.validateMirrorModes(); err != nil {
+		return err
+	}
+
 	for repo, branch := range c.ShowcaseStatsBranches {
 		if strings.TrimSpace(repo) == "" {
 			return fmt.Errorf("showcase_stats_branches: repository name cannot be empty")
@@ -122,6 +305,110 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (m *MetadataSync) validate() error {
+	if m == nil {
+		return nil
+	}
+	switch m.TopicsPolicy {
+	case "", TopicsPolicyPrimary, TopicsPolicyUnion:
+	default:
+		return fmt.Errorf("topics_policy must be %q or %q, got %q", TopicsPolicyPrimary, TopicsPolicyUnion, m.TopicsPolicy)
+	}
+	for _, field := range m.Fields {
+		if !contains(AllSettings, field) {
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+	for field, forges := range m.Precedence {
+		if !contains(AllSettings, field) {
+			return fmt.Errorf("precedence: unknown field %q", field)
+		}
+		for _, forgeType := range forges {
+			if !contains(precedenceForges, forgeType) {
+				return fmt.Errorf("precedence[%q]: unknown forge type %q", field, forgeType)
+			}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) validateMirrorModes() error {
+	native := false
+	for repo, mode := range c.MirrorMode {
+		switch mode {
+		case MirrorModePush:
+		case MirrorModeNative:
+			native = true
+		default:
+			return fmt.Errorf("mirror_mode[%q]: must be %q or %q, got %q", repo, MirrorModePush, MirrorModeNative, mode)
+		}
+	}
+	if c.NativeMirrorInterval != "" {
+		interval, err := time.ParseDuration(c.NativeMirrorInterval)
+		if err != nil || interval < 10*time.Minute {
+			return fmt.Errorf("native_mirror_interval: must be a duration of at least 10m, got %q", c.NativeMirrorInterval)
+		}
+	}
+	if native && c.NativeMirrorSource() == nil {
+		return fmt.Errorf("mirror_mode: native mirrors need a GitHub, GitLab or SourceHut organization to pull from")
+	}
+	return nil
+}
+
+// MirrorModeOf returns the mirror mode of a repository
+func (c *Config) MirrorModeOf(repoName string) string {
+	if mode := c.MirrorMode[repoName]; mode != "" {
+		return mode
+	}
+	return MirrorModePush
+}
+
+// MirrorInterval returns the pull interval of native mirrors
+func (c *Config) MirrorInterval() time.Duration {
+	if interval, err := time.ParseDuration(c.NativeMirrorInterval); err == nil {
+		return interval
+	}
+	return DefaultNativeMirrorInterval
+}
+
+// NativeMirrorSource returns the organization native mirrors pull from: the
+// first forge organization that cannot pull-mirror itself
+func (c *Config) NativeMirrorSource() *Organization {
+	for i := range c.Organizations {
+		org := &c.Organizations[i]
+		if !org.BackupLocation && (org.IsGitHub() || org.IsGitLab() || org.IsSourceHut()) {
+			return org
+		}
+	}
+	return nil
+}
+
+// PrimaryOrganization returns the first organization that is not a backup
+// location, whose copy of a repository the others mirror
+func (c *Config) PrimaryOrganization() *Organization {
+	for i := range c.Organizations {
+		if !c.Organizations[i].BackupLocation {
+			return &c.Organizations[i]
+		}
+	}
+	return nil
+}
+
+// IsNativeMirror reports whether an organization holds a native pull mirror
+// of a repository, which gitsyncer must neither fetch from nor push to
+func (c *Config) IsNativeMirror(org *Organization, repoName string) bool {
+	return !org.BackupLocation && (org.IsCodeberg() || org.IsGitea()) && c.MirrorModeOf(repoName) == MirrorModeNative
+}
+
 // ShouldSkipRelease returns true if the configuration specifies that
 // the given repo/tag combination should not have a release created.
 func (c *Config) ShouldSkipRelease(repo, tag string) bool {
@@ -142,8 +429,8 @@ func (c *Config) ShouldSkipRelease(repo, tag string) bool {
 
 // GetGitURL returns the git URL for an organization
 func (o *Organization) GetGitURL() string {
-	// For SSH backup locations with empty name, just return the host
-	if o.IsSSH() && o.Name == "" {
+	// For SSH and S3 backup locations with empty name, just return the host
+	if (o.IsSSH() || o.IsS3()) && o.Name == "" {
 		return o.Host
 	}
 	return fmt.Sprintf("%s:%s", o.Host, o.Name)
@@ -161,9 +448,17 @@ func (c *Config) FindOrganization(host string) *Organization {
 
 // IsCodeberg checks if the organization is Codeberg
 func (o *Organization) IsCodeberg() bool {
+	if o.Type != "" {
+		return false
+	}
 	return o.Host == "git@codeberg.org" || strings.Contains(o.Host, "codeberg.org")
 }
 
+// IsGitea checks if the organization is on a self-hosted Gitea or Forgejo instance
+func (o *Organization) IsGitea() bool {
+	return o.Type == TypeGitea || o.Type == TypeForgejo
+}
+
 // FindCodebergOrg finds the first Codeberg organization
 func (c *Config) FindCodebergOrg() *Organization {
 	for i := range c.Organizations {
@@ -174,11 +469,39 @@ func (c *Config) FindCodebergOrg() *Organization {
 	return nil
 }
 
-// IsGitHub checks if the organization is GitHub
+// IsGitHub checks if the organization is on github.com or a GitHub Enterprise Server
 func (o *Organization) IsGitHub() bool {
+	if o.Type != "" {
+		return o.Type == TypeGitHub
+	}
 	return o.Host == "git@github.com" || strings.Contains(o.Host, "github.com")
 }
 
+// IsGitLab checks if the organization is on gitlab.com or a self-managed GitLab
+func (o *Organization) IsGitLab() bool {
+	return o.Type == TypeGitLab || (o.Type == "" && strings.Contains(o.Host, "gitlab.com"))
+}
+
+// IsSourceHut checks if the organization is on git.sr.ht or a self-hosted SourceHut
+func (o *Organization) IsSourceHut() bool {
+	return o.Type == TypeSourceHut || (o.Type == "" && strings.Contains(o.Host, "sr.ht"))
+}
+
+// WebHost returns the host name of the organization's git host, e.g.
+// "github.example.com" for "git@github.example.com"
+func (o *Organization) WebHost() string {
+	host := o.Host
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	host, _, _ = strings.Cut(host, "/")
+	host, _, _ = strings.Cut(host, ":")
+	return host
+}
+
 // FindGitHubOrg finds the first GitHub organization
 func (c *Config) FindGitHubOrg() *Organization {
 	for i := range c.Organizations {
@@ -192,6 +515,18 @@ func (c *Config) FindGitHubOrg() *Organization {
 // IsSSH checks if the organization is a plain SSH location
 func (o *Organization) IsSSH() bool {
 	// Check if it's not a known git hosting service and contains SSH-like syntax
-	return !o.IsGitHub() && !o.IsCodeberg() && !strings.HasPrefix(o.Host, "file://") &&
+	return !o.IsGitHub() && !o.IsCodeberg() && !o.IsGitea() && !o.IsGitLab() && !o.IsSourceHut() && !o.IsS3() && !strings.HasPrefix(o.Host, "file://") &&
 		(strings.Contains(o.Host, "@") || strings.Contains(o.Host, ":"))
 }
+
+// IsS3 checks if the organization is an S3-compatible object storage backup target
+func (o *Organization) IsS3() bool {
+	return strings.HasPrefix(o.Host, "s3://")
+}
+
+// S3Location returns the bucket and key prefix encoded in an s3://bucket/prefix host
+func (o *Organization) S3Location() (string, string) {
+	location := strings.TrimPrefix(o.Host, "s3://")
+	bucket, prefix, _ := strings.Cut(location, "/")
+	return bucket, strings.Trim(prefix, "/")
+}
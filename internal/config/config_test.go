@@ -47,3 +47,164 @@ func TestValidate_DescriptionSyncFieldsMustBePaired(t *testing.T) {
 		t.Fatalf("Validate() error = %q, want descriptionSyncHost context", err)
 	}
 }
+
+func TestValidate_S3HostMustBeBackupLocation(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Organizations: []Organization{
+			{Host: "s3://backups/gitsyncer"},
+		},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want backup location error")
+	}
+	if !strings.Contains(err.Error(), "backup locations") {
+		t.Fatalf("Validate() error = %q, want backup location context", err)
+	}
+}
+
+func TestOrganization_S3Location(t *testing.T) {
+	t.Parallel()
+
+	org := Organization{Host: "s3://backups/git/mirrors/", BackupLocation: true}
+	if !org.IsS3() {
+		t.Fatal("IsS3() = false, want true")
+	}
+	if org.IsSSH() {
+		t.Fatal("IsSSH() = true, want false for s3:// hosts")
+	}
+
+	bucket, prefix := org.S3Location()
+	if bucket != "backups" || prefix != "git/mirrors" {
+		t.Fatalf("S3Location() = (%q, %q), want (%q, %q)", bucket, prefix, "backups", "git/mirrors")
+	}
+}
+
+func TestValidate_GiteaTypeRequiresAPIURL(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Organizations: []Organization{
+			{Host: "git@git.example.com", Name: "team", Type: TypeForgejo},
+		},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want api_url validation error")
+	}
+	if !strings.Contains(err.Error(), "api_url") {
+		t.Fatalf("Validate() error = %q, want api_url context", err)
+	}
+
+	cfg.Organizations[0].APIURL = "https://git.example.com"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestOrganization_GitHubEnterpriseDetection(t *testing.T) {
+	t.Parallel()
+
+	ghes := Organization{Host: "git@github.example.com", Name: "team", Type: TypeGitHub, APIURL: "https://github.example.com"}
+	if !ghes.IsGitHub() || ghes.IsSSH() {
+		t.Fatalf("expected %s to be a GitHub organization", ghes.Host)
+	}
+	if got := ghes.WebHost(); got != "github.example.com" {
+		t.Fatalf("WebHost() = %q", got)
+	}
+
+	typed := Organization{Host: "git@github.com", Name: "x", Type: TypeGitLab}
+	if typed.IsGitHub() {
+		t.Fatal("expected an explicit type to override host detection")
+	}
+}
+
+func TestValidate_MetadataSyncRejectsUnknownFieldsAndForges(t *testing.T) {
+	t.Parallel()
+
+	orgs := []Organization{{Host: "git@github.com", Name: "test-user"}}
+	tests := []struct {
+		name string
+		ms   *MetadataSync
+		want string
+	}{
+		{name: "unknown field", ms: &MetadataSync{Fields: []string{"stars"}}, want: `unknown field "stars"`},
+		{name: "unknown topics policy", ms: &MetadataSync{TopicsPolicy: "merge"}, want: "topics_policy"},
+		{name: "unknown forge", ms: &MetadataSync{Precedence: map[string][]string{SettingArchived: {"bitbucket"}}}, want: `unknown forge type "bitbucket"`},
+	}
+	for _, tc := range tests {
+		err := (&Config{Organizations: orgs, MetadataSync: tc.ms}).Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: Validate() error = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+
+	valid := &MetadataSync{Precedence: map[string][]string{SettingHomepage: {TypeGitHub, "codeberg"}}}
+	if err := (&Config{Organizations: orgs, MetadataSync: valid}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestValidate_BranchProtectionSyncSource(t *testing.T) {
+	t.Parallel()
+
+	orgs := []Organization{{Host: "git@github.com", Name: "test-user"}}
+	for source, wantErr := range map[string]bool{"": false, TypeGitHub: false, "codeberg": false, TypeSourceHut: true, "bitbucket": true} {
+		err := (&Config{Organizations: orgs, BranchProtectionSync: &BranchProtectionSync{Source: source}}).Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("source %q: Validate() error = %v, want error %v", source, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_PullRequestMirrorPrefix(t *testing.T) {
+	t.Parallel()
+
+	orgs := []Organization{{Host: "git@github.com", Name: "test-user"}}
+	for prefix, wantErr := range map[string]bool{"": false, "pr": false, "mirror/pulls": false, "pr/": true, "/pr": true, "p r": true, "pr:x": true} {
+		err := (&Config{Organizations: orgs, PullRequestMirror: &PullRequestMirror{Prefix: prefix}}).Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("prefix %q: Validate() error = %v, want error %v", prefix, err, wantErr)
+		}
+	}
+	if got := (*PullRequestMirror)(nil).BranchPrefix(); got != "pr" {
+		t.Fatalf("BranchPrefix() = %q, want pr", got)
+	}
+}
+
+func TestValidate_MirrorMode(t *testing.T) {
+	t.Parallel()
+
+	github := Organization{Host: "git@github.com", Name: "team"}
+	codeberg := Organization{Host: "git@codeberg.org", Name: "team"}
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"native", Config{Organizations: []Organization{codeberg, github}, MirrorMode: map[string]string{"tool": MirrorModeNative}}, false},
+		{"push", Config{Organizations: []Organization{codeberg}, MirrorMode: map[string]string{"tool": MirrorModePush}}, false},
+		{"unknown mode", Config{Organizations: []Organization{github}, MirrorMode: map[string]string{"tool": "pull"}}, true},
+		{"no source", Config{Organizations: []Organization{codeberg}, MirrorMode: map[string]string{"tool": MirrorModeNative}}, true},
+		{"interval", Config{Organizations: []Organization{github}, NativeMirrorInterval: "1h"}, false},
+		{"short interval", Config{Organizations: []Organization{github}, NativeMirrorInterval: "1m"}, true},
+		{"bad interval", Config{Organizations: []Organization{github}, NativeMirrorInterval: "daily"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	cfg := Config{Organizations: []Organization{codeberg, github}, MirrorMode: map[string]string{"tool": MirrorModeNative}}
+	if !cfg.IsNativeMirror(&cfg.Organizations[0], "tool") || cfg.IsNativeMirror(&cfg.Organizations[1], "tool") || cfg.IsNativeMirror(&cfg.Organizations[0], "other") {
+		t.Fatal("only the Codeberg copy of tool should be a native mirror")
+	}
+	if cfg.NativeMirrorSource() != &cfg.Organizations[1] || cfg.MirrorInterval() != DefaultNativeMirrorInterval {
+		t.Fatal("unexpected native mirror source or interval")
+	}
+}
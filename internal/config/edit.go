@@ -0,0 +1,336 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// AppendRepositories adds repository names to the "repositories" list of a
+// configuration file. The file is edited in place as text, so the layout and
+// order of everything else is kept; names already listed are skipped. It
+// returns the names that were added.
+func AppendRepositories(path string, names []string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	var added []string
+	for _, name := range names {
+		if !contains(cfg.Repositories, name) && !contains(added, name) {
+			added = append(added, name)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	edited, err := appendRepositoriesText(data, added)
+	if err != nil {
+		return nil, err
+	}
+	var check Config
+	if err := json.Unmarshal(edited, &check); err != nil {
+		return nil, fmt.Errorf("editing the config produced invalid JSON: %w", err)
+	}
+	if err := os.WriteFile(path, edited, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return added, nil
+}
+
+// appendRepositoriesText inserts names at the end of the top-level
+// "repositories" array, or adds the array as the last member of the object.
+// The indentation of the surrounding lines is reused.
+func appendRepositoriesText(data []byte, names []string) ([]byte, error) {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		encoded, _ := json.Marshal(name)
+		quoted[i] = string(encoded)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("config is not a JSON object")
+	}
+	objectStart := int(dec.InputOffset())
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+		if key != "repositories" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("failed to parse config: %w", err)
+			}
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return nil, fmt.Errorf(`"repositories" is not a list`)
+		}
+		arrayStart := int(dec.InputOffset())
+		for dec.More() {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("failed to parse config: %w", err)
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+		arrayEnd := int(dec.InputOffset()) - 1
+		return insertArrayItems(data, arrayStart, arrayEnd, quoted), nil
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	objectEnd := int(dec.InputOffset()) - 1
+
+	// No list yet: add one after the last member
+	indent := lineIndent(data, firstNonSpace(data, objectStart))
+	lastEnd := lastNonSpace(data, objectEnd)
+	separator := ","
+	if lastEnd < objectStart {
+		separator = ""
+	}
+	member := fmt.Sprintf("%s\n%s\"repositories\": [\n%s%s%s\n%s]", separator, indent, indent, indent, strings.Join(quoted, ",\n"+indent+indent), indent)
+	return splice(data, lastEnd, member), nil
+}
+
+// renameLists and renameMaps are the top-level entries that refer to
+// repositories by name: lists of names and maps keyed by name
+var (
+	renameLists = []string{"repositories", "exclude_from_showcase"}
+	renameMaps  = []string{"showcase_stats_branches", "skip_releases", "mirror_mode"}
+)
+
+// RenameRepository replaces a repository name in every entry of a
+// configuration file that refers to it: the "repositories" and
+// "exclude_from_showcase" lists and the keys of "showcase_stats_branches",
+// "skip_releases" and "mirror_mode". Like AppendRepositories, the file is
+// edited in place as text. It returns the entries that were changed, and
+// fails without changing anything if an entry already has the new name.
+func RenameRepository(path, oldName, newName string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	_, hasBranch := cfg.ShowcaseStatsBranches[newName]
+	_, hasSkip := cfg.SkipReleases[newName]
+	_, hasMode := cfg.MirrorMode[newName]
+	if contains(cfg.Repositories, newName) || contains(cfg.ExcludeFromShowcase, newName) || hasBranch || hasSkip || hasMode {
+		return nil, fmt.Errorf("config already refers to %q", newName)
+	}
+
+	edited, keys, err := renameRepositoryText(data, oldName, newName)
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	var check Config
+	if err := json.Unmarshal(edited, &check); err != nil {
+		return nil, fmt.Errorf("editing the config produced invalid JSON: %w", err)
+	}
+	if err := os.WriteFile(path, edited, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return keys, nil
+}
+
+// textEdit replaces data[start:end] with text
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// renameRepositoryText replaces the string tokens naming oldName in the
+// entries of renameLists and renameMaps, leaving all other bytes alone
+func renameRepositoryText(data []byte, oldName, newName string) ([]byte, []string, error) {
+	encoded, _ := json.Marshal(newName)
+	quoted := string(encoded)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("config is not a JSON object")
+	}
+	var edits []textEdit
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+		key, _ := tok.(string)
+		var found []textEdit
+		switch {
+		case contains(renameLists, key):
+			found, err = findNamesInList(dec, data, key, oldName, quoted)
+		case contains(renameMaps, key):
+			found, err = findKeysInMap(dec, data, key, oldName, quoted)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(found) > 0 {
+			edits = append(edits, found...)
+			keys = append(keys, key)
+		}
+	}
+
+	result := data
+	for i := len(edits) - 1; i >= 0; i-- {
+		edit := edits[i]
+		result = append(append(append([]byte{}, result[:edit.start]...), edit.text...), result[edit.end:]...)
+	}
+	return result, keys, nil
+}
+
+// findNamesInList returns the edits renaming oldName in the list value the
+// decoder is at. A null value is an empty list.
+func findNamesInList(dec *json.Decoder, data []byte, key, oldName, quoted string) ([]textEdit, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok != json.Delim('[') {
+		return nil, fmt.Errorf("%q is not a list", key)
+	}
+	var edits []textEdit
+	for dec.More() {
+		start := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+		name, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a list of names", key)
+		}
+		if name == oldName {
+			edits = append(edits, textEdit{start: tokenStart(data, start), end: int(dec.InputOffset()), text: quoted})
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return edits, nil
+}
+
+// findKeysInMap returns the edit renaming the key oldName in the object value
+// the decoder is at. A null value is an empty object.
+func findKeysInMap(dec *json.Decoder, data []byte, key, oldName, quoted string) ([]textEdit, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("%q is not an object", key)
+	}
+	var edits []textEdit
+	for dec.More() {
+		start := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+		if tok == oldName {
+			edits = append(edits, textEdit{start: tokenStart(data, start), end: int(dec.InputOffset()), text: quoted})
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return edits, nil
+}
+
+// tokenStart returns the offset of the token following offset, skipping
+// whitespace and the separators the decoder has not consumed yet
+func tokenStart(data []byte, offset int) int {
+	for offset < len(data) && strings.ContainsRune(" \t\r\n,:", rune(data[offset])) {
+		offset++
+	}
+	return offset
+}
+
+// insertArrayItems appends items to the array between the brackets at start
+// and end, one per line if the array already spans lines
+func insertArrayItems(data []byte, start, end int, items []string) []byte {
+	inner := string(data[start:end])
+	lastEnd := lastNonSpace(data, end)
+	if strings.TrimSpace(inner) == "" {
+		return splice(data, start, strings.Join(items, ", "))
+	}
+	if !strings.Contains(inner, "\n") {
+		return splice(data, lastEnd, ", "+strings.Join(items, ", "))
+	}
+	indent := lineIndent(data, lastEnd-1)
+	return splice(data, lastEnd, ",\n"+indent+strings.Join(items, ",\n"+indent))
+}
+
+// splice inserts text at offset
+func splice(data []byte, offset int, text string) []byte {
+	result := make([]byte, 0, len(data)+len(text))
+	result = append(result, data[:offset]...)
+	result = append(result, text...)
+	return append(result, data[offset:]...)
+}
+
+// lastNonSpace returns the offset just after the last non-whitespace byte
+// before end
+func lastNonSpace(data []byte, end int) int {
+	for end > 0 && strings.ContainsRune(" \t\r\n", rune(data[end-1])) {
+		end--
+	}
+	return end
+}
+
+func firstNonSpace(data []byte, start int) int {
+	for start < len(data) && strings.ContainsRune(" \t\r\n", rune(data[start])) {
+		start++
+	}
+	return start
+}
+
+// lineIndent returns the leading whitespace of the line containing offset
+func lineIndent(data []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := lineStart
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[lineStart:end])
+}
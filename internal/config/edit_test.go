@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppendRepositories_KeepsLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "multi-line list",
+			in: `{
+  "organizations": [{"host": "git@github.com", "name": "me"}],
+  "repositories": [
+    "tool",
+    "lib"
+  ],
+  "work_dir": "~/git"
+}
+`,
+			want: `{
+  "organizations": [{"host": "git@github.com", "name": "me"}],
+  "repositories": [
+    "tool",
+    "lib",
+    "new"
+  ],
+  "work_dir": "~/git"
+}
+`,
+		},
+		{
+			name: "inline list",
+			in:   `{"repositories": ["tool"], "work_dir": "~/git"}`,
+			want: `{"repositories": ["tool", "new"], "work_dir": "~/git"}`,
+		},
+		{
+			name: "empty list",
+			in:   `{"repositories": [ ]}`,
+			want: `{"repositories": ["new", "tool" ]}`,
+		},
+		{
+			name: "no list",
+			in: `{
+	"organizations": []
+}
+`,
+			want: `{
+	"organizations": [],
+	"repositories": [
+		"new",
+		"tool"
+	]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(tt.in), 0600); err != nil {
+				t.Fatal(err)
+			}
+			added, err := AppendRepositories(path, []string{"new", "tool", "new"})
+			if err != nil || len(added) == 0 || added[0] != "new" {
+				t.Fatalf("AppendRepositories() = %v, %v", added, err)
+			}
+			got, _ := os.ReadFile(path)
+			if string(got) != tt.want {
+				t.Fatalf("config =\n%s\nwant\n%s", got, tt.want)
+			}
+			if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+				t.Fatalf("mode = %v, want 0600", info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestAppendRepositories_NothingToAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	in := `{"repositories": ["tool"]}`
+	if err := os.WriteFile(path, []byte(in), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if added, err := AppendRepositories(path, []string{"tool"}); err != nil || len(added) != 0 {
+		t.Fatalf("AppendRepositories() = %v, %v", added, err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != in {
+		t.Fatalf("config changed to %s", got)
+	}
+}
+
+func TestRenameRepository_KeepsLayout(t *testing.T) {
+	in := `{
+  "repositories": [
+    "old",
+    "tool"
+  ],
+  "exclude_from_showcase": ["old"],
+  "showcase_stats_branches": {"old": "dev", "tool": "main"},
+  "skip_releases": {
+    "old" : ["v1.0.0"]
+  },
+  "mirror_mode": null,
+  "work_dir": "old"
+}
+`
+	want := `{
+  "repositories": [
+    "new",
+    "tool"
+  ],
+  "exclude_from_showcase": ["new"],
+  "showcase_stats_branches": {"new": "dev", "tool": "main"},
+  "skip_releases": {
+    "new" : ["v1.0.0"]
+  },
+  "mirror_mode": null,
+  "work_dir": "old"
+}
+`
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(in), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := RenameRepository(path, "old", "new")
+	if err != nil || strings.Join(keys, ",") != "repositories,exclude_from_showcase,showcase_stats_branches,skip_releases" {
+		t.Fatalf("RenameRepository() = %v, %v", keys, err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != want {
+		t.Fatalf("config =\n%s\nwant\n%s", got, want)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Fatalf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestRenameRepository_RefusesExistingName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	in := `{"repositories": ["old"], "mirror_mode": {"new": "pull"}}`
+	if err := os.WriteFile(path, []byte(in), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RenameRepository(path, "old", "new"); err == nil {
+		t.Fatal("expected an error for a name already in the config")
+	}
+	if got, _ := os.ReadFile(path); string(got) != in {
+		t.Fatalf("config changed to %s", got)
+	}
+}
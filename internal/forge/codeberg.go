@@ -0,0 +1,295 @@
+package forge
+
+import (
+	"fmt"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/codeberg"
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// codebergForge adapts the Gitea API client to the Forge interface. It serves
+// both Codeberg and self-hosted Gitea/Forgejo instances.
+type codebergForge struct {
+	forgeType string
+	name      string
+	org       *config.Organization
+	client    codeberg.Client
+}
+
+func newCodebergForge(org *config.Organization) Forge {
+	return &codebergForge{
+		forgeType: TypeCodeberg,
+		name:      "Codeberg",
+		org:       org,
+		client:    codeberg.NewClient(org.Name, org.CodebergToken),
+	}
+}
+
+func newGiteaForge(org *config.Organization) Forge {
+	name := "Gitea"
+	if org.Type == config.TypeForgejo {
+		name = "Forgejo"
+	}
+	return &codebergForge{
+		forgeType: TypeGitea,
+		name:      name,
+		org:       org,
+		client:    codeberg.NewGiteaClient(org.APIURL, name, org.Name, org.GiteaToken),
+	}
+}
+
+func (f *codebergForge) Type() string                       { return f.forgeType }
+func (f *codebergForge) DisplayName() string                { return f.name }
+func (f *codebergForge) Organization() *config.Organization { return f.org }
+func (f *codebergForge) HasToken() bool                     { return f.client.HasToken() }
+func (f *codebergForge) TestAuth() error                    { return f.client.TestAuth() }
+
+// ListRepos lists the repos of the organization, falling back to the user
+// endpoint when the name is a user account rather than an organization
+func (f *codebergForge) ListRepos(includePrivate bool) ([]Repository, error) {
+	repos, err := f.client.ListRepos(includePrivate)
+	if err != nil {
+		fmt.Println("Trying as user account...")
+		if repos, err = f.client.ListUserRepos(includePrivate); err != nil {
+			return nil, err
+		}
+	}
+	result := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, fromCodeberg(repo))
+	}
+	return result, nil
+}
+
+func (f *codebergForge) ListAllRepos(includePrivate bool) ([]Repository, error) {
+	repos, err := f.client.ListAllRepos(includePrivate)
+	if err != nil {
+		fmt.Println("Trying as user account...")
+		if repos, err = f.client.ListAllUserRepos(includePrivate); err != nil {
+			return nil, err
+		}
+	}
+	result := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, fromCodeberg(repo))
+	}
+	return result, nil
+}
+
+func (f *codebergForge) GetRepo(repoName string) (Repository, bool, error) {
+	repo, exists, err := f.client.GetRepo(repoName)
+	return fromCodeberg(repo), exists, err
+}
+
+func (f *codebergForge) RepoExists(repoName string) (bool, error) {
+	return f.client.RepoExists(repoName)
+}
+
+func (f *codebergForge) CreateRepo(repoName, description string, private bool) error {
+	return f.client.CreateRepo(repoName, description, private)
+}
+
+func (f *codebergForge) DeleteRepo(repoName string) error {
+	return f.client.DeleteRepo(repoName)
+}
+
+func (f *codebergForge) RenameRepo(oldName, newName string) error {
+	return f.client.RenameRepo(oldName, newName)
+}
+
+func (f *codebergForge) UpdateDescription(repoName, description string) error {
+	return f.client.UpdateRepoDescription(repoName, description)
+}
+
+func (f *codebergForge) SupportsSetting(setting string) bool { return true }
+
+func (f *codebergForge) ListTopics(repoName string) ([]string, error) {
+	return f.client.ListTopics(repoName)
+}
+
+func (f *codebergForge) UpdateSettings(repoName string, settings RepoSettings) error {
+	setArchived := func(archived bool) error {
+		return f.client.UpdateRepoSettings(repoName, map[string]interface{}{"archived": archived})
+	}
+	return applyArchivedLast(settings.Archived, setArchived, func() error {
+		payload := map[string]interface{}{}
+		if settings.Homepage != nil {
+			payload["website"] = *settings.Homepage
+		}
+		if settings.DefaultBranch != nil {
+			payload["default_branch"] = *settings.DefaultBranch
+		}
+		if settings.HasIssues != nil {
+			payload["has_issues"] = *settings.HasIssues
+		}
+		if settings.HasWiki != nil {
+			payload["has_wiki"] = *settings.HasWiki
+		}
+		if settings.Private != nil {
+			payload["private"] = *settings.Private
+		}
+		if len(payload) > 0 {
+			if err := f.client.UpdateRepoSettings(repoName, payload); err != nil {
+				return err
+			}
+		}
+		if settings.Topics != nil {
+			return f.client.ReplaceTopics(repoName, settings.Topics)
+		}
+		return nil
+	})
+}
+
+func (f *codebergForge) ListBranchProtections(repoName string) ([]BranchProtection, error) {
+	rules, err := f.client.ListBranchProtections(repoName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]BranchProtection, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, fromGiteaProtection(rule))
+	}
+	return result, nil
+}
+
+// TranslateBranchProtection drops the deletion permission, as protected
+// branches can never be deleted on Gitea
+func (f *codebergForge) TranslateBranchProtection(rule BranchProtection) (BranchProtection, []string) {
+	return withoutDeletion(rule, nil)
+}
+
+func (f *codebergForge) SetBranchProtection(repoName string, rule BranchProtection) error {
+	return f.client.SetBranchProtection(repoName, codeberg.BranchProtection{
+		RuleName:          rule.Branch,
+		RequiredApprovals: rule.RequiredReviews,
+		EnableForcePush:   rule.AllowForcePush,
+	})
+}
+
+func fromGiteaProtection(rule codeberg.BranchProtection) BranchProtection {
+	result := BranchProtection{
+		Branch:          rule.Branch(),
+		RequiredReviews: rule.RequiredApprovals,
+		AllowForcePush:  rule.EnableForcePush,
+	}
+	options := []struct {
+		name    string
+		enabled bool
+	}{
+		{"push restrictions", !rule.EnablePush || len(rule.PushWhitelistUsernames) > 0},
+		{"required status checks", rule.EnableStatusCheck},
+		{"signed commits", rule.RequireSignedCommits},
+		{"block outdated branches", rule.BlockOnOutdatedBranch},
+		{"dismiss stale reviews", rule.DismissStaleApprovals},
+		{"protected files", rule.ProtectedFilePatterns != ""},
+	}
+	for _, option := range options {
+		if option.enabled {
+			result.Other = append(result.Other, option.name)
+		}
+	}
+	return result
+}
+
+func (f *codebergForge) ListIssues(repoName string) ([]Issue, error) {
+	issues, err := f.client.ListIssues(repoName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, fromGiteaIssue(issue))
+	}
+	return result, nil
+}
+
+func (f *codebergForge) ListIssueComments(repoName string, number int) ([]IssueComment, error) {
+	comments, err := f.client.ListIssueComments(repoName, number)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]IssueComment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, fromGiteaComment(comment))
+	}
+	return result, nil
+}
+
+func (f *codebergForge) CreateIssue(repoName, title, body string) (Issue, error) {
+	issue, err := f.client.CreateIssue(repoName, title, body)
+	return fromGiteaIssue(issue), err
+}
+
+func (f *codebergForge) CreateIssueComment(repoName string, number int, body string) (IssueComment, error) {
+	comment, err := f.client.CreateIssueComment(repoName, number, body)
+	return fromGiteaComment(comment), err
+}
+
+func (f *codebergForge) CloseIssue(repoName string, number int) error {
+	return f.client.CloseIssue(repoName, number)
+}
+
+func (f *codebergForge) ListOpenPullRequests(repoName string) ([]int, error) {
+	return f.client.ListOpenPullRequests(repoName)
+}
+
+func (f *codebergForge) CreatePullMirror(repoName string, opts PullMirrorOptions) error {
+	return f.client.MigrateMirror(repoName, codeberg.MirrorOptions{
+		CloneURL:    opts.CloneURL,
+		Description: opts.Description,
+		Private:     opts.Private,
+		Interval:    opts.Interval.String(),
+	})
+}
+
+func (f *codebergForge) SetPullMirrorInterval(repoName string, interval time.Duration) error {
+	return f.client.UpdateRepoSettings(repoName, map[string]interface{}{"mirror_interval": interval.String()})
+}
+
+func fromGiteaIssue(issue codeberg.Issue) Issue {
+	return Issue{
+		Number: issue.Number,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		Author: issue.User.Login,
+		URL:    issue.HTMLURL,
+		Closed: issue.State == "closed",
+	}
+}
+
+func fromGiteaComment(comment codeberg.IssueComment) IssueComment {
+	return IssueComment{ID: comment.ID, Body: comment.Body, Author: comment.User.Login, URL: comment.HTMLURL}
+}
+
+func (f *codebergForge) ListReleases(repoName string) ([]string, error) {
+	return f.client.ListReleases(repoName)
+}
+
+func (f *codebergForge) CreateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.CreateRelease(repoName, tag, releaseNotes)
+}
+
+func (f *codebergForge) UpdateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.UpdateRelease(repoName, tag, releaseNotes)
+}
+
+func fromCodeberg(repo codeberg.Repository) Repository {
+	mirrorInterval, _ := time.ParseDuration(repo.MirrorInterval)
+	return Repository{
+		Name:           repo.Name,
+		Description:    repo.Description,
+		Private:        repo.Private,
+		Fork:           repo.Fork,
+		Archived:       repo.Archived,
+		Homepage:       repo.Website,
+		Topics:         repo.Topics,
+		DefaultBranch:  repo.DefaultBranch,
+		HasIssues:      repo.HasIssues,
+		HasWiki:        repo.HasWiki,
+		Mirror:         repo.Mirror,
+		MirrorURL:      repo.OriginalURL,
+		MirrorInterval: mirrorInterval,
+		MirrorUpdated:  repo.MirrorUpdated,
+	}
+}
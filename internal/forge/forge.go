@@ -0,0 +1,124 @@
+// Package forge provides a common interface over the supported git hosting
+// platforms so that CLI handlers can drive any configured forge generically.
+package forge
+
+import (
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// Forge types used as registry keys
+const (
+	TypeCodeberg  = "codeberg"
+	TypeGitea     = "gitea" // self-hosted Gitea and Forgejo instances
+	TypeGitHub    = "github"
+	TypeGitLab    = "gitlab"
+	TypeSourceHut = "sourcehut"
+)
+
+// Repository is the forge-independent view of a hosted repository
+type Repository struct {
+	Name          string
+	Description   string
+	Private       bool
+	Fork          bool
+	Archived      bool
+	Homepage      string
+	Topics        []string
+	DefaultBranch string
+	HasIssues     bool
+	HasWiki       bool
+	// Pull mirror state; only set on forges that support pull mirrors
+	Mirror         bool
+	MirrorURL      string
+	MirrorInterval time.Duration
+	MirrorUpdated  time.Time
+}
+
+// Forge is implemented by every supported git hosting platform. Features
+// that only some forges have are separate interfaces embedding Forge, such as
+// BranchProtector or PullMirrorer; callers check for them with a type
+// assertion.
+type Forge interface {
+	// Type returns the registry key of the forge, e.g. "github"
+	Type() string
+	// DisplayName returns a human-readable platform name, e.g. "GitHub"
+	DisplayName() string
+	// Organization returns the configured organization the forge operates on
+	Organization() *config.Organization
+	HasToken() bool
+	TestAuth() error
+
+	// ListRepos lists the non-fork repositories of the organization. Private
+	// repositories are only included if includePrivate is set.
+	ListRepos(includePrivate bool) ([]Repository, error)
+	// ListAllRepos is ListRepos including forks and archived repositories
+	ListAllRepos(includePrivate bool) ([]Repository, error)
+	GetRepo(repoName string) (Repository, bool, error)
+	RepoExists(repoName string) (bool, error)
+	CreateRepo(repoName, description string, private bool) error
+	DeleteRepo(repoName string) error
+	// RenameRepo renames a repository, keeping its history, issues and settings
+	RenameRepo(oldName, newName string) error
+	UpdateDescription(repoName, description string) error
+	// SupportsSetting reports whether the forge has a repository setting,
+	// one of config.AllSettings
+	SupportsSetting(setting string) bool
+	// ListTopics returns the topics of a repository; nil if the forge has none
+	ListTopics(repoName string) ([]string, error)
+	// UpdateSettings applies a partial update of repository settings
+	UpdateSettings(repoName string, settings RepoSettings) error
+
+	ListReleases(repoName string) ([]string, error)
+	CreateRelease(repoName, tag, releaseNotes string) error
+	UpdateRelease(repoName, tag, releaseNotes string) error
+}
+
+// BranchProtector is a forge that can protect branches
+type BranchProtector interface {
+	Forge
+	ListBranchProtections(repoName string) ([]BranchProtection, error)
+	// TranslateBranchProtection adapts a rule to what the forge can enforce
+	// and names the options that are lost, see the Protection constants
+	TranslateBranchProtection(rule BranchProtection) (BranchProtection, []string)
+	// SetBranchProtection protects a branch or updates its existing rule
+	SetBranchProtection(repoName string, rule BranchProtection) error
+}
+
+// IssueTracker is a forge whose issues can be mirrored to and from other forges
+type IssueTracker interface {
+	Forge
+	// ListIssues returns all open and closed issues, oldest first, without
+	// pull requests
+	ListIssues(repoName string) ([]Issue, error)
+	ListIssueComments(repoName string, number int) ([]IssueComment, error)
+	CreateIssue(repoName, title, body string) (Issue, error)
+	CreateIssueComment(repoName string, number int, body string) (IssueComment, error)
+	CloseIssue(repoName string, number int) error
+}
+
+// PullRequestLister is a forge that publishes pull request heads as
+// refs/pull/<number>/head
+type PullRequestLister interface {
+	Forge
+	// ListOpenPullRequests returns the numbers of all open pull requests
+	ListOpenPullRequests(repoName string) ([]int, error)
+}
+
+// PullMirrorer is a forge that can keep a repository in sync with another
+// forge on its own
+type PullMirrorer interface {
+	Forge
+	CreatePullMirror(repoName string, opts PullMirrorOptions) error
+	SetPullMirrorInterval(repoName string, interval time.Duration) error
+}
+
+// RepoNames extracts repository names from a list of repos
+func RepoNames(repos []Repository) []string {
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.Name
+	}
+	return names
+}
@@ -0,0 +1,286 @@
+package forge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// fakeGitea is a minimal stand-in for the Gitea/Forgejo API of one organization
+type fakeGitea struct {
+	mu       sync.Mutex
+	org      string
+	repos    map[string]map[string]any
+	releases map[string][]map[string]any
+	// protections maps repository names to branch protection rules by rule name
+	protections map[string]map[string]map[string]any
+}
+
+func newFakeGitea(org string) *httptest.Server {
+	f := &fakeGitea{
+		org:         org,
+		repos:       map[string]map[string]any{},
+		releases:    map[string][]map[string]any{},
+		protections: map[string]map[string]map[string]any{},
+	}
+	return httptest.NewServer(f)
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "token secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	writeJSON := func(status int, v any) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && path == "/user":
+		writeJSON(http.StatusOK, map[string]any{"login": "me"})
+	case r.Method == http.MethodGet && path == "/orgs/"+f.org+"/repos":
+		var list []map[string]any
+		if r.URL.Query().Get("page") == "1" {
+			for _, repo := range f.repos {
+				list = append(list, repo)
+			}
+		}
+		writeJSON(http.StatusOK, list)
+	case r.Method == http.MethodPost && path == "/orgs/"+f.org+"/repos":
+		var repo map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&repo)
+		repo["has_releases"] = true
+		f.repos[repo["name"].(string)] = repo
+		writeJSON(http.StatusCreated, repo)
+	case len(parts) >= 3 && parts[0] == "repos" && parts[1] == f.org:
+		f.serveRepo(w, r, parts[2], parts[3:], writeJSON)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitea) serveRepo(w http.ResponseWriter, r *http.Request, name string, rest []string, writeJSON func(int, any)) {
+	repo, ok := f.repos[name]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, repo)
+	case len(rest) == 0 && r.Method == http.MethodPatch:
+		var patch map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&patch)
+		for k, v := range patch {
+			repo[k] = v
+		}
+		writeJSON(http.StatusOK, repo)
+	case len(rest) == 1 && rest[0] == "topics" && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, map[string]any{"topics": repo["topics"]})
+	case len(rest) == 1 && rest[0] == "topics" && r.Method == http.MethodPut:
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		repo["topics"] = body["topics"]
+		w.WriteHeader(http.StatusNoContent)
+	case len(rest) == 1 && rest[0] == "releases" && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, f.releases[name])
+	case len(rest) == 1 && rest[0] == "releases" && r.Method == http.MethodPost:
+		var release map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&release)
+		f.releases[name] = append(f.releases[name], release)
+		writeJSON(http.StatusCreated, release)
+	case len(rest) >= 1 && rest[0] == "branch_protections":
+		f.serveProtections(w, r, name, rest[1:], writeJSON)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitea) serveProtections(w http.ResponseWriter, r *http.Request, name string, rest []string, writeJSON func(int, any)) {
+	if f.protections[name] == nil {
+		f.protections[name] = map[string]map[string]any{}
+	}
+	rules := f.protections[name]
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		list := []map[string]any{}
+		for _, rule := range rules {
+			list = append(list, rule)
+		}
+		writeJSON(http.StatusOK, list)
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		var rule map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		rules[rule["rule_name"].(string)] = rule
+		writeJSON(http.StatusCreated, rule)
+	case len(rest) == 1 && r.Method == http.MethodPatch && rules[rest[0]] != nil:
+		rule := rules[rest[0]]
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		writeJSON(http.StatusOK, rule)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGiteaForge_AgainstStandInServer(t *testing.T) {
+	server := newFakeGitea("team")
+	defer server.Close()
+
+	org := &config.Organization{
+		Host:       "git@git.example.com",
+		Name:       "team",
+		Type:       config.TypeForgejo,
+		APIURL:     server.URL,
+		GiteaToken: "secret",
+	}
+
+	f, err := New(org)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if f.Type() != TypeGitea || f.DisplayName() != "Forgejo" {
+		t.Fatalf("got type %q and name %q", f.Type(), f.DisplayName())
+	}
+	if err := f.TestAuth(); err != nil {
+		t.Fatalf("TestAuth() error = %v", err)
+	}
+
+	if err := f.CreateRepo("tool", "A tool", false); err != nil {
+		t.Fatalf("CreateRepo() error = %v", err)
+	}
+	if err := f.UpdateDescription("tool", "A better tool"); err != nil {
+		t.Fatalf("UpdateDescription() error = %v", err)
+	}
+	repo, exists, err := f.GetRepo("tool")
+	if err != nil || !exists || repo.Description != "A better tool" {
+		t.Fatalf("GetRepo() = %#v, %v, %v", repo, exists, err)
+	}
+
+	if err := f.CreateRepo("secret-tool", "", true); err != nil {
+		t.Fatalf("CreateRepo() error = %v", err)
+	}
+	repos, err := f.ListRepos(false)
+	if err != nil {
+		t.Fatalf("ListRepos() error = %v", err)
+	}
+	if names := RepoNames(repos); len(names) != 1 || names[0] != "tool" {
+		t.Fatalf("ListRepos(false) names = %v", names)
+	}
+	if repos, err = f.ListRepos(true); err != nil || len(repos) != 2 {
+		t.Fatalf("ListRepos(true) = %#v, %v", repos, err)
+	}
+
+	homepage, archived := "https://example.org", true
+	settings := RepoSettings{Homepage: &homepage, Archived: &archived, Topics: []string{"go"}}
+	if err := f.UpdateSettings("tool", settings); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+	repo, _, _ = f.GetRepo("tool")
+	if repo.Homepage != homepage || !repo.Archived {
+		t.Fatalf("settings not applied: %#v", repo)
+	}
+	if topics, err := f.ListTopics("tool"); err != nil || len(topics) != 1 || topics[0] != "go" {
+		t.Fatalf("ListTopics() = %v, %v", topics, err)
+	}
+
+	if err := f.CreateRelease("tool", "v1.0.0", "notes"); err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+	tags, err := f.ListReleases("tool")
+	if err != nil || len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Fatalf("ListReleases() = %v, %v", tags, err)
+	}
+}
+
+func TestTypeOf_SelfHostedGiteaTakesPrecedenceOverHost(t *testing.T) {
+	org := &config.Organization{Host: "git@codeberg.org", Name: "x", Type: config.TypeGitea, APIURL: "https://codeberg.org"}
+	if got := TypeOf(org); got != TypeGitea {
+		t.Fatalf("TypeOf() = %q, want %q", got, TypeGitea)
+	}
+	if org.IsSSH() {
+		t.Fatal("expected a Gitea organization not to be treated as a plain SSH location")
+	}
+}
+
+func TestGiteaForge_BranchProtection(t *testing.T) {
+	server := newFakeGitea("team")
+	defer server.Close()
+
+	created, err := New(&config.Organization{Host: "git@git.example.com", Name: "team", Type: config.TypeGitea, APIURL: server.URL, GiteaToken: "secret"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	f, ok := created.(BranchProtector)
+	if !ok {
+		t.Fatal("expected a Gitea forge to protect branches")
+	}
+	if err := f.CreateRepo("tool", "", false); err != nil {
+		t.Fatalf("CreateRepo() error = %v", err)
+	}
+
+	rule, lost := f.TranslateBranchProtection(BranchProtection{Branch: "main", RequiredReviews: 2, AllowDeletion: true})
+	if rule.AllowDeletion || len(lost) != 1 || lost[0] != ProtectionAllowDeletion {
+		t.Fatalf("TranslateBranchProtection() = %#v, %v", rule, lost)
+	}
+	if err := f.SetBranchProtection("tool", rule); err != nil {
+		t.Fatalf("SetBranchProtection() create error = %v", err)
+	}
+	// A push whitelist set up on the forge must survive updates
+	protectionsURL := server.URL + "/api/v1/repos/team/tool/branch_protections"
+	giteaRequest(t, http.MethodPatch, protectionsURL+"/main", `{"enable_push_whitelist": true, "push_whitelist_usernames": ["bot"]}`, nil)
+	rule.AllowForcePush = true
+	if err := f.SetBranchProtection("tool", rule); err != nil {
+		t.Fatalf("SetBranchProtection() update error = %v", err)
+	}
+
+	rules, err := f.ListBranchProtections("tool")
+	if err != nil || len(rules) != 1 {
+		t.Fatalf("ListBranchProtections() = %#v, %v", rules, err)
+	}
+	if !rules[0].Equal(rule) || len(rules[0].Other) != 1 || rules[0].Other[0] != "push restrictions" {
+		t.Fatalf("rule = %#v, want %#v with the whitelist as push restrictions", rules[0], rule)
+	}
+
+	var raw []map[string]any
+	giteaRequest(t, http.MethodGet, protectionsURL, "", &raw)
+	if len(raw) != 1 || raw[0]["enable_push"] != true || raw[0]["enable_push_whitelist"] != true {
+		t.Fatalf("expected the push whitelist to be kept, got %v", raw)
+	}
+}
+
+// giteaRequest sends an authenticated request to the stand-in server
+func giteaRequest(t *testing.T, method, url, body string, out any) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "token secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("%s %s: %s", method, url, resp.Status)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package forge
+
+import (
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/github"
+)
+
+// githubForge adapts the GitHub API client to the Forge interface
+type githubForge struct {
+	org    *config.Organization
+	client github.Client
+}
+
+func newGitHubForge(org *config.Organization) Forge {
+	f := &githubForge{org: org, client: github.NewEnterpriseClient(org.APIURL, org.GitHubToken, org.Name)}
+	f.client.SetAccountType(org.AccountType)
+	return f
+}
+
+func (f *githubForge) Type() string { return TypeGitHub }
+
+// DisplayName distinguishes GitHub Enterprise Server organizations from github.com
+func (f *githubForge) DisplayName() string {
+	if f.org.APIURL != "" {
+		return "GitHub Enterprise"
+	}
+	return "GitHub"
+}
+
+func (f *githubForge) Organization() *config.Organization { return f.org }
+func (f *githubForge) HasToken() bool                     { return f.client.HasToken() }
+func (f *githubForge) TestAuth() error                    { return f.client.TestAuth() }
+
+func (f *githubForge) ListRepos(includePrivate bool) ([]Repository, error) {
+	repos, err := f.client.ListRepos(includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, fromGitHub(repo))
+	}
+	return result, nil
+}
+
+func (f *githubForge) ListAllRepos(includePrivate bool) ([]Repository, error) {
+	repos, err := f.client.ListAllRepos(includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, fromGitHub(repo))
+	}
+	return result, nil
+}
+
+func (f *githubForge) GetRepo(repoName string) (Repository, bool, error) {
+	repo, exists, err := f.client.GetRepo(repoName)
+	return fromGitHub(repo), exists, err
+}
+
+func (f *githubForge) RepoExists(repoName string) (bool, error) {
+	return f.client.RepoExists(repoName)
+}
+
+func (f *githubForge) CreateRepo(repoName, description string, private bool) error {
+	return f.client.CreateRepo(repoName, description, private)
+}
+
+func (f *githubForge) DeleteRepo(repoName string) error {
+	return f.client.DeleteRepo(repoName)
+}
+
+func (f *githubForge) RenameRepo(oldName, newName string) error {
+	return f.client.RenameRepo(oldName, newName)
+}
+
+func (f *githubForge) UpdateDescription(repoName, description string) error {
+	return f.client.UpdateRepoDescription(repoName, description)
+}
+
+func (f *githubForge) SupportsSetting(setting string) bool { return true }
+
+func (f *githubForge) ListTopics(repoName string) ([]string, error) {
+	return f.client.ListTopics(repoName)
+}
+
+func (f *githubForge) UpdateSettings(repoName string, settings RepoSettings) error {
+	setArchived := func(archived bool) error {
+		return f.client.UpdateRepoSettings(repoName, map[string]interface{}{"archived": archived})
+	}
+	return applyArchivedLast(settings.Archived, setArchived, func() error {
+		payload := map[string]interface{}{}
+		if settings.Homepage != nil {
+			payload["homepage"] = *settings.Homepage
+		}
+		if settings.DefaultBranch != nil {
+			payload["default_branch"] = *settings.DefaultBranch
+		}
+		if settings.HasIssues != nil {
+			payload["has_issues"] = *settings.HasIssues
+		}
+		if settings.HasWiki != nil {
+			payload["has_wiki"] = *settings.HasWiki
+		}
+		if settings.Private != nil {
+			payload["private"] = *settings.Private
+		}
+		if len(payload) > 0 {
+			if err := f.client.UpdateRepoSettings(repoName, payload); err != nil {
+				return err
+			}
+		}
+		if settings.Topics != nil {
+			return f.client.ReplaceTopics(repoName, settings.Topics)
+		}
+		return nil
+	})
+}
+
+func (f *githubForge) ListBranchProtections(repoName string) ([]BranchProtection, error) {
+	rules, err := f.client.ListBranchProtections(repoName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]BranchProtection, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, BranchProtection{
+			Branch:          rule.Branch,
+			RequiredReviews: rule.RequiredReviews,
+			AllowForcePush:  rule.AllowForcePushes,
+			AllowDeletion:   rule.AllowDeletions,
+			Other:           rule.Other,
+		})
+	}
+	return result, nil
+}
+
+// TranslateBranchProtection keeps the whole subset, GitHub supports all of it
+func (f *githubForge) TranslateBranchProtection(rule BranchProtection) (BranchProtection, []string) {
+	return rule, nil
+}
+
+func (f *githubForge) SetBranchProtection(repoName string, rule BranchProtection) error {
+	return f.client.SetBranchProtection(repoName, github.BranchProtection{
+		Branch:           rule.Branch,
+		RequiredReviews:  rule.RequiredReviews,
+		AllowForcePushes: rule.AllowForcePush,
+		AllowDeletions:   rule.AllowDeletion,
+	})
+}
+
+func (f *githubForge) ListIssues(repoName string) ([]Issue, error) {
+	issues, err := f.client.ListIssues(repoName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, fromGitHubIssue(issue))
+	}
+	return result, nil
+}
+
+func (f *githubForge) ListIssueComments(repoName string, number int) ([]IssueComment, error) {
+	comments, err := f.client.ListIssueComments(repoName, number)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]IssueComment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, fromGitHubComment(comment))
+	}
+	return result, nil
+}
+
+func (f *githubForge) CreateIssue(repoName, title, body string) (Issue, error) {
+	issue, err := f.client.CreateIssue(repoName, title, body)
+	return fromGitHubIssue(issue), err
+}
+
+func (f *githubForge) CreateIssueComment(repoName string, number int, body string) (IssueComment, error) {
+	comment, err := f.client.CreateIssueComment(repoName, number, body)
+	return fromGitHubComment(comment), err
+}
+
+func (f *githubForge) CloseIssue(repoName string, number int) error {
+	return f.client.CloseIssue(repoName, number)
+}
+
+func (f *githubForge) ListOpenPullRequests(repoName string) ([]int, error) {
+	return f.client.ListOpenPullRequests(repoName)
+}
+
+func fromGitHubIssue(issue github.Issue) Issue {
+	return Issue{
+		Number: issue.Number,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		Author: issue.User.Login,
+		URL:    issue.HTMLURL,
+		Closed: issue.State == "closed",
+	}
+}
+
+func fromGitHubComment(comment github.IssueComment) IssueComment {
+	return IssueComment{ID: comment.ID, Body: comment.Body, Author: comment.User.Login, URL: comment.HTMLURL}
+}
+
+func (f *githubForge) ListReleases(repoName string) ([]string, error) {
+	return f.client.ListReleases(repoName)
+}
+
+func (f *githubForge) CreateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.CreateRelease(repoName, tag, releaseNotes)
+}
+
+func (f *githubForge) UpdateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.UpdateRelease(repoName, tag, releaseNotes)
+}
+
+func fromGitHub(repo github.Repository) Repository {
+	return Repository{
+		Name:          repo.Name,
+		Description:   repo.Description,
+		Private:       repo.Private,
+		Fork:          repo.Fork,
+		Archived:      repo.Archived,
+		Homepage:      repo.Homepage,
+		Topics:        repo.Topics,
+		DefaultBranch: repo.DefaultBranch,
+		HasIssues:     repo.HasIssues,
+		HasWiki:       repo.HasWiki,
+	}
+}
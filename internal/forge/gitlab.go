@@ -0,0 +1,168 @@
+package forge
+
+import (
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/gitlab"
+)
+
+// gitlabForge adapts the GitLab REST v4 client to the Forge interface
+type gitlabForge struct {
+	org    *config.Organization
+	client gitlab.Client
+}
+
+func newGitLabForge(org *config.Organization) Forge {
+	return &gitlabForge{org: org, client: gitlab.NewClient(org.APIURL, org.Name, org.GitLabToken)}
+}
+
+func (f *gitlabForge) Type() string                       { return TypeGitLab }
+func (f *gitlabForge) DisplayName() string                { return "GitLab" }
+func (f *gitlabForge) Organization() *config.Organization { return f.org }
+func (f *gitlabForge) HasToken() bool                     { return f.client.HasToken() }
+func (f *gitlabForge) TestAuth() error                    { return f.client.TestAuth() }
+
+func (f *gitlabForge) ListRepos(includePrivate bool) ([]Repository, error) {
+	projects, err := f.client.ListRepos(includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Repository, 0, len(projects))
+	for _, project := range projects {
+		result = append(result, fromGitLab(project))
+	}
+	return result, nil
+}
+
+func (f *gitlabForge) ListAllRepos(includePrivate bool) ([]Repository, error) {
+	projects, err := f.client.ListAllRepos(includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Repository, 0, len(projects))
+	for _, project := range projects {
+		result = append(result, fromGitLab(project))
+	}
+	return result, nil
+}
+
+func (f *gitlabForge) GetRepo(repoName string) (Repository, bool, error) {
+	project, exists, err := f.client.GetRepo(repoName)
+	return fromGitLab(project), exists, err
+}
+
+func (f *gitlabForge) RepoExists(repoName string) (bool, error) {
+	return f.client.RepoExists(repoName)
+}
+
+func (f *gitlabForge) CreateRepo(repoName, description string, private bool) error {
+	return f.client.CreateRepo(repoName, description, private)
+}
+
+func (f *gitlabForge) DeleteRepo(repoName string) error {
+	return f.client.DeleteRepo(repoName)
+}
+
+func (f *gitlabForge) RenameRepo(oldName, newName string) error {
+	return f.client.RenameRepo(oldName, newName)
+}
+
+func (f *gitlabForge) UpdateDescription(repoName, description string) error {
+	return f.client.UpdateRepoDescription(repoName, description)
+}
+
+// SupportsSetting reports all settings except the homepage, which GitLab
+// projects do not have
+func (f *gitlabForge) SupportsSetting(setting string) bool {
+	return setting != config.SettingHomepage
+}
+
+// ListTopics returns the topics GitLab includes in the project itself
+func (f *gitlabForge) ListTopics(repoName string) ([]string, error) {
+	project, _, err := f.client.GetRepo(repoName)
+	return project.Topics, err
+}
+
+func (f *gitlabForge) UpdateSettings(repoName string, settings RepoSettings) error {
+	setArchived := func(archived bool) error {
+		return f.client.SetArchived(repoName, archived)
+	}
+	return applyArchivedLast(settings.Archived, setArchived, func() error {
+		payload := map[string]any{}
+		if settings.Topics != nil {
+			payload["topics"] = settings.Topics
+		}
+		if settings.DefaultBranch != nil {
+			payload["default_branch"] = *settings.DefaultBranch
+		}
+		if settings.HasIssues != nil {
+			payload["issues_enabled"] = *settings.HasIssues
+		}
+		if settings.HasWiki != nil {
+			payload["wiki_enabled"] = *settings.HasWiki
+		}
+		if settings.Private != nil {
+			payload["visibility"] = "public"
+			if *settings.Private {
+				payload["visibility"] = "private"
+			}
+		}
+		if len(payload) == 0 {
+			return nil
+		}
+		return f.client.UpdateProjectSettings(repoName, payload)
+	})
+}
+
+func (f *gitlabForge) ListBranchProtections(repoName string) ([]BranchProtection, error) {
+	branches, err := f.client.ListProtectedBranches(repoName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]BranchProtection, 0, len(branches))
+	for _, branch := range branches {
+		rule := BranchProtection{Branch: branch.Name, AllowForcePush: branch.AllowForcePush}
+		if branch.CodeOwnerApprovalRequired {
+			rule.Other = append(rule.Other, "code owner approval")
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// TranslateBranchProtection drops required reviews, which are merge request
+// approval rules on GitLab, and the deletion permission, as protected
+// branches can never be deleted
+func (f *gitlabForge) TranslateBranchProtection(rule BranchProtection) (BranchProtection, []string) {
+	rule, lost := withoutReviews(rule, nil)
+	return withoutDeletion(rule, lost)
+}
+
+func (f *gitlabForge) SetBranchProtection(repoName string, rule BranchProtection) error {
+	return f.client.ProtectBranch(repoName, rule.Branch, rule.AllowForcePush)
+}
+
+func (f *gitlabForge) ListReleases(repoName string) ([]string, error) {
+	return f.client.ListReleases(repoName)
+}
+
+func (f *gitlabForge) CreateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.CreateRelease(repoName, tag, releaseNotes)
+}
+
+func (f *gitlabForge) UpdateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.UpdateRelease(repoName, tag, releaseNotes)
+}
+
+func fromGitLab(project gitlab.Project) Repository {
+	return Repository{
+		Name:          project.Path,
+		Description:   project.Description,
+		Private:       project.Private(),
+		Fork:          project.Fork(),
+		Archived:      project.Archived,
+		Topics:        project.Topics,
+		DefaultBranch: project.DefaultBranch,
+		HasIssues:     project.IssuesEnabled,
+		HasWiki:       project.WikiEnabled,
+	}
+}
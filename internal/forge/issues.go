@@ -0,0 +1,19 @@
+package forge
+
+// Issue is the forge-independent view of an issue
+type Issue struct {
+	Number int
+	Title  string
+	Body   string
+	Author string
+	URL    string
+	Closed bool
+}
+
+// IssueComment is a comment on an issue
+type IssueComment struct {
+	ID     int64
+	Body   string
+	Author string
+	URL    string
+}
@@ -0,0 +1,22 @@
+package forge
+
+import (
+	"fmt"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// PullMirrorOptions describes a repository that pulls from another forge on
+// its own
+type PullMirrorOptions struct {
+	CloneURL    string // HTTPS clone URL of the source repository
+	Description string
+	Private     bool
+	Interval    time.Duration
+}
+
+// CloneURL returns the anonymous HTTPS clone URL of a repository
+func CloneURL(org *config.Organization, repoName string) string {
+	return fmt.Sprintf("https://%s/%s/%s.git", org.WebHost(), org.Name, repoName)
+}
@@ -0,0 +1,44 @@
+package forge
+
+// BranchProtection is the forge-independent subset of a branch protection
+// rule: required reviews, force pushes and branch deletion
+type BranchProtection struct {
+	Branch          string
+	RequiredReviews int // required approving reviews, 0 for none
+	AllowForcePush  bool
+	AllowDeletion   bool
+	// Other names enabled options outside the subset; they are not mirrored
+	Other []string
+}
+
+// Branch protection options that a forge may be unable to enforce
+const (
+	ProtectionRequiredReviews = "required reviews"
+	ProtectionAllowDeletion   = "allow deletion"
+)
+
+// Equal reports whether two rules agree on the mirrored subset
+func (p BranchProtection) Equal(other BranchProtection) bool {
+	return p.Branch == other.Branch && p.RequiredReviews == other.RequiredReviews &&
+		p.AllowForcePush == other.AllowForcePush && p.AllowDeletion == other.AllowDeletion
+}
+
+// withoutReviews drops required reviews from a rule for forges that keep
+// approvals outside of branch protection
+func withoutReviews(rule BranchProtection, lost []string) (BranchProtection, []string) {
+	if rule.RequiredReviews > 0 {
+		rule.RequiredReviews = 0
+		lost = append(lost, ProtectionRequiredReviews)
+	}
+	return rule, lost
+}
+
+// withoutDeletion drops the deletion permission for forges on which
+// protected branches can never be deleted
+func withoutDeletion(rule BranchProtection, lost []string) (BranchProtection, []string) {
+	if rule.AllowDeletion {
+		rule.AllowDeletion = false
+		lost = append(lost, ProtectionAllowDeletion)
+	}
+	return rule, lost
+}
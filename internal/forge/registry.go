@@ -0,0 +1,90 @@
+package forge
+
+import (
+	"fmt"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// Factory creates a forge for an organization
+type Factory func(org *config.Organization) Forge
+
+var (
+	factories = make(map[string]Factory)
+	// order keeps the registration order, which is also the precedence used
+	// when a canonical value (e.g. a description) is picked across forges
+	order []string
+)
+
+func init() {
+	// Codeberg is registered first as it is the canonical source of metadata
+	Register(TypeCodeberg, newCodebergForge)
+	Register(TypeGitea, newGiteaForge)
+	Register(TypeGitHub, newGitHubForge)
+	Register(TypeGitLab, newGitLabForge)
+	Register(TypeSourceHut, newSourceHutForge)
+}
+
+// Register adds a forge factory for an organization type
+func Register(forgeType string, factory Factory) {
+	if _, exists := factories[forgeType]; !exists {
+		order = append(order, forgeType)
+	}
+	factories[forgeType] = factory
+}
+
+// TypeOf returns the forge type of an organization, or "" if it is not hosted on a forge
+func TypeOf(org *config.Organization) string {
+	switch {
+	case org == nil:
+		return ""
+	case org.IsGitea():
+		return TypeGitea
+	case org.IsGitLab():
+		return TypeGitLab
+	case org.IsSourceHut():
+		return TypeSourceHut
+	case org.IsCodeberg():
+		return TypeCodeberg
+	case org.IsGitHub():
+		return TypeGitHub
+	default:
+		return ""
+	}
+}
+
+// New creates the forge for an organization
+func New(org *config.Organization) (Forge, error) {
+	forgeType := TypeOf(org)
+	factory, ok := factories[forgeType]
+	if !ok {
+		return nil, fmt.Errorf("no forge API available for %s", org.Host)
+	}
+	return factory(org), nil
+}
+
+// Configured returns a forge for every forge-hosted organization in the
+// configuration, ordered by forge precedence and then by configuration order
+func Configured(cfg *config.Config) []Forge {
+	var forges []Forge
+	for _, forgeType := range order {
+		for i := range cfg.Organizations {
+			org := &cfg.Organizations[i]
+			if TypeOf(org) == forgeType {
+				forges = append(forges, factories[forgeType](org))
+			}
+		}
+	}
+	return forges
+}
+
+// Find returns the forge of the first organization of the given type
+func Find(cfg *config.Config, forgeType string) Forge {
+	for i := range cfg.Organizations {
+		org := &cfg.Organizations[i]
+		if TypeOf(org) == forgeType {
+			return factories[forgeType](org)
+		}
+	}
+	return nil
+}
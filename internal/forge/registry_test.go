@@ -0,0 +1,103 @@
+package forge
+
+import (
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestConfigured_OrdersForgesByPrecedence(t *testing.T) {
+	cfg := &config.Config{Organizations: []config.Organization{
+		{Host: "git@github.com", Name: "gh"},
+		{Host: "paul@nas:git", BackupLocation: true},
+		{Host: "git@codeberg.org", Name: "cb"},
+	}}
+
+	forges := Configured(cfg)
+	if len(forges) != 2 {
+		t.Fatalf("expected 2 forges, got %d", len(forges))
+	}
+	if forges[0].Type() != TypeCodeberg || forges[1].Type() != TypeGitHub {
+		t.Fatalf("expected codeberg before github, got %s, %s", forges[0].Type(), forges[1].Type())
+	}
+	if forges[1].Organization() != &cfg.Organizations[0] {
+		t.Fatal("expected forge to reference the configured organization")
+	}
+}
+
+func TestNew_RejectsNonForgeOrganizations(t *testing.T) {
+	if _, err := New(&config.Organization{Host: "file:///srv/git", BackupLocation: true}); err == nil {
+		t.Fatal("expected an error for a file:// organization")
+	}
+
+	f, err := New(&config.Organization{Host: "git@codeberg.org", Name: "cb"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if f.DisplayName() != "Codeberg" {
+		t.Fatalf("DisplayName() = %q", f.DisplayName())
+	}
+}
+
+func TestNew_OptionalFeatures(t *testing.T) {
+	tests := []struct {
+		host                                 string
+		protects, tracksIssues, lists, pulls bool
+	}{
+		{host: "git@github.com", protects: true, tracksIssues: true, lists: true},
+		{host: "git@codeberg.org", protects: true, tracksIssues: true, lists: true, pulls: true},
+		{host: "git@gitlab.com", protects: true},
+		{host: "git@git.sr.ht"},
+	}
+	for _, tt := range tests {
+		f, err := New(&config.Organization{Host: tt.host, Name: "me"})
+		if err != nil {
+			t.Fatalf("New(%s) error = %v", tt.host, err)
+		}
+		_, protects := f.(BranchProtector)
+		_, tracksIssues := f.(IssueTracker)
+		_, lists := f.(PullRequestLister)
+		_, pulls := f.(PullMirrorer)
+		if protects != tt.protects || tracksIssues != tt.tracksIssues || lists != tt.lists || pulls != tt.pulls {
+			t.Errorf("%s: BranchProtector %v, IssueTracker %v, PullRequestLister %v, PullMirrorer %v", tt.host, protects, tracksIssues, lists, pulls)
+		}
+	}
+}
+
+func TestFind_ReturnsFirstOrganizationOfType(t *testing.T) {
+	cfg := &config.Config{Organizations: []config.Organization{
+		{Host: "git@github.com", Name: "first"},
+		{Host: "git@github.com", Name: "second"},
+	}}
+
+	if f := Find(cfg, TypeGitHub); f == nil || f.Organization().Name != "first" {
+		t.Fatalf("expected first GitHub org, got %#v", f)
+	}
+	if f := Find(cfg, TypeCodeberg); f != nil {
+		t.Fatalf("expected no Codeberg forge, got %#v", f)
+	}
+}
+
+func TestTypeOf_DetectsSourceHut(t *testing.T) {
+	org := &config.Organization{Host: "git@git.sr.ht", Name: "~me"}
+	if got := TypeOf(org); got != TypeSourceHut {
+		t.Fatalf("TypeOf() = %q, want %q", got, TypeSourceHut)
+	}
+	if got := org.GetGitURL(); got != "git@git.sr.ht:~me" {
+		t.Fatalf("GetGitURL() = %q", got)
+	}
+}
+
+func TestTypeOf_DetectsGitLab(t *testing.T) {
+	for _, org := range []*config.Organization{
+		{Host: "git@gitlab.com", Name: "group"},
+		{Host: "git@gitlab.example.com", Name: "group", Type: config.TypeGitLab, APIURL: "https://gitlab.example.com"},
+	} {
+		if got := TypeOf(org); got != TypeGitLab {
+			t.Fatalf("TypeOf(%s) = %q, want %q", org.Host, got, TypeGitLab)
+		}
+		if org.IsSSH() {
+			t.Fatalf("expected %s not to be treated as a plain SSH location", org.Host)
+		}
+	}
+}
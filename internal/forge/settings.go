@@ -0,0 +1,39 @@
+package forge
+
+// RepoSettings is a partial update of repository settings. Nil fields are
+// left unchanged; an empty, non-nil Topics slice removes all topics.
+// Private is only ever set to true, as repositories are never made public
+// automatically.
+type RepoSettings struct {
+	Homepage      *string
+	Topics        []string
+	Archived      *bool
+	DefaultBranch *string
+	HasIssues     *bool
+	HasWiki       *bool
+	Private       *bool
+}
+
+// IsEmpty reports whether the update changes nothing
+func (s RepoSettings) IsEmpty() bool {
+	return s.Homepage == nil && s.Topics == nil && s.Archived == nil &&
+		s.DefaultBranch == nil && s.HasIssues == nil && s.HasWiki == nil &&
+		s.Private == nil
+}
+
+// applyArchivedLast runs update between unarchiving and archiving, since
+// archived repositories are read-only on most forges
+func applyArchivedLast(archived *bool, setArchived func(bool) error, update func() error) error {
+	if archived != nil && !*archived {
+		if err := setArchived(false); err != nil {
+			return err
+		}
+	}
+	if err := update(); err != nil {
+		return err
+	}
+	if archived != nil && *archived {
+		return setArchived(true)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package forge
+
+import (
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/sourcehut"
+)
+
+// sourcehutForge adapts the git.sr.ht GraphQL client to the Forge interface
+type sourcehutForge struct {
+	org    *config.Organization
+	client sourcehut.Client
+}
+
+func newSourceHutForge(org *config.Organization) Forge {
+	return &sourcehutForge{org: org, client: sourcehut.NewClient(org.APIURL, org.Name, org.SourceHutToken)}
+}
+
+func (f *sourcehutForge) Type() string                       { return TypeSourceHut }
+func (f *sourcehutForge) DisplayName() string                { return "SourceHut" }
+func (f *sourcehutForge) Organization() *config.Organization { return f.org }
+func (f *sourcehutForge) HasToken() bool                     { return f.client.HasToken() }
+func (f *sourcehutForge) TestAuth() error                    { return f.client.TestAuth() }
+
+func (f *sourcehutForge) ListRepos(includePrivate bool) ([]Repository, error) {
+	repos, err := f.client.ListRepos(includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, fromSourceHut(repo))
+	}
+	return result, nil
+}
+
+// ListAllRepos is ListRepos, as SourceHut has neither forks nor archived
+// repositories
+func (f *sourcehutForge) ListAllRepos(includePrivate bool) ([]Repository, error) {
+	return f.ListRepos(includePrivate)
+}
+
+func (f *sourcehutForge) GetRepo(repoName string) (Repository, bool, error) {
+	repo, exists, err := f.client.GetRepo(repoName)
+	return fromSourceHut(repo), exists, err
+}
+
+func (f *sourcehutForge) RepoExists(repoName string) (bool, error) {
+	return f.client.RepoExists(repoName)
+}
+
+func (f *sourcehutForge) CreateRepo(repoName, description string, private bool) error {
+	return f.client.CreateRepo(repoName, description, private)
+}
+
+func (f *sourcehutForge) DeleteRepo(repoName string) error {
+	return f.client.DeleteRepo(repoName)
+}
+
+func (f *sourcehutForge) RenameRepo(oldName, newName string) error {
+	return f.client.RenameRepo(oldName, newName)
+}
+
+func (f *sourcehutForge) UpdateDescription(repoName, description string) error {
+	return f.client.UpdateRepoDescription(repoName, description)
+}
+
+// SupportsSetting reports the default branch and visibility; SourceHut
+// repositories have no homepage, topics, archived flag, issues or wiki
+func (f *sourcehutForge) SupportsSetting(setting string) bool {
+	return setting == config.SettingDefaultBranch || setting == config.SettingVisibility
+}
+
+func (f *sourcehutForge) ListTopics(repoName string) ([]string, error) { return nil, nil }
+
+func (f *sourcehutForge) UpdateSettings(repoName string, settings RepoSettings) error {
+	if settings.DefaultBranch != nil {
+		if err := f.client.UpdateDefaultBranch(repoName, *settings.DefaultBranch); err != nil {
+			return err
+		}
+	}
+	if settings.Private != nil {
+		visibility := sourcehut.VisibilityPublic
+		if *settings.Private {
+			visibility = sourcehut.VisibilityPrivate
+		}
+		return f.client.UpdateVisibility(repoName, visibility)
+	}
+	return nil
+}
+
+func (f *sourcehutForge) ListReleases(repoName string) ([]string, error) {
+	return f.client.ListReleases(repoName)
+}
+
+func (f *sourcehutForge) CreateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.CreateRelease(repoName, tag, releaseNotes)
+}
+
+func (f *sourcehutForge) UpdateRelease(repoName, tag, releaseNotes string) error {
+	return f.client.UpdateRelease(repoName, tag, releaseNotes)
+}
+
+func fromSourceHut(repo sourcehut.Repository) Repository {
+	return Repository{
+		Name:          repo.Name,
+		Description:   repo.Description,
+		Private:       repo.Private(),
+		DefaultBranch: repo.DefaultBranch(),
+	}
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// jsonRequest sends an authenticated API request with an optional JSON
+// payload and decodes the response into out if it is non-nil. what names the
+// requested resource in error messages.
+func (c *Client) jsonRequest(method, endpoint, what string, payload, out interface{}) error {
+	if c.token == "" {
+		return fmt.Errorf("GitHub token required for %s", what)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	req, cancel, err := httpclient.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub %s request failed: %s - %s", what, resp.Status, string(b))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", what, err)
+	}
+	return nil
+}
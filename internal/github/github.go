@@ -13,17 +13,42 @@ import (
 	"codeberg.org/snonux/gitsyncer/internal/httpclient"
 )
 
+// DefaultAPIURL is the REST endpoint of github.com
+const DefaultAPIURL = "https://api.github.com"
+
+// Account types of the GitHub owner a client operates on
+const (
+	AccountUser = "user"
+	AccountOrg  = "org"
+)
+
 // Client handles GitHub API operations
 type Client struct {
-	token string
-	org   string
+	baseURL     string
+	token       string
+	org         string
+	accountType string // AccountUser, AccountOrg or "" until detected
 }
 
 // NewClient creates a new GitHub API client
 func NewClient(token, org string) Client {
+	return NewEnterpriseClient("", token, org)
+}
+
+// NewEnterpriseClient creates a GitHub API client for a GitHub Enterprise
+// Server. apiURL may be the server root or its /api/v3 endpoint; an empty
+// apiURL means github.com.
+func NewEnterpriseClient(apiURL, token, org string) Client {
+	baseURL := strings.TrimRight(strings.TrimSpace(apiURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	} else if !strings.HasSuffix(baseURL, "/api/v3") {
+		baseURL += "/api/v3"
+	}
 	return Client{
-		token: loadToken(token),
-		org:   org,
+		baseURL: baseURL,
+		token:   loadToken(token),
+		org:     org,
 	}
 }
 
@@ -50,6 +75,52 @@ func loadToken(token string) string {
 	return strings.TrimSpace(string(data))
 }
 
+// SetAccountType tells the client whether the owner is a user or an
+// organization. An empty account type is detected on first use.
+func (c *Client) SetAccountType(accountType string) {
+	c.accountType = accountType
+}
+
+// isOrganization reports whether the owner is an organization, asking the
+// API once if the account type was not configured
+func (c *Client) isOrganization() (bool, error) {
+	if c.accountType == "" {
+		req, cancel, err := httpclient.NewRequest(http.MethodGet, fmt.Sprintf("%s/users/%s", c.baseURL, c.org), nil)
+		if err != nil {
+			return false, err
+		}
+		defer cancel()
+
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := httpclient.Do(req)
+		if err != nil {
+			return false, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return false, fmt.Errorf("failed to look up account %s: status %d: %s", c.org, resp.StatusCode, string(body))
+		}
+
+		var account struct {
+			Type string `json:"type"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+			return false, fmt.Errorf("failed to decode account: %w", err)
+		}
+		c.accountType = AccountUser
+		if account.Type == "Organization" {
+			c.accountType = AccountOrg
+		}
+	}
+	return c.accountType == AccountOrg, nil
+}
+
 // CreateRepoRequest represents the request to create a repository
 type CreateRepoRequest struct {
 	Name        string `json:"name"`
@@ -84,7 +155,7 @@ func (c *Client) RepoExists(repoName string) (bool, error) {
 		return false, fmt.Errorf("GitHub token required")
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", c.org, repoName)
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
 	fmt.Printf("  Checking URL: %s\n", url)
 
 	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
@@ -134,7 +205,14 @@ func (c *Client) CreateRepo(repoName, description string, private bool) error {
 		return nil
 	}
 
-	url := fmt.Sprintf("https://api.github.com/user/repos")
+	isOrg, err := c.isOrganization()
+	if err != nil {
+		return err
+	}
+	url := c.baseURL + "/user/repos"
+	if isOrg {
+		url = fmt.Sprintf("%s/orgs/%s/repos", c.baseURL, c.org)
+	}
 
 	reqBody := CreateRepoRequest{
 		Name:        repoName,
@@ -199,7 +277,7 @@ func (c *Client) GetRepo(repoName string) (Repository, bool, error) {
 		return repo, false, fmt.Errorf("GitHub token required")
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", c.org, repoName)
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
 	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return repo, false, err
@@ -230,76 +308,92 @@ func (c *Client) GetRepo(repoName string) (Repository, bool, error) {
 
 // UpdateRepoDescription updates the repository description
 func (c *Client) UpdateRepoDescription(repoName, description string) error {
-	if c.token == "" {
-		return fmt.Errorf("GitHub token required to update repository")
-	}
+	return c.editRepo(repoName, map[string]interface{}{"description": description}, "description")
+}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", c.org, repoName)
-	payload := map[string]interface{}{
-		"description": description,
-	}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
+// RenameRepo renames a repository. GitHub redirects the old name to the new one.
+func (c *Client) RenameRepo(oldName, newName string) error {
+	return c.editRepo(oldName, map[string]interface{}{"name": newName}, "repository name")
+}
 
-	req, cancel, err := httpclient.NewRequest(http.MethodPatch, url, bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
-	defer cancel()
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("Content-Type", "application/json")
+// Repository represents a GitHub repository
+type Repository struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Private       bool     `json:"private"`
+	Fork          bool     `json:"fork"`
+	Archived      bool     `json:"archived"`
+	Disabled      bool     `json:"disabled"`
+	Size          int      `json:"size"`
+	Homepage      string   `json:"homepage"`
+	Topics        []string `json:"topics"`
+	DefaultBranch string   `json:"default_branch"`
+	HasIssues     bool     `json:"has_issues"`
+	HasWiki       bool     `json:"has_wiki"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
 
-	resp, err := httpclient.Do(req)
+// ListRepos lists the non-fork, non-archived repositories of the user/org,
+// see ListAllRepos
+func (c *Client) ListRepos(includePrivate bool) ([]Repository, error) {
+	repos, err := c.ListAllRepos(includePrivate)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update GitHub description: %s - %s", resp.Status, string(b))
+	var result []Repository
+	for _, repo := range repos {
+		if !repo.Fork && !repo.Archived && !repo.Disabled {
+			result = append(result, repo)
+		}
 	}
-	return nil
+	return result, nil
 }
 
-// Repository represents a GitHub repository
-type Repository struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Private     bool   `json:"private"`
-	Fork        bool   `json:"fork"`
-	Archived    bool   `json:"archived"`
-	Disabled    bool   `json:"disabled"`
-	Size        int    `json:"size"`
-}
-
-// ListPublicRepos lists all public repositories for the user/org
-func (c *Client) ListPublicRepos() ([]Repository, error) {
+// ListAllRepos lists all repositories owned by the user/org, including forks
+// and archived ones. Private repositories are only included if includePrivate
+// is set. For user accounts they are only visible through the authenticated
+// /user/repos endpoint, so the token must belong to that user.
+func (c *Client) ListAllRepos(includePrivate bool) ([]Repository, error) {
 	if c.token == "" {
 		return nil, fmt.Errorf("GitHub token required to list repositories")
 	}
 
+	isOrg, err := c.isOrganization()
+	if err != nil {
+		return nil, err
+	}
+	listURL := fmt.Sprintf("%s/users/%s/repos?type=owner", c.baseURL, c.org)
+	switch {
+	case isOrg:
+		listURL = fmt.Sprintf("%s/orgs/%s/repos?type=all", c.baseURL, c.org)
+	case includePrivate:
+		listURL = fmt.Sprintf("%s/user/repos?affiliation=owner&visibility=all", c.baseURL)
+	}
+
 	var allRepos []Repository
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("https://api.github.com/users/%s/repos?page=%d&per_page=%d&type=owner", c.org, page, perPage)
+		url := fmt.Sprintf("%s&page=%d&per_page=%d", listURL, page, perPage)
 		fmt.Printf("  Fetching page %d...\n", page)
 
-		repos, err := c.listPublicReposPage(url)
+		repos, err := c.listReposPage(url)
 		if err != nil {
 			return nil, err
 		}
 
-		// Filter for public, non-fork, non-archived
+		// Filter for repos owned by the user/org
 		for _, repo := range repos {
-			if !repo.Private && !repo.Fork && !repo.Archived && !repo.Disabled {
-				allRepos = append(allRepos, repo)
+			if repo.Private && !includePrivate {
+				continue
+			}
+			if repo.Owner.Login != "" && !strings.EqualFold(repo.Owner.Login, c.org) {
+				continue
 			}
+			allRepos = append(allRepos, repo)
 		}
 
 		// Check if there are more pages
@@ -312,7 +406,7 @@ func (c *Client) ListPublicRepos() ([]Repository, error) {
 	return allRepos, nil
 }
 
-func (c *Client) listPublicReposPage(url string) ([]Repository, error) {
+func (c *Client) listReposPage(url string) ([]Repository, error) {
 	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -341,15 +435,6 @@ func (c *Client) listPublicReposPage(url string) ([]Repository, error) {
 	return repos, nil
 }
 
-// GetRepoNames extracts repository names from a list of repos
-func GetRepoNames(repos []Repository) []string {
-	names := make([]string, len(repos))
-	for i, repo := range repos {
-		names[i] = repo.Name
-	}
-	return names
-}
-
 // DeleteRepo deletes a repository from GitHub
 func (c *Client) DeleteRepo(repoName string) error {
 	if c.token == "" {
@@ -366,7 +451,7 @@ func (c *Client) DeleteRepo(repoName string) error {
 		return fmt.Errorf("repository %s/%s does not exist", c.org, repoName)
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", c.org, repoName)
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
 
 	req, cancel, err := httpclient.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
@@ -400,3 +485,31 @@ func (c *Client) DeleteRepo(repoName string) error {
 	body, _ := io.ReadAll(resp.Body)
 	return fmt.Errorf("failed to delete repository: status %d: %s", resp.StatusCode, string(body))
 }
+
+// TestAuth verifies the token by fetching the authenticated user
+func (c *Client) TestAuth() error {
+	if c.token == "" {
+		return fmt.Errorf("GitHub token required")
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, c.baseURL+"/user", nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("authentication failed (%d): %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package github
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -109,3 +111,196 @@ func captureStdout(t *testing.T, fn func()) string {
 
 	return output
 }
+
+func TestNewEnterpriseClient_UsesConfiguredAPIURL(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/api/v3/user":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/api/v3/repos/team/tool" && r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/api/v3/repos/team/tool/releases":
+			_, _ = w.Write([]byte(`[{"tag_name": "v1.0.0"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewEnterpriseClient(server.URL+"/", "secret", "team")
+	if client.baseURL != server.URL+"/api/v3" {
+		t.Fatalf("baseURL = %q", client.baseURL)
+	}
+	if err := client.TestAuth(); err != nil {
+		t.Fatalf("TestAuth() error = %v", err)
+	}
+	if err := client.UpdateRepoDescription("tool", "A tool"); err != nil {
+		t.Fatalf("UpdateRepoDescription() error = %v", err)
+	}
+	tags, err := client.ListReleases("tool")
+	if err != nil || len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Fatalf("ListReleases() = %v, %v", tags, err)
+	}
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 requests against the enterprise server, got %v", paths)
+	}
+}
+
+func TestClient_DetectsOrganizationForListingAndCreation(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/api/v3/users/team":
+			_, _ = w.Write([]byte(`{"login": "team", "type": "Organization"}`))
+		case r.URL.Path == "/api/v3/orgs/team/repos" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"name": "tool"}, {"name": "secret", "private": true}]`))
+		case r.URL.Path == "/api/v3/orgs/team/repos" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"full_name": "team/new"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewEnterpriseClient(server.URL, "secret", "team")
+	captureStdout(t, func() {
+		repos, err := client.ListRepos(false)
+		if err != nil || len(repos) != 1 || repos[0].Name != "tool" {
+			t.Fatalf("ListRepos() = %#v, %v", repos, err)
+		}
+		if err := client.CreateRepo("new", "", false); err != nil {
+			t.Fatalf("CreateRepo() error = %v", err)
+		}
+	})
+
+	lookups := 0
+	for _, path := range paths {
+		if path == "GET /api/v3/users/team" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Fatalf("expected the account type to be looked up once, got %d in %v", lookups, paths)
+	}
+	if paths[len(paths)-1] != "POST /api/v3/orgs/team/repos" {
+		t.Fatalf("expected creation in the organization, got %v", paths)
+	}
+}
+
+func TestClient_ConfiguredUserAccountSkipsDetection(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewEnterpriseClient(server.URL, "secret", "me")
+	client.SetAccountType(AccountUser)
+	captureStdout(t, func() {
+		if _, err := client.ListRepos(false); err != nil {
+			t.Fatalf("ListRepos() error = %v", err)
+		}
+	})
+	if len(paths) != 1 || paths[0] != "/api/v3/users/me/repos" {
+		t.Fatalf("expected only the user listing, got %v", paths)
+	}
+}
+
+func TestClient_ListsPrivateUserReposViaAuthenticatedEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/user/repos" || r.URL.Query().Get("visibility") != "all" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"name": "tool", "owner": {"login": "me"}},
+			{"name": "secret", "private": true, "owner": {"login": "me"}},
+			{"name": "other", "private": true, "owner": {"login": "someone-else"}}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewEnterpriseClient(server.URL, "secret", "me")
+	client.SetAccountType(AccountUser)
+	captureStdout(t, func() {
+		repos, err := client.ListRepos(true)
+		if err != nil || len(repos) != 2 || !repos[1].Private {
+			t.Fatalf("ListRepos(true) = %#v, %v", repos, err)
+		}
+	})
+}
+
+func TestClient_ListAllReposIncludesForksAndArchived(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "tool", "owner": {"login": "me"}},
+			{"name": "upstream", "fork": true, "owner": {"login": "me"}},
+			{"name": "old", "archived": true, "owner": {"login": "me"}}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewEnterpriseClient(server.URL, "secret", "me")
+	client.SetAccountType(AccountUser)
+	captureStdout(t, func() {
+		all, err := client.ListAllRepos(false)
+		if err != nil || len(all) != 3 {
+			t.Fatalf("ListAllRepos() = %#v, %v", all, err)
+		}
+		repos, err := client.ListRepos(false)
+		if err != nil || len(repos) != 1 || repos[0].Name != "tool" {
+			t.Fatalf("ListRepos() = %#v, %v", repos, err)
+		}
+	})
+}
+
+func TestClient_SetBranchProtectionKeepsUnmirroredOptions(t *testing.T) {
+	var put map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/me/tool/branches/main":
+			_, _ = w.Write([]byte(`{"name": "main", "protected": true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/me/tool/branches/main/protection":
+			_, _ = w.Write([]byte(`{
+				"required_status_checks": {"strict": true, "contexts": ["ci"], "checks": [{"context": "ci", "app_id": 15368}]},
+				"enforce_admins": {"enabled": true},
+				"required_pull_request_reviews": {"required_approving_review_count": 1, "dismiss_stale_reviews": true},
+				"restrictions": {"users": [{"login": "bot"}], "teams": [], "apps": []},
+				"required_linear_history": {"enabled": true}
+			}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v3/repos/me/tool/branches/main/protection":
+			_ = json.NewDecoder(r.Body).Decode(&put)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewEnterpriseClient(server.URL, "secret", "me")
+	client.SetAccountType(AccountUser)
+	if err := client.SetBranchProtection("tool", BranchProtection{Branch: "main", RequiredReviews: 2, AllowForcePushes: true}); err != nil {
+		t.Fatalf("SetBranchProtection() error = %v", err)
+	}
+
+	checks, _ := put["required_status_checks"].(map[string]any)
+	if checks == nil || checks["strict"] != true || len(checks["checks"].([]any)) != 1 {
+		t.Fatalf("expected the status checks to be kept, got %v", put["required_status_checks"])
+	}
+	restrictions, _ := put["restrictions"].(map[string]any)
+	if restrictions == nil || len(restrictions["users"].([]any)) != 1 {
+		t.Fatalf("expected the push restrictions to be kept, got %v", put["restrictions"])
+	}
+	reviews, _ := put["required_pull_request_reviews"].(map[string]any)
+	if reviews == nil || reviews["required_approving_review_count"] != float64(2) || reviews["dismiss_stale_reviews"] != true {
+		t.Fatalf("unexpected reviews %v", put["required_pull_request_reviews"])
+	}
+	if put["enforce_admins"] != true || put["required_linear_history"] != true || put["allow_force_pushes"] != true {
+		t.Fatalf("unexpected protection %v", put)
+	}
+}
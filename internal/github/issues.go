@@ -0,0 +1,90 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Issue is a GitHub issue. Pull requests are listed as issues too and carry
+// a pull_request object.
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	PullRequest *json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// IssueComment is a comment on a GitHub issue
+type IssueComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// ListIssues returns all open and closed issues of a repository, oldest
+// first, without pull requests
+func (c *Client) ListIssues(repoName string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/issues?state=all&sort=created&direction=asc&per_page=100&page=%d", c.baseURL, c.org, repoName, page)
+		var batch []Issue
+		if err := c.jsonRequest(http.MethodGet, url, "issues", nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, issue := range batch {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+		if len(batch) < 100 {
+			return issues, nil
+		}
+	}
+}
+
+// ListIssueComments returns the comments of an issue, oldest first
+func (c *Client) ListIssueComments(repoName string, number int) ([]IssueComment, error) {
+	var comments []IssueComment
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments?per_page=100&page=%d", c.baseURL, c.org, repoName, number, page)
+		var batch []IssueComment
+		if err := c.jsonRequest(http.MethodGet, url, "issue comments", nil, &batch); err != nil {
+			return nil, err
+		}
+		comments = append(comments, batch...)
+		if len(batch) < 100 {
+			return comments, nil
+		}
+	}
+}
+
+// CreateIssue opens an issue
+func (c *Client) CreateIssue(repoName, title, body string) (Issue, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues", c.baseURL, c.org, repoName)
+	var issue Issue
+	err := c.jsonRequest(http.MethodPost, url, "issue", map[string]string{"title": title, "body": body}, &issue)
+	return issue, err
+}
+
+// CreateIssueComment comments on an issue
+func (c *Client) CreateIssueComment(repoName string, number int, body string) (IssueComment, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.baseURL, c.org, repoName, number)
+	var comment IssueComment
+	err := c.jsonRequest(http.MethodPost, url, "issue comment", map[string]string{"body": body}, &comment)
+	return comment, err
+}
+
+// CloseIssue closes an issue
+func (c *Client) CloseIssue(repoName string, number int) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.baseURL, c.org, repoName, number)
+	return c.jsonRequest(http.MethodPatch, url, "issue", map[string]string{"state": "closed"}, nil)
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// BranchProtection is the part of a branch protection rule that gitsyncer mirrors
+type BranchProtection struct {
+	Branch           string
+	RequiredReviews  int
+	AllowForcePushes bool
+	AllowDeletions   bool
+	// Other names enabled options that are not mirrored, e.g. status checks
+	Other []string
+}
+
+type enabledFlag struct {
+	Enabled bool `json:"enabled"`
+}
+
+// protectionResponse is the subset of GET /branches/{branch}/protection we read
+type protectionResponse struct {
+	RequiredPullRequestReviews *struct {
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	} `json:"required_pull_request_reviews"`
+	RequiredStatusChecks           *json.RawMessage `json:"required_status_checks"`
+	Restrictions                   *json.RawMessage `json:"restrictions"`
+	EnforceAdmins                  enabledFlag      `json:"enforce_admins"`
+	RequiredLinearHistory          enabledFlag      `json:"required_linear_history"`
+	RequiredSignatures             enabledFlag      `json:"required_signatures"`
+	RequiredConversationResolution enabledFlag      `json:"required_conversation_resolution"`
+	AllowForcePushes               enabledFlag      `json:"allow_force_pushes"`
+	AllowDeletions                 enabledFlag      `json:"allow_deletions"`
+	BlockCreations                 enabledFlag      `json:"block_creations"`
+	LockBranch                     enabledFlag      `json:"lock_branch"`
+	AllowForkSyncing               enabledFlag      `json:"allow_fork_syncing"`
+}
+
+// statusChecksResponse is required_status_checks as returned by GET
+type statusChecksResponse struct {
+	Strict bool `json:"strict"`
+	Checks []struct {
+		Context string `json:"context"`
+		AppID   *int   `json:"app_id"`
+	} `json:"checks"`
+}
+
+// restrictionsResponse is restrictions as returned by GET
+type restrictionsResponse struct {
+	Users []struct {
+		Login string `json:"login"`
+	} `json:"users"`
+	Teams []struct {
+		Slug string `json:"slug"`
+	} `json:"teams"`
+	Apps []struct {
+		Slug string `json:"slug"`
+	} `json:"apps"`
+}
+
+func (p protectionResponse) toBranchProtection(branch string) BranchProtection {
+	rule := BranchProtection{
+		Branch:           branch,
+		AllowForcePushes: p.AllowForcePushes.Enabled,
+		AllowDeletions:   p.AllowDeletions.Enabled,
+	}
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		rule.RequiredReviews = reviews.RequiredApprovingReviewCount
+		if reviews.DismissStaleReviews {
+			rule.Other = append(rule.Other, "dismiss stale reviews")
+		}
+		if reviews.RequireCodeOwnerReviews {
+			rule.Other = append(rule.Other, "code owner reviews")
+		}
+	}
+	if p.RequiredStatusChecks != nil && string(*p.RequiredStatusChecks) != "null" {
+		rule.Other = append(rule.Other, "required status checks")
+	}
+	if p.Restrictions != nil && string(*p.Restrictions) != "null" {
+		rule.Other = append(rule.Other, "push restrictions")
+	}
+	flags := []struct {
+		name string
+		flag enabledFlag
+	}{
+		{"enforce admins", p.EnforceAdmins},
+		{"linear history", p.RequiredLinearHistory},
+		{"signed commits", p.RequiredSignatures},
+		{"conversation resolution", p.RequiredConversationResolution},
+	}
+	for _, f := range flags {
+		if f.flag.Enabled {
+			rule.Other = append(rule.Other, f.name)
+		}
+	}
+	return rule
+}
+
+// ListBranchProtections returns the protection rules of all protected branches
+func (c *Client) ListBranchProtections(repoName string) ([]BranchProtection, error) {
+	listURL := fmt.Sprintf("%s/repos/%s/%s/branches?protected=true&per_page=100", c.baseURL, c.org, repoName)
+	var branches []struct {
+		Name string `json:"name"`
+	}
+	if err := c.jsonRequest(http.MethodGet, listURL, "branch protection", nil, &branches); err != nil {
+		return nil, err
+	}
+
+	rules := make([]BranchProtection, 0, len(branches))
+	for _, branch := range branches {
+		var protection protectionResponse
+		if err := c.jsonRequest(http.MethodGet, c.protectionURL(repoName, branch.Name), "branch protection", nil, &protection); err != nil {
+			return nil, err
+		}
+		rules = append(rules, protection.toBranchProtection(branch.Name))
+	}
+	return rules, nil
+}
+
+// SetBranchProtection protects a branch with the mirrored options of a rule.
+// GitHub replaces the whole protection on update, so the options gitsyncer
+// does not mirror, such as status checks, are read first and sent back
+// unchanged.
+func (c *Client) SetBranchProtection(repoName string, rule BranchProtection) error {
+	var current protectionResponse
+	var branch struct {
+		Protected bool `json:"protected"`
+	}
+	branchURL := fmt.Sprintf("%s/repos/%s/%s/branches/%s", c.baseURL, c.org, repoName, url.PathEscape(rule.Branch))
+	if err := c.jsonRequest(http.MethodGet, branchURL, "branch protection", nil, &branch); err != nil {
+		return err
+	}
+	if branch.Protected {
+		if err := c.jsonRequest(http.MethodGet, c.protectionURL(repoName, rule.Branch), "branch protection", nil, &current); err != nil {
+			return err
+		}
+	}
+
+	payload, err := current.updatePayload(rule)
+	if err != nil {
+		return err
+	}
+	return c.jsonRequest(http.MethodPut, c.protectionURL(repoName, rule.Branch), "branch protection", payload, nil)
+}
+
+// updatePayload builds the PUT request that applies rule on top of the
+// current protection, keeping every option that is not mirrored
+func (p protectionResponse) updatePayload(rule BranchProtection) (map[string]interface{}, error) {
+	var reviews interface{}
+	if rule.RequiredReviews > 0 {
+		r := map[string]interface{}{"required_approving_review_count": rule.RequiredReviews}
+		if current := p.RequiredPullRequestReviews; current != nil {
+			r["dismiss_stale_reviews"] = current.DismissStaleReviews
+			r["require_code_owner_reviews"] = current.RequireCodeOwnerReviews
+		}
+		reviews = r
+	}
+
+	var statusChecks interface{}
+	if p.RequiredStatusChecks != nil && string(*p.RequiredStatusChecks) != "null" {
+		var current statusChecksResponse
+		if err := json.Unmarshal(*p.RequiredStatusChecks, &current); err != nil {
+			return nil, fmt.Errorf("failed to decode required status checks: %w", err)
+		}
+		checks := make([]map[string]interface{}, 0, len(current.Checks))
+		for _, check := range current.Checks {
+			entry := map[string]interface{}{"context": check.Context}
+			if check.AppID != nil {
+				entry["app_id"] = *check.AppID
+			}
+			checks = append(checks, entry)
+		}
+		statusChecks = map[string]interface{}{"strict": current.Strict, "checks": checks}
+	}
+
+	var restrictions interface{}
+	if p.Restrictions != nil && string(*p.Restrictions) != "null" {
+		var current restrictionsResponse
+		if err := json.Unmarshal(*p.Restrictions, &current); err != nil {
+			return nil, fmt.Errorf("failed to decode push restrictions: %w", err)
+		}
+		users, teams, apps := []string{}, []string{}, []string{}
+		for _, user := range current.Users {
+			users = append(users, user.Login)
+		}
+		for _, team := range current.Teams {
+			teams = append(teams, team.Slug)
+		}
+		for _, app := range current.Apps {
+			apps = append(apps, app.Slug)
+		}
+		restrictions = map[string]interface{}{"users": users, "teams": teams, "apps": apps}
+	}
+
+	return map[string]interface{}{
+		"required_status_checks":           statusChecks,
+		"enforce_admins":                   p.EnforceAdmins.Enabled,
+		"required_pull_request_reviews":    reviews,
+		"restrictions":                     restrictions,
+		"required_linear_history":          p.RequiredLinearHistory.Enabled,
+		"required_conversation_resolution": p.RequiredConversationResolution.Enabled,
+		"block_creations":                  p.BlockCreations.Enabled,
+		"lock_branch":                      p.LockBranch.Enabled,
+		"allow_fork_syncing":               p.AllowForkSyncing.Enabled,
+		"allow_force_pushes":               rule.AllowForcePushes,
+		"allow_deletions":                  rule.AllowDeletions,
+	}, nil
+}
+
+func (c *Client) protectionURL(repoName, branch string) string {
+	return fmt.Sprintf("%s/repos/%s/%s/branches/%s/protection", c.baseURL, c.org, repoName, url.PathEscape(branch))
+}
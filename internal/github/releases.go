@@ -0,0 +1,173 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// Release represents a GitHub release
+type Release struct {
+	ID      int64  `json:"id,omitempty"`
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+}
+
+// ListReleases returns the tag names of all releases of a repository
+func (c *Client) ListReleases(repoName string) ([]string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", c.baseURL, c.org, repoName)
+
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	// Add GitHub token if available
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		// Repository might not exist on GitHub
+		return []string{}, nil
+	}
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(body))
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+
+	return tags, nil
+}
+
+// CreateRelease creates a release for an existing tag
+func (c *Client) CreateRelease(repoName, tag, releaseNotes string) error {
+	if c.token == "" {
+		return fmt.Errorf("GitHub token is required for creating releases")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", c.baseURL, c.org, repoName)
+
+	// Use provided release notes or default
+	body := releaseNotes
+	if body == "" {
+		body = fmt.Sprintf("Release %s", tag)
+	}
+
+	jsonData, err := json.Marshal(Release{TagName: tag, Name: tag, Body: body})
+	if err != nil {
+		return err
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 201 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to create GitHub release: %s - %s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// UpdateRelease replaces the notes of the release for a tag
+func (c *Client) UpdateRelease(repoName, tag, releaseNotes string) error {
+	if c.token == "" {
+		return fmt.Errorf("GitHub token is required for updating releases")
+	}
+
+	// First, get the release ID
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", c.baseURL, c.org, repoName, tag)
+
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get release: %s - %s", resp.Status, string(body))
+	}
+
+	var releaseInfo Release
+	if err := json.NewDecoder(resp.Body).Decode(&releaseInfo); err != nil {
+		return err
+	}
+
+	// Now update the release
+	updateURL := fmt.Sprintf("%s/repos/%s/%s/releases/%d", c.baseURL, c.org, repoName, releaseInfo.ID)
+
+	jsonData, err := json.Marshal(Release{TagName: tag, Name: tag, Body: releaseNotes})
+	if err != nil {
+		return err
+	}
+
+	updateReq, updateCancel, err := httpclient.NewRequest(http.MethodPatch, updateURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	defer updateCancel()
+
+	updateReq.Header.Set("Authorization", "Bearer "+c.token)
+	updateReq.Header.Set("Content-Type", "application/json")
+	updateReq.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	updateResp, err := httpclient.Do(updateReq)
+	if err != nil {
+		return err
+	}
+	defer updateResp.Body.Close()
+
+	if updateResp.StatusCode != 200 {
+		body, _ := io.ReadAll(updateResp.Body)
+		return fmt.Errorf("failed to update GitHub release: %s - %s", updateResp.Status, string(body))
+	}
+
+	return nil
+}
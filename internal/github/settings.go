@@ -0,0 +1,124 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// editRepo applies a partial update to a repository. what names the updated
+// settings in error messages.
+func (c *Client) editRepo(repoName string, payload map[string]interface{}, what string) error {
+	if c.token == "" {
+		return fmt.Errorf("GitHub token required to update repository")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, c.org, repoName)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodPatch, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update GitHub %s: %s - %s", what, resp.Status, string(b))
+	}
+	return nil
+}
+
+// UpdateRepoSettings applies repository settings such as homepage,
+// default_branch, has_issues, has_wiki or archived
+func (c *Client) UpdateRepoSettings(repoName string, settings map[string]interface{}) error {
+	return c.editRepo(repoName, settings, "settings")
+}
+
+// ListTopics returns the topics of a repository
+func (c *Client) ListTopics(repoName string) ([]string, error) {
+	if c.token == "" {
+		return nil, fmt.Errorf("GitHub token required")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/topics", c.baseURL, c.org, repoName)
+	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list GitHub topics: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Names []string `json:"names"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode topics: %w", err)
+	}
+	return result.Names, nil
+}
+
+// ReplaceTopics replaces all topics of a repository
+func (c *Client) ReplaceTopics(repoName string, topics []string) error {
+	if c.token == "" {
+		return fmt.Errorf("GitHub token required to update repository")
+	}
+	if topics == nil {
+		topics = []string{}
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/topics", c.baseURL, c.org, repoName)
+	body, err := json.Marshal(map[string]interface{}{"names": topics})
+	if err != nil {
+		return err
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update GitHub topics: %s - %s", resp.Status, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,416 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// DefaultAPIURL is the REST v4 endpoint of gitlab.com
+const DefaultAPIURL = "https://gitlab.com/api/v4"
+
+// Project represents a GitLab project
+type Project struct {
+	ID                int64    `json:"id"`
+	Name              string   `json:"name"`
+	Path              string   `json:"path"`
+	PathWithNamespace string   `json:"path_with_namespace"`
+	Description       string   `json:"description"`
+	Visibility        string   `json:"visibility"`
+	Archived          bool     `json:"archived"`
+	EmptyRepo         bool     `json:"empty_repo"`
+	Topics            []string `json:"topics"`
+	DefaultBranch     string   `json:"default_branch"`
+	IssuesEnabled     bool     `json:"issues_enabled"`
+	WikiEnabled       bool     `json:"wiki_enabled"`
+	ForkedFromProject *struct {
+		ID int64 `json:"id"`
+	} `json:"forked_from_project,omitempty"`
+}
+
+// Private reports whether the project is not publicly visible
+func (p Project) Private() bool {
+	return p.Visibility != "" && p.Visibility != "public"
+}
+
+// Fork reports whether the project is a fork of another project
+func (p Project) Fork() bool {
+	return p.ForkedFromProject != nil
+}
+
+// Client handles GitLab REST v4 API operations for one user or group namespace
+type Client struct {
+	baseURL   string
+	namespace string
+	token     string
+}
+
+// NewClient creates a new GitLab API client. apiURL may be empty for
+// gitlab.com, or the root or /api/v4 endpoint of a self-managed instance.
+func NewClient(apiURL, namespace, token string) Client {
+	baseURL := strings.TrimRight(strings.TrimSpace(apiURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	} else if !strings.HasSuffix(baseURL, "/api/v4") {
+		baseURL += "/api/v4"
+	}
+	return Client{
+		baseURL:   baseURL,
+		namespace: strings.Trim(namespace, "/"),
+		token:     loadToken(token),
+	}
+}
+
+// loadToken loads the GitLab API token from config, env, or file
+func loadToken(token string) string {
+	if token != "" {
+		return strings.TrimSpace(token)
+	}
+
+	if envToken := os.Getenv("GITLAB_TOKEN"); envToken != "" {
+		return strings.TrimSpace(envToken)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".gitsyncer_gitlab_token"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// HasToken returns true if a token is loaded
+func (c *Client) HasToken() bool {
+	return c.token != ""
+}
+
+// projectURL returns the API URL of a project in the namespace
+func (c *Client) projectURL(repoName string) string {
+	return fmt.Sprintf("%s/projects/%s", c.baseURL, url.PathEscape(c.namespace+"/"+repoName))
+}
+
+// request sends an API request with an optional JSON payload and returns the
+// status code and the response body
+func (c *Client) request(method, endpoint string, payload any) (int, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, err
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	req, cancel, err := httpclient.NewRequest(method, endpoint, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cancel()
+
+	if c.HasToken() {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// GetRepo fetches a project by name
+func (c *Client) GetRepo(repoName string) (Project, bool, error) {
+	var project Project
+	status, body, err := c.request(http.MethodGet, c.projectURL(repoName), nil)
+	if err != nil {
+		return project, false, err
+	}
+	if status == http.StatusNotFound {
+		return project, false, nil
+	}
+	if status != http.StatusOK {
+		return project, false, fmt.Errorf("failed to get project: status %d: %s", status, string(body))
+	}
+
+	if err := json.Unmarshal(body, &project); err != nil {
+		return project, false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return project, true, nil
+}
+
+// RepoExists checks if a project exists in the namespace
+func (c *Client) RepoExists(repoName string) (bool, error) {
+	_, exists, err := c.GetRepo(repoName)
+	return exists, err
+}
+
+// ListRepos lists all non-fork, non-archived, non-empty projects of the
+// namespace. Groups are tried first, then user accounts. Private and internal
+// projects are only included if includePrivate is set, which requires a token
+// with read access to them.
+func (c *Client) ListRepos(includePrivate bool) ([]Project, error) {
+	projects, err := c.ListAllRepos(includePrivate)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Project
+	for _, project := range projects {
+		if !project.Fork() && !project.Archived && !project.EmptyRepo {
+			result = append(result, project)
+		}
+	}
+	return result, nil
+}
+
+// ListAllRepos is ListRepos including forks, archived and empty projects
+func (c *Client) ListAllRepos(includePrivate bool) ([]Project, error) {
+	escaped := url.PathEscape(c.namespace)
+	projects, err := c.listProjects(fmt.Sprintf("%s/groups/%s/projects", c.baseURL, escaped), includePrivate)
+	if errors.Is(err, errNotFound) {
+		projects, err = c.listProjects(fmt.Sprintf("%s/users/%s/projects", c.baseURL, escaped), includePrivate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Project
+	for _, project := range projects {
+		if includePrivate || !project.Private() {
+			result = append(result, project)
+		}
+	}
+	return result, nil
+}
+
+var errNotFound = errors.New("namespace not found")
+
+func (c *Client) listProjects(listURL string, includePrivate bool) ([]Project, error) {
+	var all []Project
+	perPage := 100
+	if !includePrivate {
+		listURL += "?visibility=public&"
+	} else {
+		listURL += "?"
+	}
+
+	for page := 1; ; page++ {
+		pageURL := fmt.Sprintf("%sper_page=%d&page=%d", listURL, perPage, page)
+		status, body, err := c.request(http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch projects: %w", err)
+		}
+		if status == http.StatusNotFound {
+			return nil, errNotFound
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("failed to list projects: status %d: %s", status, string(body))
+		}
+
+		var projects []Project
+		if err := json.Unmarshal(body, &projects); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		all = append(all, projects...)
+
+		// If we got fewer projects than requested, we've reached the end
+		if len(projects) < perPage {
+			return all, nil
+		}
+	}
+}
+
+// namespaceID resolves the numeric ID of the user or group namespace
+func (c *Client) namespaceID() (int64, error) {
+	status, body, err := c.request(http.MethodGet, fmt.Sprintf("%s/namespaces/%s", c.baseURL, url.PathEscape(c.namespace)), nil)
+	if err != nil {
+		return 0, err
+	}
+	if status != http.StatusOK {
+		return 0, fmt.Errorf("failed to look up namespace %s: status %d: %s", c.namespace, status, string(body))
+	}
+
+	var namespace struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(body, &namespace); err != nil {
+		return 0, fmt.Errorf("failed to parse namespace: %w", err)
+	}
+	return namespace.ID, nil
+}
+
+// CreateRepo creates a new project in the user or group namespace
+func (c *Client) CreateRepo(repoName, description string, private bool) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to create repository")
+	}
+
+	exists, err := c.RepoExists(repoName)
+	if err != nil {
+		return fmt.Errorf("failed to check if repo exists: %w", err)
+	}
+	if exists {
+		return nil // Project already exists
+	}
+
+	namespaceID, err := c.namespaceID()
+	if err != nil {
+		return err
+	}
+
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+	payload := map[string]any{
+		"name":         repoName,
+		"path":         repoName,
+		"description":  description,
+		"visibility":   visibility,
+		"namespace_id": namespaceID,
+	}
+
+	status, body, err := c.request(http.MethodPost, c.baseURL+"/projects", payload)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusCreated {
+		return fmt.Errorf("failed to create repository: status %d: %s", status, string(body))
+	}
+	return nil
+}
+
+// updateProject applies a partial update to a project
+func (c *Client) updateProject(repoName string, payload map[string]any) error {
+	status, body, err := c.request(http.MethodPut, c.projectURL(repoName), payload)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to update GitLab project: status %d: %s", status, string(body))
+	}
+	return nil
+}
+
+// UpdateRepoDescription updates a project description
+func (c *Client) UpdateRepoDescription(repoName, description string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to update repository")
+	}
+	return c.updateProject(repoName, map[string]any{"description": description})
+}
+
+// RenameRepo renames a project and its path, which is the name used in URLs
+func (c *Client) RenameRepo(oldName, newName string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to update repository")
+	}
+	return c.updateProject(oldName, map[string]any{"name": newName, "path": newName})
+}
+
+// UpdateTopics replaces the topics of a project
+func (c *Client) UpdateTopics(repoName string, topics []string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to update repository")
+	}
+	if topics == nil {
+		topics = []string{}
+	}
+	return c.updateProject(repoName, map[string]any{"topics": topics})
+}
+
+// UpdateProjectSettings applies project settings such as default_branch,
+// issues_enabled or wiki_enabled
+func (c *Client) UpdateProjectSettings(repoName string, settings map[string]any) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to update repository")
+	}
+	return c.updateProject(repoName, settings)
+}
+
+// SetArchived archives or unarchives a project
+func (c *Client) SetArchived(repoName string, archived bool) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to update repository")
+	}
+
+	action := "unarchive"
+	if archived {
+		action = "archive"
+	}
+	status, body, err := c.request(http.MethodPost, c.projectURL(repoName)+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusCreated && status != http.StatusOK {
+		return fmt.Errorf("failed to %s GitLab project: status %d: %s", action, status, string(body))
+	}
+	return nil
+}
+
+// DeleteRepo deletes a project. GitLab may schedule the deletion rather than
+// performing it immediately.
+func (c *Client) DeleteRepo(repoName string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to delete repository")
+	}
+
+	exists, err := c.RepoExists(repoName)
+	if err != nil {
+		return fmt.Errorf("failed to check if repo exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("repository %s/%s does not exist", c.namespace, repoName)
+	}
+
+	status, body, err := c.request(http.MethodDelete, c.projectURL(repoName), nil)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	case http.StatusForbidden:
+		return fmt.Errorf("permission denied (403): %s", string(body))
+	case http.StatusUnauthorized:
+		return fmt.Errorf("authentication failed (401): %s", string(body))
+	}
+	return fmt.Errorf("failed to delete repository: status %d: %s", status, string(body))
+}
+
+// TestAuth verifies the token by fetching the authenticated user
+func (c *Client) TestAuth() error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required")
+	}
+
+	status, body, err := c.request(http.MethodGet, c.baseURL+"/user", nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("authentication failed (%d): %s", status, string(body))
+	}
+	return nil
+}
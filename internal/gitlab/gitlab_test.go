@@ -0,0 +1,162 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGitLab is a minimal stand-in for the GitLab REST v4 API with a single
+// user namespace "me"
+type fakeGitLab struct {
+	mu       sync.Mutex
+	projects map[string]map[string]any
+	releases map[string][]Release
+	deleted  []string
+}
+
+func newFakeGitLab(t *testing.T) (*fakeGitLab, *httptest.Server) {
+	t.Helper()
+	f := &fakeGitLab{projects: map[string]map[string]any{}, releases: map[string][]Release{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON := func(status int, v any) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4")
+
+	switch {
+	case path == "/user":
+		writeJSON(http.StatusOK, map[string]any{"username": "me"})
+	case path == "/users/me/projects":
+		var list []map[string]any
+		for _, project := range f.projects {
+			list = append(list, project)
+		}
+		writeJSON(http.StatusOK, list)
+	case path == "/namespaces/me":
+		writeJSON(http.StatusOK, map[string]any{"id": 42, "kind": "user"})
+	case path == "/projects" && r.Method == http.MethodPost:
+		var project map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&project)
+		if project["namespace_id"] != float64(42) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.projects[project["path"].(string)] = project
+		writeJSON(http.StatusCreated, project)
+	case strings.HasPrefix(path, "/projects/me%2F"):
+		rest := strings.Split(strings.TrimPrefix(path, "/projects/me%2F"), "/")
+		f.serveProject(w, r, rest[0], rest[1:], writeJSON)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitLab) serveProject(w http.ResponseWriter, r *http.Request, name string, rest []string, writeJSON func(int, any)) {
+	project, ok := f.projects[name]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, project)
+	case len(rest) == 0 && r.Method == http.MethodPut:
+		var update map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		for k, v := range update {
+			project[k] = v
+		}
+		writeJSON(http.StatusOK, project)
+	case len(rest) == 0 && r.Method == http.MethodDelete:
+		delete(f.projects, name)
+		f.deleted = append(f.deleted, name)
+		w.WriteHeader(http.StatusAccepted)
+	case len(rest) == 1 && rest[0] == "releases" && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, f.releases[name])
+	case len(rest) == 1 && rest[0] == "releases" && r.Method == http.MethodPost:
+		var release Release
+		_ = json.NewDecoder(r.Body).Decode(&release)
+		f.releases[name] = append(f.releases[name], release)
+		writeJSON(http.StatusCreated, release)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestNewClient_DefaultsToGitLabCom(t *testing.T) {
+	if got := NewClient("", "me", "x").baseURL; got != DefaultAPIURL {
+		t.Fatalf("baseURL = %q, want %q", got, DefaultAPIURL)
+	}
+	if got := NewClient("https://gitlab.example.com/", "me", "x").baseURL; got != "https://gitlab.example.com/api/v4" {
+		t.Fatalf("baseURL = %q", got)
+	}
+}
+
+func TestClient_ProjectLifecycle(t *testing.T) {
+	fake, server := newFakeGitLab(t)
+	client := NewClient(server.URL, "me", "secret")
+
+	if err := client.TestAuth(); err != nil {
+		t.Fatalf("TestAuth() error = %v", err)
+	}
+	if err := client.CreateRepo("tool", "A tool", false); err != nil {
+		t.Fatalf("CreateRepo() error = %v", err)
+	}
+	if err := client.UpdateRepoDescription("tool", "A better tool"); err != nil {
+		t.Fatalf("UpdateRepoDescription() error = %v", err)
+	}
+	if err := client.UpdateTopics("tool", []string{"go", "cli"}); err != nil {
+		t.Fatalf("UpdateTopics() error = %v", err)
+	}
+
+	project, exists, err := client.GetRepo("tool")
+	if err != nil || !exists {
+		t.Fatalf("GetRepo() = %v, %v", exists, err)
+	}
+	if project.Description != "A better tool" || project.Visibility != "public" || len(project.Topics) != 2 {
+		t.Fatalf("unexpected project %#v", project)
+	}
+
+	// The group lookup 404s, so listing falls back to the user namespace
+	projects, err := client.ListRepos(false)
+	if err != nil || len(projects) != 1 || projects[0].Path != "tool" {
+		t.Fatalf("ListRepos() = %#v, %v", projects, err)
+	}
+
+	if err := client.CreateRelease("tool", "v1.0.0", ""); err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+	tags, err := client.ListReleases("tool")
+	if err != nil || len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Fatalf("ListReleases() = %v, %v", tags, err)
+	}
+	if got := fake.releases["tool"][0].Description; got != "Release v1.0.0" {
+		t.Fatalf("release description = %q", got)
+	}
+
+	if err := client.DeleteRepo("tool"); err != nil {
+		t.Fatalf("DeleteRepo() error = %v", err)
+	}
+	if len(fake.deleted) != 1 {
+		t.Fatalf("expected the project to be deleted, got %v", fake.deleted)
+	}
+}
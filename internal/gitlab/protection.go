@@ -0,0 +1,70 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ProtectedBranch is a GitLab protected branch. Protected branches cannot be
+// deleted, and required approvals are not part of the rule on GitLab.
+type ProtectedBranch struct {
+	Name                      string `json:"name"`
+	AllowForcePush            bool   `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool   `json:"code_owner_approval_required"`
+}
+
+// ListProtectedBranches returns the protected branches of a project
+func (c *Client) ListProtectedBranches(repoName string) ([]ProtectedBranch, error) {
+	status, body, err := c.request(http.MethodGet, c.projectURL(repoName)+"/protected_branches?per_page=100", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to list GitLab protected branches: status %d: %s", status, string(body))
+	}
+
+	var branches []ProtectedBranch
+	if err := json.Unmarshal(body, &branches); err != nil {
+		return nil, fmt.Errorf("failed to parse protected branches: %w", err)
+	}
+	return branches, nil
+}
+
+// ProtectBranch protects a branch, or updates the force push setting of an
+// already protected one. Maintainers keep push access so that gitsyncer can
+// keep mirroring.
+func (c *Client) ProtectBranch(repoName, branch string, allowForcePush bool) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token required to protect branches")
+	}
+
+	payload := map[string]any{
+		"name":               branch,
+		"allow_force_push":   allowForcePush,
+		"push_access_level":  40,
+		"merge_access_level": 40,
+	}
+	status, body, err := c.request(http.MethodPost, c.projectURL(repoName)+"/protected_branches", payload)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusCreated {
+		return nil
+	}
+	if status != http.StatusConflict {
+		return fmt.Errorf("failed to protect GitLab branch %s: status %d: %s", branch, status, string(body))
+	}
+
+	// Already protected: update the existing rule
+	endpoint := c.projectURL(repoName) + "/protected_branches/" + url.PathEscape(branch)
+	status, body, err = c.request(http.MethodPatch, endpoint, map[string]any{"allow_force_push": allowForcePush})
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to update GitLab protected branch %s: status %d: %s", branch, status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Release represents a GitLab release
+type Release struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListReleases returns the tag names of all releases of a project
+func (c *Client) ListReleases(repoName string) ([]string, error) {
+	var tags []string
+	perPage := 100
+
+	for page := 1; ; page++ {
+		pageURL := fmt.Sprintf("%s/releases?per_page=%d&page=%d", c.projectURL(repoName), perPage, page)
+		status, body, err := c.request(http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusNotFound {
+			// Project might not exist on GitLab
+			return []string{}, nil
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("GitLab API error: status %d - %s", status, string(body))
+		}
+
+		var releases []Release
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			tags = append(tags, release.TagName)
+		}
+
+		if len(releases) < perPage {
+			return tags, nil
+		}
+	}
+}
+
+// CreateRelease creates a release for an existing tag
+func (c *Client) CreateRelease(repoName, tag, releaseNotes string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token is required for creating releases")
+	}
+
+	// Use provided release notes or default
+	description := releaseNotes
+	if description == "" {
+		description = fmt.Sprintf("Release %s", tag)
+	}
+
+	status, body, err := c.request(http.MethodPost, c.projectURL(repoName)+"/releases", Release{
+		TagName:     tag,
+		Name:        tag,
+		Description: description,
+	})
+	if err != nil {
+		return err
+	}
+	if status != http.StatusCreated {
+		return fmt.Errorf("failed to create GitLab release: status %d - %s", status, string(body))
+	}
+	return nil
+}
+
+// UpdateRelease replaces the notes of the release for a tag
+func (c *Client) UpdateRelease(repoName, tag, releaseNotes string) error {
+	if !c.HasToken() {
+		return fmt.Errorf("GitLab token is required for updating releases")
+	}
+
+	releaseURL := fmt.Sprintf("%s/releases/%s", c.projectURL(repoName), url.PathEscape(tag))
+	status, body, err := c.request(http.MethodPut, releaseURL, map[string]any{"description": releaseNotes})
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to update GitLab release: status %d - %s", status, string(body))
+	}
+	return nil
+}
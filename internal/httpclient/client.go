@@ -9,16 +9,37 @@ import (
 
 const DefaultTimeout = 30 * time.Second
 
+// TransferTimeout bounds large uploads and downloads such as backup bundles.
+const TransferTimeout = 30 * time.Minute
+
 var defaultClient = &http.Client{
 	Timeout: DefaultTimeout,
 }
 
+var transferClient = &http.Client{
+	Timeout: TransferTimeout,
+}
+
 func Do(req *http.Request) (*http.Response, error) {
 	return defaultClient.Do(req)
 }
 
+// DoTransfer sends a request created with NewTransferRequest.
+func DoTransfer(req *http.Request) (*http.Response, error) {
+	return transferClient.Do(req)
+}
+
 func NewRequest(method, url string, body io.Reader) (*http.Request, context.CancelFunc, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	return newRequest(method, url, body, DefaultTimeout)
+}
+
+// NewTransferRequest creates a request with the longer TransferTimeout deadline.
+func NewTransferRequest(method, url string, body io.Reader) (*http.Request, context.CancelFunc, error) {
+	return newRequest(method, url, body, TransferTimeout)
+}
+
+func newRequest(method, url string, body io.Reader, timeout time.Duration) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
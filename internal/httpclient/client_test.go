@@ -42,3 +42,22 @@ func TestDo_UsesSharedTimeout(t *testing.T) {
 		t.Fatalf("expected shared timeout %v, got %v", DefaultTimeout, defaultClient.Timeout)
 	}
 }
+
+func TestNewTransferRequest_UsesTransferTimeout(t *testing.T) {
+	req, cancel, err := NewTransferRequest(http.MethodPut, "https://example.com/bucket/key", nil)
+	if err != nil {
+		t.Fatalf("NewTransferRequest returned error: %v", err)
+	}
+	defer cancel()
+
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		t.Fatal("expected request context to include a deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= DefaultTimeout {
+		t.Fatalf("expected transfer deadline beyond %v, got %v", DefaultTimeout, remaining)
+	}
+	if transferClient.Timeout != TransferTimeout {
+		t.Fatalf("expected transfer timeout %v, got %v", TransferTimeout, transferClient.Timeout)
+	}
+}
@@ -0,0 +1,107 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MappingFile is the name of the mapping file in the work directory
+const MappingFile = ".gitsyncer-issues.json"
+
+// Link connects an original issue with its mirror on the other forge
+type Link struct {
+	Origin       string        `json:"origin"` // forge type of the original issue
+	OriginNumber int           `json:"origin_number"`
+	Mirror       string        `json:"mirror"` // forge type of the mirrored issue
+	MirrorNumber int           `json:"mirror_number"`
+	Closed       bool          `json:"closed,omitempty"` // the mirror was closed after the original
+	Comments     []CommentLink `json:"comments,omitempty"`
+}
+
+// CommentLink connects a comment with its copy on the other forge
+type CommentLink struct {
+	Forge  string `json:"forge"` // forge type the comment was written on
+	ID     int64  `json:"id"`
+	Mirror int64  `json:"mirror"` // ID of the copy on the other forge
+}
+
+// Mapping records the mirrored issues of all repositories, so that reruns
+// neither mirror an issue twice nor mirror a mirror back
+type Mapping struct {
+	Repos map[string][]*Link `json:"repos"`
+}
+
+// LoadMapping reads the mapping file from the work directory. A missing file
+// yields an empty mapping.
+func LoadMapping(workDir string) (*Mapping, error) {
+	m := &Mapping{Repos: make(map[string][]*Link)}
+	data, err := os.ReadFile(filepath.Join(workDir, MappingFile))
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue mapping: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse issue mapping: %w", err)
+	}
+	if m.Repos == nil {
+		m.Repos = make(map[string][]*Link)
+	}
+	return m, nil
+}
+
+// Save writes the mapping file to the work directory
+func (m *Mapping) Save(workDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal issue mapping: %w", err)
+	}
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	// Written to a temporary file first, so that an interrupted run never
+	// leaves a truncated mapping behind
+	path := filepath.Join(workDir, MappingFile)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write issue mapping: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write issue mapping: %w", err)
+	}
+	return nil
+}
+
+// find returns the link an issue belongs to, as original or as mirror
+func (m *Mapping) find(repoName, forgeType string, number int) *Link {
+	for _, link := range m.Repos[repoName] {
+		if (link.Origin == forgeType && link.OriginNumber == number) ||
+			(link.Mirror == forgeType && link.MirrorNumber == number) {
+			return link
+		}
+	}
+	return nil
+}
+
+// knowsComment reports whether a comment was mirrored or is itself a mirror
+func (l *Link) knowsComment(forgeType string, id int64) bool {
+	for _, c := range l.Comments {
+		if (c.Forge == forgeType && c.ID == id) || (c.Forge != forgeType && c.Mirror == id) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenameRepo moves the links of a renamed repository to its new name
+func (m *Mapping) RenameRepo(oldName, newName string) bool {
+	links, ok := m.Repos[oldName]
+	if !ok || oldName == newName {
+		return false
+	}
+	m.Repos[newName] = append(m.Repos[newName], links...)
+	delete(m.Repos, oldName)
+	return true
+}
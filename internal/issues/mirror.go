@@ -0,0 +1,179 @@
+// Package issues mirrors issues and their comments between two forges.
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// mirrorMarker tags issues and comments written by gitsyncer, so that they
+// are never mirrored back even if the mapping file is lost
+const mirrorMarker = "<!-- gitsyncer:mirror -->"
+
+// Result counts what a run mirrored, or would mirror in report-only mode
+type Result struct {
+	Issues   int
+	Comments int
+	Closed   int
+}
+
+// Mirror mirrors the issues of a repository between two forges. Open issues
+// are copied to the other forge with a backlink, new comments on either copy
+// are copied to the other one, and a mirror is closed once its original is.
+type Mirror struct {
+	forges     [2]forge.IssueTracker
+	mapping    *Mapping
+	reportOnly bool
+}
+
+// NewMirror creates a mirror between two forges. In report-only mode nothing
+// is written and the mapping is left unchanged.
+func NewMirror(a, b forge.IssueTracker, mapping *Mapping, reportOnly bool) *Mirror {
+	return &Mirror{forges: [2]forge.IssueTracker{a, b}, mapping: mapping, reportOnly: reportOnly}
+}
+
+// SyncRepo mirrors the issues of one repository. Links created before an
+// error are kept in the mapping.
+func (m *Mirror) SyncRepo(repoName string) (Result, error) {
+	var result Result
+
+	var issues [2][]forge.Issue
+	for i, f := range m.forges {
+		list, err := f.ListIssues(repoName)
+		if err != nil {
+			return result, fmt.Errorf("failed to list %s issues: %w", f.DisplayName(), err)
+		}
+		issues[i] = list
+	}
+
+	// Links closed in this run still get their last comments mirrored
+	closedBefore := make(map[*Link]bool)
+	for _, link := range m.mapping.Repos[repoName] {
+		closedBefore[link] = link.Closed
+	}
+
+	for i, from := range m.forges {
+		to := m.forges[1-i]
+		for _, issue := range issues[i] {
+			if err := m.syncIssue(repoName, from, to, issue, &result); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	for _, link := range m.mapping.Repos[repoName] {
+		if closedBefore[link] {
+			continue
+		}
+		if err := m.syncComments(repoName, link, &result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// syncIssue mirrors a new open issue, or closes the mirror of a closed one
+func (m *Mirror) syncIssue(repoName string, from, to forge.IssueTracker, issue forge.Issue, result *Result) error {
+	link := m.mapping.find(repoName, from.Type(), issue.Number)
+	if link != nil {
+		if link.Origin != from.Type() || !issue.Closed || link.Closed {
+			return nil
+		}
+		result.Closed++
+		if m.reportOnly {
+			fmt.Printf("  Would close %s issue #%d, as %s issue #%d is closed\n", to.DisplayName(), link.MirrorNumber, from.DisplayName(), issue.Number)
+			return nil
+		}
+		if err := to.CloseIssue(repoName, link.MirrorNumber); err != nil {
+			return fmt.Errorf("failed to close %s issue #%d: %w", to.DisplayName(), link.MirrorNumber, err)
+		}
+		link.Closed = true
+		fmt.Printf("  Closed %s issue #%d, as %s issue #%d is closed\n", to.DisplayName(), link.MirrorNumber, from.DisplayName(), issue.Number)
+		return nil
+	}
+
+	// Closed issues are not worth mirroring, and mirrors are never mirrored back
+	if issue.Closed || isMirror(issue.Body) {
+		return nil
+	}
+
+	result.Issues++
+	if m.reportOnly {
+		fmt.Printf("  Would mirror %s issue #%d to %s: %s\n", from.DisplayName(), issue.Number, to.DisplayName(), issue.Title)
+		return nil
+	}
+	body := mirrorBody(from.DisplayName()+" issue #"+fmt.Sprint(issue.Number), issue.URL, issue.Author, issue.Body)
+	created, err := to.CreateIssue(repoName, issue.Title, body)
+	if err != nil {
+		return fmt.Errorf("failed to mirror %s issue #%d to %s: %w", from.DisplayName(), issue.Number, to.DisplayName(), err)
+	}
+	m.mapping.Repos[repoName] = append(m.mapping.Repos[repoName], &Link{
+		Origin:       from.Type(),
+		OriginNumber: issue.Number,
+		Mirror:       to.Type(),
+		MirrorNumber: created.Number,
+	})
+	fmt.Printf("  Mirrored %s issue #%d to %s issue #%d: %s\n", from.DisplayName(), issue.Number, to.DisplayName(), created.Number, issue.Title)
+	return nil
+}
+
+// syncComments copies new comments of both copies of a linked issue to the
+// other copy
+func (m *Mirror) syncComments(repoName string, link *Link, result *Result) error {
+	sides := []struct {
+		from, to         forge.IssueTracker
+		number, toNumber int
+	}{
+		{m.forgeOf(link.Origin), m.forgeOf(link.Mirror), link.OriginNumber, link.MirrorNumber},
+		{m.forgeOf(link.Mirror), m.forgeOf(link.Origin), link.MirrorNumber, link.OriginNumber},
+	}
+
+	for _, side := range sides {
+		if side.from == nil || side.to == nil {
+			continue
+		}
+		comments, err := side.from.ListIssueComments(repoName, side.number)
+		if err != nil {
+			return fmt.Errorf("failed to list comments of %s issue #%d: %w", side.from.DisplayName(), side.number, err)
+		}
+		for _, comment := range comments {
+			if link.knowsComment(side.from.Type(), comment.ID) || isMirror(comment.Body) {
+				continue
+			}
+			result.Comments++
+			if m.reportOnly {
+				fmt.Printf("  Would mirror a comment by %s on %s issue #%d to %s issue #%d\n", comment.Author, side.from.DisplayName(), side.number, side.to.DisplayName(), side.toNumber)
+				continue
+			}
+			body := mirrorBody("comment on "+side.from.DisplayName()+" issue #"+fmt.Sprint(side.number), comment.URL, comment.Author, comment.Body)
+			created, err := side.to.CreateIssueComment(repoName, side.toNumber, body)
+			if err != nil {
+				return fmt.Errorf("failed to mirror comment to %s issue #%d: %w", side.to.DisplayName(), side.toNumber, err)
+			}
+			link.Comments = append(link.Comments, CommentLink{Forge: side.from.Type(), ID: comment.ID, Mirror: created.ID})
+			fmt.Printf("  Mirrored a comment by %s to %s issue #%d\n", comment.Author, side.to.DisplayName(), side.toNumber)
+		}
+	}
+	return nil
+}
+
+// forgeOf returns the forge of the given type, or nil if it is not mirrored
+func (m *Mirror) forgeOf(forgeType string) forge.IssueTracker {
+	for _, f := range m.forges {
+		if f.Type() == forgeType {
+			return f
+		}
+	}
+	return nil
+}
+
+// mirrorBody prefixes a mirrored text with the marker and a backlink
+func mirrorBody(what, url, author, body string) string {
+	return fmt.Sprintf("%s\n*Mirrored from [%s](%s), written by %s.*\n\n%s", mirrorMarker, what, url, author, body)
+}
+
+func isMirror(body string) bool {
+	return strings.Contains(body, mirrorMarker)
+}
@@ -0,0 +1,208 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+type fakeComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+type fakeIssue struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	State    string `json:"state"`
+	HTMLURL  string `json:"html_url"`
+	comments []fakeComment
+}
+
+// fakeIssueTracker is a stand-in for the issue API of one repository. GitHub
+// and Gitea share the endpoints used here; only the API prefix differs.
+type fakeIssueTracker struct {
+	mu     sync.Mutex
+	prefix string
+	issues []*fakeIssue
+	nextID int64
+	writes int
+}
+
+func newFakeIssueTracker(t *testing.T, prefix string) (*fakeIssueTracker, *httptest.Server) {
+	f := &fakeIssueTracker{prefix: prefix, nextID: 100}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeIssueTracker) addIssue(title, state string) *fakeIssue {
+	issue := &fakeIssue{Number: len(f.issues) + 1, Title: title, State: state}
+	issue.HTMLURL = fmt.Sprintf("https://forge.example%s/issues/%d", f.prefix, issue.Number)
+	f.issues = append(f.issues, issue)
+	return issue
+}
+
+func (f *fakeIssueTracker) addComment(issue *fakeIssue, author, body string) {
+	f.nextID++
+	comment := fakeComment{ID: f.nextID, Body: body, HTMLURL: fmt.Sprintf("%s#comment-%d", issue.HTMLURL, f.nextID)}
+	comment.User.Login = author
+	issue.comments = append(issue.comments, comment)
+}
+
+func (f *fakeIssueTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, f.prefix+"/repos/team/tool"), "/"), "/")
+	writeJSON := func(status int, v any) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	var payload map[string]string
+	if r.Method != http.MethodGet {
+		f.writes++
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}
+
+	if parts[0] != "issues" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(http.StatusOK, f.issues)
+		case http.MethodPost:
+			issue := f.addIssue(payload["title"], "open")
+			issue.Body = payload["body"]
+			writeJSON(http.StatusCreated, issue)
+		}
+		return
+	}
+
+	number, _ := strconv.Atoi(parts[1])
+	if number < 1 || number > len(f.issues) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	issue := f.issues[number-1]
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodPatch:
+		issue.State = payload["state"]
+		writeJSON(http.StatusOK, issue)
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		writeJSON(http.StatusOK, issue.comments)
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		f.addComment(issue, "gitsyncer", payload["body"])
+		writeJSON(http.StatusCreated, issue.comments[len(issue.comments)-1])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestForges(t *testing.T) (*fakeIssueTracker, *fakeIssueTracker, forge.IssueTracker, forge.IssueTracker) {
+	t.Helper()
+
+	ghTracker, ghServer := newFakeIssueTracker(t, "/api/v3")
+	giteaTracker, giteaServer := newFakeIssueTracker(t, "/api/v1")
+	gh, err := forge.New(&config.Organization{Host: "git@github.com", Name: "team", APIURL: ghServer.URL, GitHubToken: "secret"})
+	if err != nil {
+		t.Fatalf("forge.New(github) error = %v", err)
+	}
+	gitea, err := forge.New(&config.Organization{Host: "git@git.example.com", Name: "team", Type: config.TypeGitea, APIURL: giteaServer.URL, GiteaToken: "secret"})
+	if err != nil {
+		t.Fatalf("forge.New(gitea) error = %v", err)
+	}
+	return ghTracker, giteaTracker, gh.(forge.IssueTracker), gitea.(forge.IssueTracker)
+}
+
+func TestMirror_SyncRepoIsIdempotentAndFollowsUpdates(t *testing.T) {
+	ghTracker, giteaTracker, gh, gitea := newTestForges(t)
+	crash := ghTracker.addIssue("Crash on start", "open")
+	ghTracker.addComment(crash, "alice", "Happens on Linux too")
+	ghTracker.addIssue("Old bug", "closed")
+	giteaTracker.addIssue("Typo in README", "open")
+
+	workDir := t.TempDir()
+	mapping, err := LoadMapping(workDir)
+	if err != nil {
+		t.Fatalf("LoadMapping() error = %v", err)
+	}
+	result, err := NewMirror(gh, gitea, mapping, false).SyncRepo("tool")
+	if err != nil {
+		t.Fatalf("SyncRepo() error = %v", err)
+	}
+	if result != (Result{Issues: 2, Comments: 1}) {
+		t.Fatalf("first run result = %+v", result)
+	}
+
+	mirrored := giteaTracker.issues[1]
+	if mirrored.Title != "Crash on start" || !strings.Contains(mirrored.Body, crash.HTMLURL) || !isMirror(mirrored.Body) {
+		t.Fatalf("unexpected mirrored issue %#v", mirrored)
+	}
+	if len(mirrored.comments) != 1 || !strings.Contains(mirrored.comments[0].Body, "Happens on Linux too") {
+		t.Fatalf("expected the comment to be mirrored, got %#v", mirrored.comments)
+	}
+	if len(ghTracker.issues) != 3 || ghTracker.issues[2].Title != "Typo in README" {
+		t.Fatalf("expected the Gitea issue on GitHub, got %d issues", len(ghTracker.issues))
+	}
+
+	// A rerun with the saved mapping changes nothing
+	if err := mapping.Save(workDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if mapping, err = LoadMapping(workDir); err != nil {
+		t.Fatalf("LoadMapping() error = %v", err)
+	}
+	writes := ghTracker.writes + giteaTracker.writes
+	if result, err = NewMirror(gh, gitea, mapping, false).SyncRepo("tool"); err != nil || result != (Result{}) {
+		t.Fatalf("rerun = %+v, %v", result, err)
+	}
+	if ghTracker.writes+giteaTracker.writes != writes {
+		t.Fatal("expected the rerun to write nothing")
+	}
+
+	// Replies on the mirror go back to the original, closing the original closes the mirror
+	giteaTracker.addComment(mirrored, "bob", "Fixed by reverting")
+	crash.State = "closed"
+	if result, err = NewMirror(gh, gitea, mapping, false).SyncRepo("tool"); err != nil {
+		t.Fatalf("SyncRepo() error = %v", err)
+	}
+	if result != (Result{Comments: 1, Closed: 1}) {
+		t.Fatalf("update run result = %+v", result)
+	}
+	if mirrored.State != "closed" {
+		t.Fatal("expected the mirror to be closed")
+	}
+	if len(crash.comments) != 2 || !strings.Contains(crash.comments[1].Body, "Fixed by reverting") {
+		t.Fatalf("expected the reply on the original, got %#v", crash.comments)
+	}
+}
+
+func TestMirror_ReportOnlyWritesNothing(t *testing.T) {
+	ghTracker, giteaTracker, gh, gitea := newTestForges(t)
+	ghTracker.addIssue("Crash on start", "open")
+
+	mapping := &Mapping{Repos: map[string][]*Link{}}
+	result, err := NewMirror(gh, gitea, mapping, true).SyncRepo("tool")
+	if err != nil || result != (Result{Issues: 1}) {
+		t.Fatalf("SyncRepo() = %+v, %v", result, err)
+	}
+	if ghTracker.writes+giteaTracker.writes != 0 || len(mapping.Repos["tool"]) != 0 {
+		t.Fatal("expected report-only mode to write nothing")
+	}
+}
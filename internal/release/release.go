@@ -1,18 +1,12 @@
 package release
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"regexp"
 	"sort"
 	"strings"
-
-	"codeberg.org/snonux/gitsyncer/internal/httpclient"
 )
 
 // Tag represents a git tag
@@ -20,19 +14,10 @@ type Tag struct {
 	Name string
 }
 
-// Release represents a release on a platform
-type Release struct {
-	TagName string `json:"tag_name"`
-	Name    string `json:"name"`
-	Body    string `json:"body"`
-}
-
 // Manager handles release operations
 type Manager struct {
-	workDir       string
-	githubToken   string
-	codebergToken string
-	aiTool        string
+	workDir string
+	aiTool  string
 }
 
 // NewManager creates a new release manager
@@ -42,81 +27,11 @@ func NewManager(workDir string) *Manager {
 	}
 }
 
-// SetGitHubToken sets the GitHub token for API authentication
-func (m *Manager) SetGitHubToken(token string) {
-	m.githubToken = token
-}
-
-// SetCodebergToken sets the Codeberg token for API authentication
-func (m *Manager) SetCodebergToken(token string) {
-	m.codebergToken = token
-}
-
 // SetAITool sets the AI tool to use for release notes generation
 func (m *Manager) SetAITool(tool string) {
 	m.aiTool = tool
 }
 
-// EnsureCodebergReleasesEnabled ensures that the Codeberg repository has the
-// Releases feature enabled. If it's disabled, attempts to enable it via API.
-func (m *Manager) EnsureCodebergReleasesEnabled(owner, repo string) error {
-	if m.codebergToken == "" {
-		return fmt.Errorf("Codeberg token is required to manage repository settings")
-	}
-
-	// Fetch repository metadata
-	infoURL := fmt.Sprintf("https://codeberg.org/api/v1/repos/%s/%s", owner, repo)
-	getReq, cancel, err := httpclient.NewRequest(http.MethodGet, infoURL, nil)
-	if err != nil {
-		return err
-	}
-	defer cancel()
-	getReq.Header.Set("Authorization", "token "+m.codebergToken)
-	resp, err := httpclient.Do(getReq)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get repo info: %s - %s", resp.Status, string(body))
-	}
-
-	var repoInfo struct {
-		HasReleases bool `json:"has_releases"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
-		return fmt.Errorf("failed to parse repo info: %w", err)
-	}
-	if repoInfo.HasReleases {
-		return nil
-	}
-
-	// Enable releases via PATCH
-	payload := map[string]any{"has_releases": true}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-	patchReq, patchCancel, err := httpclient.NewRequest(http.MethodPatch, infoURL, bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
-	defer patchCancel()
-	patchReq.Header.Set("Authorization", "token "+m.codebergToken)
-	patchReq.Header.Set("Content-Type", "application/json")
-	patchResp, err := httpclient.Do(patchReq)
-	if err != nil {
-		return err
-	}
-	defer patchResp.Body.Close()
-	if patchResp.StatusCode != 200 {
-		pbody, _ := io.ReadAll(patchResp.Body)
-		return fmt.Errorf("failed to enable releases: %s - %s", patchResp.Status, string(pbody))
-	}
-	return nil
-}
-
 // isVersionTag checks if a tag name is a version tag
 // Supports formats: vX.Y.Z, vX.Y, vX, X.Y.Z, X.Y, X
 func isVersionTag(tag string) bool {
@@ -526,95 +441,6 @@ func (m *Manager) GenerateAIReleaseNotes(repoPath, repoName, tag string, allTags
 	return finalNotes.String(), nil
 }
 
-// GetGitHubReleases fetches releases from GitHub
-func (m *Manager) GetGitHubReleases(owner, repo string) ([]string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", owner, repo)
-
-	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer cancel()
-
-	// Add GitHub token if available
-	if m.githubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+m.githubToken)
-	}
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
-		// Repository might not exist on GitHub
-		return []string{}, nil
-	}
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(body))
-	}
-
-	var releases []Release
-	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return nil, err
-	}
-
-	var tags []string
-	for _, release := range releases {
-		tags = append(tags, release.TagName)
-	}
-
-	return tags, nil
-}
-
-// GetCodebergReleases fetches releases from Codeberg
-func (m *Manager) GetCodebergReleases(owner, repo string) ([]string, error) {
-	url := fmt.Sprintf("https://codeberg.org/api/v1/repos/%s/%s/releases", owner, repo)
-
-	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer cancel()
-
-	// Add Codeberg token if available
-	if m.codebergToken != "" {
-		req.Header.Set("Authorization", "token "+m.codebergToken)
-	}
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
-		// Repository might not exist on Codeberg
-		return []string{}, nil
-	}
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Codeberg API error: %s - %s", resp.Status, string(body))
-	}
-
-	var releases []Release
-	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return nil, err
-	}
-
-	var tags []string
-	for _, release := range releases {
-		tags = append(tags, release.TagName)
-	}
-
-	return tags, nil
-}
-
 // FindMissingReleases finds tags that don't have releases
 func (m *Manager) FindMissingReleases(localTags, releaseTags []string) []string {
 	releaseMap := make(map[string]bool)
@@ -632,183 +458,6 @@ func (m *Manager) FindMissingReleases(localTags, releaseTags []string) []string
 	return missing
 }
 
-// CreateGitHubRelease creates a release on GitHub
-func (m *Manager) CreateGitHubRelease(owner, repo, tag, releaseNotes string) error {
-	if m.githubToken == "" {
-		return fmt.Errorf("GitHub token is required for creating releases")
-	}
-
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", owner, repo)
-
-	// Use provided release notes or default
-	body := releaseNotes
-	if body == "" {
-		body = fmt.Sprintf("Release %s", tag)
-	}
-
-	release := Release{
-		TagName: tag,
-		Name:    tag,
-		Body:    body,
-	}
-
-	jsonData, err := json.Marshal(release)
-	if err != nil {
-		return err
-	}
-
-	req, cancel, err := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-	defer cancel()
-
-	req.Header.Set("Authorization", "Bearer "+m.githubToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 201 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create GitHub release: %s - %s", resp.Status, string(body))
-	}
-
-	return nil
-}
-
-// CreateCodebergRelease creates a release on Codeberg
-func (m *Manager) CreateCodebergRelease(owner, repo, tag, releaseNotes string) error {
-	if m.codebergToken == "" {
-		return fmt.Errorf("Codeberg token is required for creating releases")
-	}
-
-	url := fmt.Sprintf("https://codeberg.org/api/v1/repos/%s/%s/releases", owner, repo)
-
-	// Use provided release notes or default
-	body := releaseNotes
-	if body == "" {
-		body = fmt.Sprintf("Release %s", tag)
-	}
-
-	// Codeberg uses Gitea API
-	// According to Gitea API docs, only tag_name is required
-	release := map[string]interface{}{
-		"tag_name":   tag,
-		"name":       tag, // Use simple tag name like working releases
-		"body":       body,
-		"draft":      false,
-		"prerelease": false,
-	}
-
-	jsonData, err := json.Marshal(release)
-	if err != nil {
-		return err
-	}
-
-	req, cancel, err := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-	defer cancel()
-
-	req.Header.Set("Authorization", "token "+m.codebergToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 201 {
-		body, _ := io.ReadAll(resp.Body)
-
-		// Provide a more actionable hint when the repository is missing or owner/repo is wrong
-		if resp.StatusCode == 404 {
-			// Probe repository details to distinguish scenarios
-			probeURL := fmt.Sprintf("https://codeberg.org/api/v1/repos/%s/%s", owner, repo)
-			probeReq, probeCancel, perr := httpclient.NewRequest(http.MethodGet, probeURL, nil)
-			if perr == nil {
-				defer probeCancel()
-				// Prefer probing with the same token
-				if m.codebergToken != "" {
-					probeReq.Header.Set("Authorization", "token "+m.codebergToken)
-				}
-				if probeResp, perr2 := httpclient.Do(probeReq); perr2 == nil {
-					defer probeResp.Body.Close()
-					if probeResp.StatusCode == 200 {
-						// Try to detect if releases are disabled
-						var repoInfo struct {
-							HasReleases bool `json:"has_releases"`
-						}
-						if data, rerr := io.ReadAll(probeResp.Body); rerr == nil {
-							_ = json.Unmarshal(data, &repoInfo)
-							if !repoInfo.HasReleases {
-								// Try to enable releases automatically and retry creation
-								if err := m.EnsureCodebergReleasesEnabled(owner, repo); err != nil {
-									return fmt.Errorf(
-										"failed to create Codeberg release: releases are disabled for %s/%s and enabling via API failed: %v. Raw response: %s",
-										owner, repo, err, string(body),
-									)
-								}
-								// Retry POST after enabling
-								retryReq, retryCancel, rerr := httpclient.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
-								if rerr != nil {
-									return rerr
-								}
-								defer retryCancel()
-								retryReq.Header.Set("Authorization", "token "+m.codebergToken)
-								retryReq.Header.Set("Content-Type", "application/json")
-								retryReq.Header.Set("Accept", "application/json")
-								retryResp, rerr := httpclient.Do(retryReq)
-								if rerr != nil {
-									return rerr
-								}
-								defer retryResp.Body.Close()
-								if retryResp.StatusCode != 201 {
-									rbody, _ := io.ReadAll(retryResp.Body)
-									return fmt.Errorf("failed to create Codeberg release after enabling releases: %s - %s", retryResp.Status, string(rbody))
-								}
-								// Success after enabling
-								return nil
-							}
-						}
-						// Repo exists and has releases; likely permission/scope issue
-						return fmt.Errorf(
-							"failed to create Codeberg release: repo %s/%s exists but returned 404 on release creation. This usually indicates the token lacks write permissions to this repository or owner. Ensure the token belongs to '%s' (or a collaborator/maintainer) and has repository write access. Raw response: %s",
-							owner, repo, owner, string(body),
-						)
-					}
-				}
-			}
-			return fmt.Errorf(
-				"failed to create Codeberg release: repository %s/%s not found (404). Verify your Codeberg owner ('organizations[].name') matches the actual owner for this repo and that the repository exists. If needed, create it first, e.g.: gitsyncer sync repo %s --create-codeberg-repos. Raw response: %s",
-				owner, repo, repo, string(body),
-			)
-		}
-
-		// Special handling for known Gitea issue
-		if resp.StatusCode == 409 && strings.Contains(string(body), "Release is has no Tag") {
-			// This is a known Gitea bug - the tag exists but Gitea can't create a release for it
-			// Check if it's one of the problematic old tags
-			fmt.Printf("\nWARNING: Codeberg/Gitea returned 'Release is has no Tag' error for tag %s\n", tag)
-			fmt.Printf("This is a known issue with some old tags. The tag exists but cannot have a release created via API.\n")
-			fmt.Printf("You may need to create this release manually through the Codeberg web interface.\n\n")
-			return fmt.Errorf("cannot create release for tag %s due to Gitea API limitation", tag)
-		}
-
-		return fmt.Errorf("failed to create Codeberg release: %s - %s", resp.Status, string(body))
-	}
-
-	return nil
-}
-
 // PromptConfirmation asks for user confirmation
 func PromptConfirmation(message string) bool {
 	fmt.Printf("%s [y/N]: ", message)
@@ -835,149 +484,3 @@ func PromptConfirmationWithNotes(message, releaseNotes string) bool {
 	response = strings.ToLower(strings.TrimSpace(response))
 	return response == "y" || response == "yes"
 }
-
-// UpdateGitHubRelease updates an existing release on GitHub
-func (m *Manager) UpdateGitHubRelease(owner, repo, tag, releaseNotes string) error {
-	if m.githubToken == "" {
-		return fmt.Errorf("GitHub token is required for updating releases")
-	}
-
-	// First, get the release ID
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", owner, repo, tag)
-
-	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return err
-	}
-	defer cancel()
-
-	req.Header.Set("Authorization", "Bearer "+m.githubToken)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get release: %s - %s", resp.Status, string(body))
-	}
-
-	var releaseInfo struct {
-		ID int64 `json:"id"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&releaseInfo); err != nil {
-		return err
-	}
-
-	// Now update the release
-	updateURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/%d", owner, repo, releaseInfo.ID)
-
-	release := Release{
-		TagName: tag,
-		Name:    tag,
-		Body:    releaseNotes,
-	}
-
-	jsonData, err := json.Marshal(release)
-	if err != nil {
-		return err
-	}
-
-	updateReq, updateCancel, err := httpclient.NewRequest(http.MethodPatch, updateURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-	defer updateCancel()
-
-	updateReq.Header.Set("Authorization", "Bearer "+m.githubToken)
-	updateReq.Header.Set("Content-Type", "application/json")
-	updateReq.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	updateResp, err := httpclient.Do(updateReq)
-	if err != nil {
-		return err
-	}
-	defer updateResp.Body.Close()
-
-	if updateResp.StatusCode != 200 {
-		body, _ := io.ReadAll(updateResp.Body)
-		return fmt.Errorf("failed to update GitHub release: %s - %s", updateResp.Status, string(body))
-	}
-
-	return nil
-}
-
-// UpdateCodebergRelease updates an existing release on Codeberg
-func (m *Manager) UpdateCodebergRelease(owner, repo, tag, releaseNotes string) error {
-	if m.codebergToken == "" {
-		return fmt.Errorf("Codeberg token is required for updating releases")
-	}
-
-	// First, get the release ID
-	url := fmt.Sprintf("https://codeberg.org/api/v1/repos/%s/%s/releases/tags/%s", owner, repo, tag)
-
-	req, cancel, err := httpclient.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return err
-	}
-	defer cancel()
-
-	req.Header.Set("Authorization", "token "+m.codebergToken)
-
-	resp, err := httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get release: %s - %s", resp.Status, string(body))
-	}
-
-	var releaseInfo struct {
-		ID int64 `json:"id"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&releaseInfo); err != nil {
-		return err
-	}
-
-	// Now update the release
-	updateURL := fmt.Sprintf("https://codeberg.org/api/v1/repos/%s/%s/releases/%d", owner, repo, releaseInfo.ID)
-
-	release := Release{
-		TagName: tag,
-		Name:    tag,
-		Body:    releaseNotes,
-	}
-
-	jsonData, err := json.Marshal(release)
-	if err != nil {
-		return err
-	}
-
-	updateReq, updateCancel, err := httpclient.NewRequest(http.MethodPatch, updateURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-	defer updateCancel()
-
-	updateReq.Header.Set("Authorization", "token "+m.codebergToken)
-	updateReq.Header.Set("Content-Type", "application/json")
-
-	updateResp, err := httpclient.Do(updateReq)
-	if err != nil {
-		return err
-	}
-	defer updateResp.Body.Close()
-
-	if updateResp.StatusCode != 200 {
-		body, _ := io.ReadAll(updateResp.Body)
-		return fmt.Errorf("failed to update Codeberg release: %s - %s", updateResp.Status, string(body))
-	}
-
-	return nil
-}
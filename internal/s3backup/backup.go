@@ -0,0 +1,259 @@
+package s3backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const manifestFilename = "manifest.json"
+
+// Manifest describes the bundle stored for one repository
+type Manifest struct {
+	Repository   string            `json:"repository"`
+	BundleKey    string            `json:"bundleKey"`
+	BundleSHA256 string            `json:"bundleSha256"`
+	BundleSize   int64             `json:"bundleSize"`
+	Refs         map[string]string `json:"refs"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// Result reports what a backup run did for one repository
+type Result struct {
+	Uploaded bool
+	Manifest Manifest
+}
+
+// Target uploads repository bundles below a key prefix in a bucket
+type Target struct {
+	client Client
+	prefix string
+}
+
+// NewTarget creates a backup target for the given client and key prefix
+func NewTarget(client Client, prefix string) *Target {
+	return &Target{
+		client: client,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+// BundleKey returns the object key of a repository's bundle
+func (t *Target) BundleKey(repoName string) string {
+	return path.Join(t.prefix, repoName, repoName+".bundle")
+}
+
+// ManifestKey returns the object key of a repository's manifest
+func (t *Target) ManifestKey(repoName string) string {
+	return path.Join(t.prefix, repoName, manifestFilename)
+}
+
+// BackupRepository bundles all local branches and tags of repoPath and
+// uploads the bundle and its manifest. The upload is skipped when the stored
+// manifest already records the same bundle content hash.
+func (t *Target) BackupRepository(repoPath, repoName string) (Result, error) {
+	refs, err := listRefs(repoPath)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(refs) == 0 {
+		return Result{}, fmt.Errorf("repository %s has no branches or tags to back up", repoName)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gitsyncer-bundle-")
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bundlePath := filepath.Join(tmpDir, repoName+".bundle")
+	if err := createBundle(repoPath, bundlePath); err != nil {
+		return Result{}, err
+	}
+
+	sum, size, err := fileSHA256(bundlePath)
+	if err != nil {
+		return Result{}, err
+	}
+
+	manifest := Manifest{
+		Repository:   repoName,
+		BundleKey:    t.BundleKey(repoName),
+		BundleSHA256: sum,
+		BundleSize:   size,
+		Refs:         refs,
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	existing, found, err := t.GetManifest(repoName)
+	if err != nil {
+		return Result{}, err
+	}
+	if found && existing.BundleSHA256 == sum {
+		return Result{Uploaded: false, Manifest: existing}, nil
+	}
+
+	bundle, err := os.Open(bundlePath)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer bundle.Close()
+
+	if err := t.client.PutObject(manifest.BundleKey, bundle, size, sum, "application/octet-stream"); err != nil {
+		return Result{}, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := t.client.PutObject(t.ManifestKey(repoName), bytes.NewReader(data), int64(len(data)), hashHex(data), "application/json"); err != nil {
+		return Result{}, err
+	}
+
+	return Result{Uploaded: true, Manifest: manifest}, nil
+}
+
+// GetManifest fetches the stored manifest for a repository, if any
+func (t *Target) GetManifest(repoName string) (Manifest, bool, error) {
+	var manifest Manifest
+	var buf bytes.Buffer
+	found, err := t.client.GetObject(t.ManifestKey(repoName), &buf)
+	if err != nil || !found {
+		return manifest, found, err
+	}
+	if err := json.Unmarshal(buf.Bytes(), &manifest); err != nil {
+		return manifest, false, fmt.Errorf("failed to parse manifest for %s: %w", repoName, err)
+	}
+	return manifest, true, nil
+}
+
+// ListRepositories returns the names of all repositories stored below the prefix
+func (t *Target) ListRepositories() ([]string, error) {
+	prefix := ""
+	if t.prefix != "" {
+		prefix = t.prefix + "/"
+	}
+
+	prefixes, err := t.client.ListPrefixes(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		name := strings.Trim(strings.TrimPrefix(p, prefix), "/")
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// DownloadBundle fetches a repository's bundle into bundlePath and checks it
+// against the hash recorded in the manifest.
+func (t *Target) DownloadBundle(repoName, bundlePath string) (Manifest, error) {
+	manifest, found, err := t.GetManifest(repoName)
+	if err != nil {
+		return manifest, err
+	}
+	if !found {
+		return manifest, fmt.Errorf("no backup manifest found for %s", repoName)
+	}
+
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	found, err = t.client.GetObject(manifest.BundleKey, io.MultiWriter(f, h))
+	if err != nil {
+		return manifest, err
+	}
+	if !found {
+		return manifest, fmt.Errorf("bundle %s listed in manifest is missing", manifest.BundleKey)
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != manifest.BundleSHA256 {
+		return manifest, fmt.Errorf("bundle %s is corrupt: sha256 %s, manifest says %s", manifest.BundleKey, sum, manifest.BundleSHA256)
+	}
+	return manifest, nil
+}
+
+// DeleteRepository removes the bundle and the manifest of a repository. The
+// manifest goes last, so an interrupted deletion can be rerun. It reports
+// false if there is no backup of the repository.
+func (t *Target) DeleteRepository(repoName string) (bool, error) {
+	manifest, found, err := t.GetManifest(repoName)
+	if err != nil || !found {
+		return false, err
+	}
+	bundleKey := manifest.BundleKey
+	if bundleKey == "" {
+		bundleKey = t.BundleKey(repoName)
+	}
+	if err := t.client.DeleteObject(bundleKey); err != nil {
+		return false, err
+	}
+	if err := t.client.DeleteObject(t.ManifestKey(repoName)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// listRefs returns the local branch and tag tips of a repository
+func listRefs(repoPath string) (map[string]string, error) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+
+	refs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			refs[fields[1]] = fields[0]
+		}
+	}
+	return refs, nil
+}
+
+// createBundle writes a bundle of all local branches and tags. A single pack
+// thread keeps the output stable so unchanged repositories hash identically.
+func createBundle(repoPath, bundlePath string) error {
+	cmd := exec.Command("git", "-c", "pack.threads=1", "bundle", "create", bundlePath, "--branches", "--tags")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w\n%s", err, string(output))
+	}
+	return nil
+}
+
+func fileSHA256(filePath string) (string, int64, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash %s: %w", filePath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
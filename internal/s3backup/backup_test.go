@@ -0,0 +1,249 @@
+package s3backup
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	stdsync "sync"
+	"testing"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible server
+type fakeS3 struct {
+	mu      stdsync.Mutex
+	objects map[string][]byte
+	puts    map[string]int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	store := &fakeS3{objects: map[string][]byte{}, puts: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			if got := r.Header.Get("X-Amz-Content-Sha256"); got != hashHex(data) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			store.objects[r.URL.Path] = data
+			store.puts[r.URL.Path]++
+		case http.MethodGet:
+			if r.URL.Query().Get("list-type") == "2" {
+				store.writeListing(w, r)
+				return
+			}
+			data, ok := store.objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(store.objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return store, server
+}
+
+func TestBackupRepository_UploadsBundleAndSkipsUnchanged(t *testing.T) {
+	t.Setenv("GITSYNCER_S3_ACCESS_KEY_ID", "test-key")
+	t.Setenv("GITSYNCER_S3_SECRET_ACCESS_KEY", "test-secret")
+
+	store, server := newFakeS3(t)
+	repoPath := initRepo(t)
+
+	target := NewTarget(NewClient(server.URL, "", "backups"), "gitsyncer/")
+
+	first, err := target.BackupRepository(repoPath, "sample")
+	if err != nil {
+		t.Fatalf("BackupRepository() error = %v", err)
+	}
+	if !first.Uploaded {
+		t.Fatal("expected first backup to upload the bundle")
+	}
+	if first.Manifest.Refs["refs/heads/main"] == "" {
+		t.Fatalf("expected manifest to record main branch, got %#v", first.Manifest.Refs)
+	}
+	if _, ok := store.objects["/backups/gitsyncer/sample/sample.bundle"]; !ok {
+		t.Fatalf("bundle not stored, objects: %v", keys(store.objects))
+	}
+
+	second, err := target.BackupRepository(repoPath, "sample")
+	if err != nil {
+		t.Fatalf("second BackupRepository() error = %v", err)
+	}
+	if second.Uploaded {
+		t.Fatal("expected unchanged bundle to be skipped")
+	}
+	if store.puts["/backups/gitsyncer/sample/sample.bundle"] != 1 {
+		t.Fatalf("bundle uploaded %d times, want 1", store.puts["/backups/gitsyncer/sample/sample.bundle"])
+	}
+
+	runGit(t, repoPath, "commit", "--allow-empty", "-m", "second")
+	third, err := target.BackupRepository(repoPath, "sample")
+	if err != nil {
+		t.Fatalf("third BackupRepository() error = %v", err)
+	}
+	if !third.Uploaded {
+		t.Fatal("expected changed repository to upload a new bundle")
+	}
+}
+
+func (s *fakeS3) writeListing(w http.ResponseWriter, r *http.Request) {
+	bucketPath := r.URL.Path + "/"
+	prefix := r.URL.Query().Get("prefix")
+
+	seen := map[string]bool{}
+	var b strings.Builder
+	b.WriteString("<ListBucketResult>")
+	for key := range s.objects {
+		name := strings.TrimPrefix(key, bucketPath)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(name, prefix)
+		if idx := strings.Index(rest, "/"); idx >= 0 {
+			common := prefix + rest[:idx+1]
+			if !seen[common] {
+				seen[common] = true
+				b.WriteString("<CommonPrefixes><Prefix>" + common + "</Prefix></CommonPrefixes>")
+			}
+		}
+	}
+	b.WriteString("<IsTruncated>false</IsTruncated></ListBucketResult>")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func TestTarget_ListAndDownloadBundle(t *testing.T) {
+	t.Setenv("GITSYNCER_S3_ACCESS_KEY_ID", "test-key")
+	t.Setenv("GITSYNCER_S3_SECRET_ACCESS_KEY", "test-secret")
+
+	store, server := newFakeS3(t)
+	target := NewTarget(NewClient(server.URL, "", "backups"), "gitsyncer")
+
+	if _, err := target.BackupRepository(initRepo(t), "sample"); err != nil {
+		t.Fatalf("BackupRepository() error = %v", err)
+	}
+
+	names, err := target.ListRepositories()
+	if err != nil {
+		t.Fatalf("ListRepositories() error = %v", err)
+	}
+	if len(names) != 1 || names[0] != "sample" {
+		t.Fatalf("ListRepositories() = %v, want [sample]", names)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "sample.bundle")
+	if _, err := target.DownloadBundle("sample", bundlePath); err != nil {
+		t.Fatalf("DownloadBundle() error = %v", err)
+	}
+	runGit(t, "", "clone", "-q", "--mirror", bundlePath, filepath.Join(t.TempDir(), "sample.git"))
+
+	store.objects["/backups/gitsyncer/sample/sample.bundle"] = []byte("corrupt")
+	if _, err := target.DownloadBundle("sample", bundlePath); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Fatalf("expected corrupt bundle error, got %v", err)
+	}
+}
+
+func TestTarget_DeleteRepository(t *testing.T) {
+	t.Setenv("GITSYNCER_S3_ACCESS_KEY_ID", "test-key")
+	t.Setenv("GITSYNCER_S3_SECRET_ACCESS_KEY", "test-secret")
+
+	store, server := newFakeS3(t)
+	target := NewTarget(NewClient(server.URL, "", "backups"), "gitsyncer")
+	for _, name := range []string{"sample", "other"} {
+		if _, err := target.BackupRepository(initRepo(t), name); err != nil {
+			t.Fatalf("BackupRepository(%s) error = %v", name, err)
+		}
+	}
+
+	deleted, err := target.DeleteRepository("sample")
+	if err != nil || !deleted {
+		t.Fatalf("DeleteRepository() = %v, %v", deleted, err)
+	}
+	for key := range store.objects {
+		if strings.Contains(key, "/sample/") {
+			t.Fatalf("object %s left behind", key)
+		}
+	}
+	if len(store.objects) != 2 {
+		t.Fatalf("expected the other backup to be kept, objects: %v", len(store.objects))
+	}
+
+	if deleted, err := target.DeleteRepository("sample"); err != nil || deleted {
+		t.Fatalf("second DeleteRepository() = %v, %v", deleted, err)
+	}
+}
+
+func TestBackupRepository_RequiresCredentials(t *testing.T) {
+	t.Setenv("GITSYNCER_S3_ACCESS_KEY_ID", "")
+	t.Setenv("GITSYNCER_S3_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	_, server := newFakeS3(t)
+	target := NewTarget(NewClient(server.URL, "", "backups"), "")
+
+	if _, err := target.BackupRepository(initRepo(t), "sample"); err == nil || !strings.Contains(err.Error(), "credentials") {
+		t.Fatalf("expected credentials error, got %v", err)
+	}
+}
+
+func TestURIEncode_EscapesReservedCharacters(t *testing.T) {
+	t.Parallel()
+
+	if got := uriEncode("my repo+1~.bundle"); got != "my%20repo%2B1~.bundle" {
+		t.Fatalf("uriEncode() = %q", got)
+	}
+}
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	runGit(t, repoPath, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repoPath, "README"), []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repoPath, "add", "README")
+	runGit(t, repoPath, "commit", "-q", "-m", "initial")
+	runGit(t, repoPath, "tag", "v1.0.0")
+	return repoPath
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func keys(m map[string][]byte) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
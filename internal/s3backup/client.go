@@ -0,0 +1,353 @@
+package s3backup
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+const (
+	defaultEndpoint = "https://s3.amazonaws.com"
+	defaultRegion   = "us-east-1"
+	emptyPayloadSHA = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// Client talks to an S3-compatible object store using path-style requests
+// signed with AWS Signature Version 4.
+type Client struct {
+	endpoint     string
+	region       string
+	bucket       string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	now          func() time.Time
+}
+
+// NewClient creates a new object storage client for a bucket.
+// Credentials are loaded from GITSYNCER_S3_ACCESS_KEY_ID/GITSYNCER_S3_SECRET_ACCESS_KEY,
+// falling back to the standard AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY variables.
+func NewClient(endpoint, region, bucket string) Client {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if region == "" {
+		region = firstEnv("GITSYNCER_S3_REGION", "AWS_REGION")
+	}
+	if region == "" {
+		region = defaultRegion
+	}
+
+	return Client{
+		endpoint:     strings.TrimRight(endpoint, "/"),
+		region:       region,
+		bucket:       bucket,
+		accessKey:    firstEnv("GITSYNCER_S3_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID"),
+		secretKey:    firstEnv("GITSYNCER_S3_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY"),
+		sessionToken: firstEnv("GITSYNCER_S3_SESSION_TOKEN", "AWS_SESSION_TOKEN"),
+		now:          time.Now,
+	}
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// HasCredentials returns whether an access key pair is configured
+func (c *Client) HasCredentials() bool {
+	return c.accessKey != "" && c.secretKey != ""
+}
+
+// PutObject uploads body under key. payloadHash must be the hex SHA-256 of body.
+func (c *Client) PutObject(key string, body io.Reader, size int64, payloadHash, contentType string) error {
+	req, cancel, err := httpclient.NewTransferRequest(http.MethodPut, c.objectURL(key), body)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if err := c.sign(req, payloadHash); err != nil {
+		return err
+	}
+
+	resp, err := httpclient.DoTransfer(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload %s: %s - %s", key, resp.Status, string(b))
+	}
+	return nil
+}
+
+// GetObject downloads key into w. It returns false without an error if the
+// object does not exist.
+func (c *Client) GetObject(key string, w io.Writer) (bool, error) {
+	req, cancel, err := httpclient.NewTransferRequest(http.MethodGet, c.objectURL(key), nil)
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+
+	if err := c.sign(req, emptyPayloadSHA); err != nil {
+		return false, err
+	}
+
+	resp, err := httpclient.DoTransfer(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("failed to download %s: %s - %s", key, resp.Status, string(b))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// DeleteObject removes key. Deleting a missing object is not an error.
+func (c *Client) DeleteObject(key string) error {
+	req, cancel, err := httpclient.NewTransferRequest(http.MethodDelete, c.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := c.sign(req, emptyPayloadSHA); err != nil {
+		return err
+	}
+
+	resp, err := httpclient.DoTransfer(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete %s: %s - %s", key, resp.Status, string(b))
+	}
+}
+
+// listBucketResult is the subset of a ListObjectsV2 response we need
+type listBucketResult struct {
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListPrefixes returns the common prefixes directly below prefix, using "/"
+// as delimiter. prefix should end with "/" unless it is empty.
+func (c *Client) ListPrefixes(prefix string) ([]string, error) {
+	var prefixes []string
+	token := ""
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("delimiter", "/")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		req, cancel, err := httpclient.NewRequest(http.MethodGet, c.endpoint+"/"+uriEncode(c.bucket)+"?"+canonicalQuery(query), nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.sign(req, emptyPayloadSHA); err != nil {
+			cancel()
+			return nil, err
+		}
+
+		result, err := c.doList(req)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range result.CommonPrefixes {
+			prefixes = append(prefixes, p.Prefix)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	return prefixes, nil
+}
+
+func (c *Client) doList(req *http.Request) (listBucketResult, error) {
+	var result listBucketResult
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return result, fmt.Errorf("failed to list bucket %s: %s - %s", c.bucket, resp.Status, string(b))
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, fmt.Errorf("failed to parse bucket listing: %w", err)
+	}
+	return result, nil
+}
+
+func (c *Client) objectPath(key string) string {
+	segments := []string{uriEncode(c.bucket)}
+	for _, part := range strings.Split(key, "/") {
+		segments = append(segments, uriEncode(part))
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func (c *Client) objectURL(key string) string {
+	return c.endpoint + c.objectPath(key)
+}
+
+// sign adds the AWS Signature Version 4 headers to req
+func (c *Client) sign(req *http.Request, payloadHash string) error {
+	if !c.HasCredentials() {
+		return fmt.Errorf("S3 credentials required (set GITSYNCER_S3_ACCESS_KEY_ID and GITSYNCER_S3_SECRET_ACCESS_KEY)")
+	}
+
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", shortDate, c.region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), shortDate)
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, signature))
+	return nil
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		vals := append([]string(nil), values[key]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, uriEncode(key)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything except the RFC 3986 unreserved characters
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
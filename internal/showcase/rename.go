@@ -0,0 +1,59 @@
+package showcase
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RenameRepo carries the cached summary and the rank history of a renamed
+// repository over to its new name, so that the next showcase run neither
+// regenerates the AI summary nor reports the project as new.
+func RenameRepo(workDir, oldName, newName string) error {
+	cacheDir := filepath.Join(workDir, ".gitsyncer-showcase-cache")
+	oldCache := filepath.Join(cacheDir, oldName+".json")
+	data, err := os.ReadFile(oldCache)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("read showcase cache: %w", err)
+	default:
+		var summary ProjectSummary
+		if err := json.Unmarshal(data, &summary); err != nil {
+			return fmt.Errorf("parse showcase cache: %w", err)
+		}
+		summary.Name = newName
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal showcase cache: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(cacheDir, newName+".json"), data, 0644); err != nil {
+			return fmt.Errorf("write showcase cache: %w", err)
+		}
+		if err := os.Remove(oldCache); err != nil {
+			return fmt.Errorf("remove old showcase cache: %w", err)
+		}
+	}
+
+	rankHistoryFile := filepath.Join(workDir, rankHistoryFilename)
+	if _, err := os.Stat(rankHistoryFile); os.IsNotExist(err) {
+		return nil
+	}
+	store, err := loadRankHistory(rankHistoryFile)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, snapshot := range store.Snapshots {
+		if spot, ok := snapshot.Ranks[oldName]; ok {
+			snapshot.Ranks[newName] = spot
+			delete(snapshot.Ranks, oldName)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return saveRankHistory(rankHistoryFile, store)
+}
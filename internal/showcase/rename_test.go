@@ -0,0 +1,64 @@
+package showcase
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenameRepo_MovesCacheAndRankHistory(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	g := New(nil, workDir)
+	cacheDir := filepath.Join(workDir, ".gitsyncer-showcase-cache")
+	if err := g.saveToCache(filepath.Join(cacheDir, "old.json"), &ProjectSummary{Name: "old", Summary: "A tool."}); err != nil {
+		t.Fatalf("saveToCache() error = %v", err)
+	}
+	historyFile := filepath.Join(workDir, rankHistoryFilename)
+	if err := saveRankHistory(historyFile, &RankHistoryStore{
+		Version: rankHistoryVersion,
+		Snapshots: []RankSnapshot{
+			{Date: "2026-02-15", Ranks: map[string]int{"old": 2, "other": 1}},
+			{Date: "2026-02-22", Ranks: map[string]int{"other": 1}},
+		},
+	}); err != nil {
+		t.Fatalf("saveRankHistory() error = %v", err)
+	}
+
+	if err := RenameRepo(workDir, "old", "new"); err != nil {
+		t.Fatalf("RenameRepo() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(cacheDir, "old.json")); !os.IsNotExist(err) {
+		t.Fatalf("old cache file still exists: %v", err)
+	}
+	summary, err := g.loadFromCache(filepath.Join(cacheDir, "new.json"))
+	if err != nil {
+		t.Fatalf("loadFromCache() error = %v", err)
+	}
+	if summary.Name != "new" || summary.Summary != "A tool." {
+		t.Fatalf("renamed summary = %+v", summary)
+	}
+
+	store, err := loadRankHistory(historyFile)
+	if err != nil {
+		t.Fatalf("loadRankHistory() error = %v", err)
+	}
+	ranks := store.Snapshots[0].Ranks
+	if _, ok := ranks["old"]; ok || ranks["new"] != 2 || ranks["other"] != 1 {
+		t.Fatalf("renamed ranks = %v", ranks)
+	}
+}
+
+func TestRenameRepo_WithoutShowcaseData(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	if err := RenameRepo(workDir, "old", "new"); err != nil {
+		t.Fatalf("RenameRepo() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, rankHistoryFilename)); !os.IsNotExist(err) {
+		t.Fatal("RenameRepo() must not create a rank history file")
+	}
+}
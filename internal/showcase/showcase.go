@@ -16,9 +16,10 @@ import (
 
 // Generator handles showcase generation for repositories
 type Generator struct {
-	config  *config.Config
-	workDir string
-	aiTool  string
+	config     *config.Config
+	workDir    string
+	aiTool     string
+	visibility map[string]bool // repo name -> private; nil if not filtered
 }
 
 // ProjectSummary holds the summary information for a project
@@ -60,6 +61,25 @@ func (g *Generator) SetAITool(tool string) {
 	g.aiTool = tool
 }
 
+// SetRepoVisibility sets the known visibility of repositories, true if
+// private. Once set, only repositories known to be public are included in
+// the showcase; private ones and those missing from visibility never are.
+func (g *Generator) SetRepoVisibility(visibility map[string]bool) {
+	g.visibility = make(map[string]bool, len(visibility))
+	for name, private := range visibility {
+		g.visibility[name] = private
+	}
+}
+
+// isPublic reports whether a repository is known to be public
+func (g *Generator) isPublic(repo string) bool {
+	if g.visibility == nil {
+		return true
+	}
+	private, known := g.visibility[repo]
+	return known && !private
+}
+
 // GenerateShowcase generates a showcase for repositories
 // If repoFilter is provided, only those repositories are processed
 // If repoFilter is empty/nil, all repositories in work directory are processed
@@ -680,7 +700,7 @@ func (g *Generator) buildProjectLinks(repoName string) (string, string, string)
 	}
 
 	if githubOrg := g.config.FindGitHubOrg(); githubOrg != nil {
-		githubURL = fmt.Sprintf("https://github.com/%s/%s", githubOrg.Name, repoName)
+		githubURL = fmt.Sprintf("https://%s/%s/%s", githubOrg.WebHost(), githubOrg.Name, repoName)
 	}
 
 	return codebergURL, githubURL, cgitURL
@@ -1250,7 +1270,7 @@ func (g *Generator) filterExcludedRepos(repos []string) []string {
 
 // isExcluded checks if a repository is in the exclusion list
 func (g *Generator) isExcluded(repo string) bool {
-	if isBackupRepo(repo) {
+	if isBackupRepo(repo) || !g.isPublic(repo) {
 		return true
 	}
 
@@ -1270,6 +1290,14 @@ func (g *Generator) exclusionReason(repo string) string {
 		reasons = append(reasons, "backup suffix")
 	}
 
+	if !g.isPublic(repo) {
+		if g.visibility[repo] {
+			reasons = append(reasons, "private")
+		} else {
+			reasons = append(reasons, "visibility unknown")
+		}
+	}
+
 	for _, excluded := range g.config.ExcludeFromShowcase {
 		if excluded == repo {
 			reasons = append(reasons, "config")
@@ -87,6 +87,24 @@ func TestFilterExcludedRepos_RemovesBackupAndConfigRepos(t *testing.T) {
 	}
 }
 
+func TestFilterExcludedRepos_RemovesPrivateAndUnknownRepos(t *testing.T) {
+	t.Parallel()
+
+	g := &Generator{config: &config.Config{}}
+	g.SetRepoVisibility(map[string]bool{"normal": false, "secret": true})
+
+	got := g.filterExcludedRepos([]string{"normal", "secret", "unknown"})
+	if want := []string{"normal"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("filterExcludedRepos() = %#v, want %#v", got, want)
+	}
+	if reason := g.exclusionReason("secret"); reason != "private" {
+		t.Fatalf("exclusionReason() = %q, want %q", reason, "private")
+	}
+	if reason := g.exclusionReason("unknown"); reason != "visibility unknown" {
+		t.Fatalf("exclusionReason() = %q, want %q", reason, "visibility unknown")
+	}
+}
+
 func TestFilterExcludedRepos_EmptyConfigStillRemovesBackupRepos(t *testing.T) {
 	t.Parallel()
 
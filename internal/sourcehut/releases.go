@@ -0,0 +1,177 @@
+package sourcehut
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"strings"
+)
+
+// SourceHut has no release objects. Release notes are attached as a file
+// artifact to an annotated tag, which git.sr.ht shows on the refs page.
+
+// artifact is a file attached to a tag
+type artifact struct {
+	ID       int64  `json:"id"`
+	Filename string `json:"filename"`
+}
+
+// reference is a git ref together with its artifacts
+type reference struct {
+	Name      string `json:"name"`
+	Artifacts struct {
+		Results []artifact `json:"results"`
+	} `json:"artifacts"`
+}
+
+// ReleaseNotesFile returns the artifact file name used for the notes of a tag
+func ReleaseNotesFile(tag string) string {
+	return fmt.Sprintf("release-notes-%s.md", tag)
+}
+
+// listTags returns all tag references of a repository with their artifacts
+func (c *Client) listTags(repoName string) ([]reference, error) {
+	var tags []reference
+	var cursor *string
+
+	for {
+		var data struct {
+			User *struct {
+				Repository *struct {
+					References struct {
+						Results []reference `json:"results"`
+						Cursor  *string     `json:"cursor"`
+					} `json:"references"`
+				} `json:"repository"`
+			} `json:"user"`
+		}
+		err := c.query(`query($username: String!, $name: String!, $cursor: Cursor) {
+			user(username: $username) { repository(name: $name) {
+				references(cursor: $cursor) { results { name artifacts { results { id filename } } } cursor }
+			} }
+		}`, map[string]any{"username": c.username, "name": repoName, "cursor": cursor}, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.User == nil || data.User.Repository == nil {
+			// Repository might not exist on SourceHut
+			return nil, nil
+		}
+
+		for _, ref := range data.User.Repository.References.Results {
+			if strings.HasPrefix(ref.Name, "refs/tags/") {
+				tags = append(tags, ref)
+			}
+		}
+
+		cursor = data.User.Repository.References.Cursor
+		if cursor == nil {
+			return tags, nil
+		}
+	}
+}
+
+// findNotesArtifact returns the release notes artifact of a tag, if any
+func findNotesArtifact(tags []reference, tag string) (artifact, bool) {
+	for _, ref := range tags {
+		if ref.Name != "refs/tags/"+tag {
+			continue
+		}
+		for _, a := range ref.Artifacts.Results {
+			if a.Filename == ReleaseNotesFile(tag) {
+				return a, true
+			}
+		}
+	}
+	return artifact{}, false
+}
+
+// ListReleases returns the tags that have release notes attached
+func (c *Client) ListReleases(repoName string) ([]string, error) {
+	tags, err := c.listTags(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := []string{}
+	for _, ref := range tags {
+		tag := strings.TrimPrefix(ref.Name, "refs/tags/")
+		if _, ok := findNotesArtifact(tags, tag); ok {
+			releases = append(releases, tag)
+		}
+	}
+	return releases, nil
+}
+
+// CreateRelease attaches release notes to an annotated tag
+func (c *Client) CreateRelease(repoName, tag, releaseNotes string) error {
+	// Use provided release notes or default
+	if releaseNotes == "" {
+		releaseNotes = fmt.Sprintf("Release %s", tag)
+	}
+
+	repo, exists, err := c.GetRepo(repoName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("repository ~%s/%s does not exist", c.username, repoName)
+	}
+
+	if err := c.uploadArtifact(repo.ID, tag, ReleaseNotesFile(tag), releaseNotes); err != nil {
+		return fmt.Errorf("failed to create SourceHut release notes for %s (SourceHut requires an annotated tag): %w", tag, err)
+	}
+	return nil
+}
+
+// UpdateRelease replaces the release notes attached to a tag
+func (c *Client) UpdateRelease(repoName, tag, releaseNotes string) error {
+	tags, err := c.listTags(repoName)
+	if err != nil {
+		return err
+	}
+
+	if existing, ok := findNotesArtifact(tags, tag); ok {
+		err := c.query(`mutation($id: Int!) { deleteArtifact(id: $id) { id } }`,
+			map[string]any{"id": existing.ID}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to remove old release notes: %w", err)
+		}
+	}
+	return c.CreateRelease(repoName, tag, releaseNotes)
+}
+
+// uploadArtifact attaches a file to a tag using a GraphQL multipart request
+func (c *Client) uploadArtifact(repoID int64, tag, filename, content string) error {
+	operations, err := json.Marshal(map[string]any{
+		"query": `mutation($repoId: Int!, $revspec: String!, $file: Upload!) {
+			uploadArtifact(repoId: $repoId, revspec: $revspec, file: $file) { id }
+		}`,
+		"variables": map[string]any{"repoId": repoID, "revspec": tag, "file": nil},
+	})
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("operations", string(operations)); err != nil {
+		return err
+	}
+	if err := writer.WriteField("map", `{"0": ["variables.file"]}`); err != nil {
+		return err
+	}
+	part, err := writer.CreateFormFile("0", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write([]byte(content)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return c.post(&body, writer.FormDataContentType(), nil)
+}
@@ -0,0 +1,308 @@
+package sourcehut
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/httpclient"
+)
+
+// DefaultAPIURL is the GraphQL endpoint of git.sr.ht
+const DefaultAPIURL = "https://git.sr.ht/query"
+
+// Visibility values of a SourceHut repository
+const (
+	VisibilityPublic   = "PUBLIC"
+	VisibilityUnlisted = "UNLISTED"
+	VisibilityPrivate  = "PRIVATE"
+)
+
+// Repository represents a git.sr.ht repository
+type Repository struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+	Head        *struct {
+		Name string `json:"name"`
+	} `json:"HEAD"`
+}
+
+// Private reports whether the repository is not publicly listed
+func (r Repository) Private() bool {
+	return r.Visibility != VisibilityPublic
+}
+
+// DefaultBranch returns the branch HEAD points to, if any
+func (r Repository) DefaultBranch() string {
+	if r.Head == nil {
+		return ""
+	}
+	return strings.TrimPrefix(r.Head.Name, "refs/heads/")
+}
+
+const repositoryFields = "id name description visibility HEAD { name }"
+
+// Client handles git.sr.ht GraphQL API operations for one user
+type Client struct {
+	apiURL   string
+	username string
+	token    string
+}
+
+// NewClient creates a new SourceHut API client. username may be given with or
+// without the leading "~"; apiURL may be empty for git.sr.ht.
+func NewClient(apiURL, username, token string) Client {
+	apiURL = strings.TrimRight(strings.TrimSpace(apiURL), "/")
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	} else if !strings.HasSuffix(apiURL, "/query") {
+		apiURL += "/query"
+	}
+	return Client{
+		apiURL:   apiURL,
+		username: strings.TrimPrefix(strings.Trim(username, "/"), "~"),
+		token:    loadToken(token),
+	}
+}
+
+// loadToken loads the SourceHut personal access token from config, env, or file
+func loadToken(token string) string {
+	if token != "" {
+		return strings.TrimSpace(token)
+	}
+
+	if envToken := os.Getenv("SRHT_TOKEN"); envToken != "" {
+		return strings.TrimSpace(envToken)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".gitsyncer_sourcehut_token"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// HasToken returns true if a token is loaded
+func (c *Client) HasToken() bool {
+	return c.token != ""
+}
+
+// graphQLResponse is the envelope of every GraphQL response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// query runs a GraphQL query or mutation and decodes its data into out
+func (c *Client) query(query string, variables map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	return c.post(bytes.NewBuffer(body), "application/json", out)
+}
+
+// post sends a GraphQL request body and decodes the response data into out
+func (c *Client) post(body io.Reader, contentType string, out any) error {
+	if !c.HasToken() {
+		return fmt.Errorf("SourceHut token required")
+	}
+
+	req, cancel, err := httpclient.NewRequest(http.MethodPost, c.apiURL, body)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("SourceHut API error: status %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var envelope graphQLResponse
+	if err := json.Unmarshal(respBody, &envelope); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		messages := make([]string, len(envelope.Errors))
+		for i, e := range envelope.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("SourceHut API error: %s", strings.Join(messages, "; "))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// GetRepo fetches a repository of the user by name
+func (c *Client) GetRepo(repoName string) (Repository, bool, error) {
+	var data struct {
+		User *struct {
+			Repository *Repository `json:"repository"`
+		} `json:"user"`
+	}
+	err := c.query(`query($username: String!, $name: String!) {
+		user(username: $username) { repository(name: $name) { `+repositoryFields+` } }
+	}`, map[string]any{"username": c.username, "name": repoName}, &data)
+	if err != nil {
+		return Repository{}, false, err
+	}
+	if data.User == nil {
+		return Repository{}, false, fmt.Errorf("SourceHut user ~%s not found", c.username)
+	}
+	if data.User.Repository == nil {
+		return Repository{}, false, nil
+	}
+	return *data.User.Repository, true, nil
+}
+
+// RepoExists checks if a repository exists
+func (c *Client) RepoExists(repoName string) (bool, error) {
+	_, exists, err := c.GetRepo(repoName)
+	return exists, err
+}
+
+// ListRepos lists the repositories of the user. Unlisted and private
+// repositories are only included if includePrivate is set.
+func (c *Client) ListRepos(includePrivate bool) ([]Repository, error) {
+	var all []Repository
+	var cursor *string
+
+	for {
+		var data struct {
+			User *struct {
+				Repositories struct {
+					Results []Repository `json:"results"`
+					Cursor  *string      `json:"cursor"`
+				} `json:"repositories"`
+			} `json:"user"`
+		}
+		err := c.query(`query($username: String!, $cursor: Cursor) {
+			user(username: $username) { repositories(cursor: $cursor) { results { `+repositoryFields+` } cursor } }
+		}`, map[string]any{"username": c.username, "cursor": cursor}, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.User == nil {
+			return nil, fmt.Errorf("SourceHut user ~%s not found", c.username)
+		}
+
+		for _, repo := range data.User.Repositories.Results {
+			if includePrivate || !repo.Private() {
+				all = append(all, repo)
+			}
+		}
+
+		cursor = data.User.Repositories.Cursor
+		if cursor == nil {
+			return all, nil
+		}
+	}
+}
+
+// CreateRepo creates a new repository. SourceHut always creates repositories
+// for the owner of the token.
+func (c *Client) CreateRepo(repoName, description string, private bool) error {
+	exists, err := c.RepoExists(repoName)
+	if err != nil {
+		return fmt.Errorf("failed to check if repo exists: %w", err)
+	}
+	if exists {
+		return nil // Repository already exists
+	}
+
+	visibility := VisibilityPublic
+	if private {
+		visibility = VisibilityPrivate
+	}
+	err = c.query(`mutation($name: String!, $visibility: Visibility!, $description: String) {
+		createRepository(name: $name, visibility: $visibility, description: $description) { id }
+	}`, map[string]any{"name": repoName, "visibility": visibility, "description": description}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	return nil
+}
+
+// updateRepo applies a RepoInput update to an existing repository
+func (c *Client) updateRepo(repoName string, input map[string]any) error {
+	repo, exists, err := c.GetRepo(repoName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("repository ~%s/%s does not exist", c.username, repoName)
+	}
+
+	return c.query(`mutation($id: Int!, $input: RepoInput!) {
+		updateRepository(id: $id, input: $input) { id }
+	}`, map[string]any{"id": repo.ID, "input": input}, nil)
+}
+
+// UpdateRepoDescription updates a repository description
+func (c *Client) UpdateRepoDescription(repoName, description string) error {
+	return c.updateRepo(repoName, map[string]any{"description": description})
+}
+
+// RenameRepo renames a repository
+func (c *Client) RenameRepo(oldName, newName string) error {
+	return c.updateRepo(oldName, map[string]any{"name": newName})
+}
+
+// UpdateVisibility sets the visibility (PUBLIC, UNLISTED or PRIVATE) of a repository
+func (c *Client) UpdateVisibility(repoName, visibility string) error {
+	return c.updateRepo(repoName, map[string]any{"visibility": visibility})
+}
+
+// UpdateDefaultBranch points HEAD of a repository to another branch
+func (c *Client) UpdateDefaultBranch(repoName, branch string) error {
+	return c.updateRepo(repoName, map[string]any{"HEAD": branch})
+}
+
+// DeleteRepo deletes a repository
+func (c *Client) DeleteRepo(repoName string) error {
+	repo, exists, err := c.GetRepo(repoName)
+	if err != nil {
+		return fmt.Errorf("failed to check if repo exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("repository ~%s/%s does not exist", c.username, repoName)
+	}
+
+	return c.query(`mutation($id: Int!) { deleteRepository(id: $id) { id } }`,
+		map[string]any{"id": repo.ID}, nil)
+}
+
+// TestAuth verifies the token by fetching the authenticated user
+func (c *Client) TestAuth() error {
+	var data struct {
+		Me struct {
+			Username string `json:"username"`
+		} `json:"me"`
+	}
+	return c.query(`query { me { username } }`, nil, &data)
+}
@@ -0,0 +1,176 @@
+package sourcehut
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSourceHut is a minimal stand-in for the git.sr.ht GraphQL API. It
+// dispatches on the operation name found in the query.
+type fakeSourceHut struct {
+	mu        sync.Mutex
+	repos     map[string]*Repository
+	artifacts map[string]string // filename -> content
+	nextID    int64
+}
+
+func newFakeSourceHut(t *testing.T) (*fakeSourceHut, *httptest.Server) {
+	t.Helper()
+	f := &fakeSourceHut{repos: map[string]*Repository{}, artifacts: map[string]string{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeSourceHut) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var op struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		_ = json.Unmarshal([]byte(r.FormValue("operations")), &op)
+		file, header, err := r.FormFile("0")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		f.artifacts[header.Filename] = string(content)
+		writeData(w, map[string]any{"uploadArtifact": map[string]any{"id": 1}})
+		return
+	}
+	_ = json.NewDecoder(r.Body).Decode(&op)
+
+	name, _ := op.Variables["name"].(string)
+	switch {
+	case strings.Contains(op.Query, "me {"):
+		writeData(w, map[string]any{"me": map[string]any{"username": "me"}})
+	case strings.Contains(op.Query, "createRepository"):
+		f.nextID++
+		f.repos[name] = &Repository{ID: f.nextID, Name: name, Description: op.Variables["description"].(string), Visibility: op.Variables["visibility"].(string)}
+		writeData(w, map[string]any{"createRepository": map[string]any{"id": f.nextID}})
+	case strings.Contains(op.Query, "updateRepository"):
+		input := op.Variables["input"].(map[string]any)
+		for _, repo := range f.repos {
+			if float64(repo.ID) == op.Variables["id"].(float64) {
+				if description, ok := input["description"].(string); ok {
+					repo.Description = description
+				}
+				if visibility, ok := input["visibility"].(string); ok {
+					repo.Visibility = visibility
+				}
+			}
+		}
+		writeData(w, map[string]any{"updateRepository": map[string]any{"id": op.Variables["id"]}})
+	case strings.Contains(op.Query, "references"):
+		var artifacts []map[string]any
+		for filename := range f.artifacts {
+			artifacts = append(artifacts, map[string]any{"id": 1, "filename": filename})
+		}
+		refs := []map[string]any{
+			{"name": "refs/heads/main", "artifacts": map[string]any{"results": []any{}}},
+			{"name": "refs/tags/v1.0.0", "artifacts": map[string]any{"results": artifacts}},
+		}
+		writeData(w, map[string]any{"user": map[string]any{"repository": map[string]any{
+			"references": map[string]any{"results": refs, "cursor": nil},
+		}}})
+	case strings.Contains(op.Query, "repositories("):
+		var results []*Repository
+		for _, repo := range f.repos {
+			results = append(results, repo)
+		}
+		writeData(w, map[string]any{"user": map[string]any{"repositories": map[string]any{"results": results, "cursor": nil}}})
+	case strings.Contains(op.Query, "repository(name"):
+		writeData(w, map[string]any{"user": map[string]any{"repository": f.repos[name]}})
+	default:
+		writeData(w, nil)
+	}
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func TestNewClient_NormalizesUsernameAndURL(t *testing.T) {
+	client := NewClient("", "~me", "x")
+	if client.username != "me" || client.apiURL != DefaultAPIURL {
+		t.Fatalf("got username %q and apiURL %q", client.username, client.apiURL)
+	}
+	if got := NewClient("https://git.example.org", "me", "x").apiURL; got != "https://git.example.org/query" {
+		t.Fatalf("apiURL = %q", got)
+	}
+}
+
+func TestClient_RepositoryLifecycle(t *testing.T) {
+	fake, server := newFakeSourceHut(t)
+	client := NewClient(server.URL, "~me", "secret")
+
+	if err := client.TestAuth(); err != nil {
+		t.Fatalf("TestAuth() error = %v", err)
+	}
+	if err := client.CreateRepo("tool", "A tool", false); err != nil {
+		t.Fatalf("CreateRepo() error = %v", err)
+	}
+	if err := client.CreateRepo("secret-tool", "", true); err != nil {
+		t.Fatalf("CreateRepo() error = %v", err)
+	}
+	if err := client.UpdateRepoDescription("tool", "A better tool"); err != nil {
+		t.Fatalf("UpdateRepoDescription() error = %v", err)
+	}
+
+	repo, exists, err := client.GetRepo("tool")
+	if err != nil || !exists || repo.Description != "A better tool" {
+		t.Fatalf("GetRepo() = %#v, %v, %v", repo, exists, err)
+	}
+
+	if err := client.UpdateVisibility("tool", VisibilityPrivate); err != nil {
+		t.Fatalf("UpdateVisibility() error = %v", err)
+	}
+	if repo, _, err := client.GetRepo("tool"); err != nil || !repo.Private() {
+		t.Fatalf("expected tool to be private, got %#v, %v", repo, err)
+	}
+	if err := client.UpdateVisibility("tool", VisibilityPublic); err != nil {
+		t.Fatalf("UpdateVisibility() error = %v", err)
+	}
+
+	repos, err := client.ListRepos(false)
+	if err != nil || len(repos) != 1 || repos[0].Name != "tool" {
+		t.Fatalf("ListRepos() = %#v, %v", repos, err)
+	}
+
+	if err := client.CreateRelease("tool", "v1.0.0", "notes"); err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+	if got := fake.artifacts[ReleaseNotesFile("v1.0.0")]; got != "notes" {
+		t.Fatalf("release notes artifact = %q", got)
+	}
+	tags, err := client.ListReleases("tool")
+	if err != nil || len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Fatalf("ListReleases() = %v, %v", tags, err)
+	}
+}
+
+func TestClient_ReportsGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors": [{"message": "Access denied"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "me", "secret")
+	if err := client.TestAuth(); err == nil || !strings.Contains(err.Error(), "Access denied") {
+		t.Fatalf("TestAuth() error = %v, want GraphQL error", err)
+	}
+}
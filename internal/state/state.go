@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -14,6 +15,21 @@ type State struct {
 	// Per-repo sync tracking for default daily sync limits and optional throttling
 	LastRepoSync        map[string]time.Time `json:"lastRepoSync,omitempty"`
 	NextRepoSyncAllowed map[string]time.Time `json:"nextRepoSyncAllowed,omitempty"`
+	// Per-repo, per-backup-location health tracking keyed by repo name and backup host
+	Backups map[string]map[string]BackupRecord `json:"backups,omitempty"`
+	// Visibility of repositories by name, true if private. Repositories of
+	// unknown visibility are missing and, like private ones, kept out of
+	// public output.
+	PrivateRepos map[string]bool `json:"privateRepos,omitempty"`
+	// Repositories archived via manage archive-repo, which are no longer synced
+	ArchivedRepos map[string]time.Time `json:"archivedRepos,omitempty"`
+}
+
+// BackupRecord tracks one repository on one backup location
+type BackupRecord struct {
+	LastPush     time.Time         `json:"lastPush,omitempty"`
+	Refs         map[string]string `json:"refs,omitempty"` // Ref tips pushed by LastPush
+	LastVerified time.Time         `json:"lastVerified,omitempty"`
 }
 
 // Manager handles state persistence
@@ -142,3 +158,136 @@ func (s *State) ClearNextRepoSyncAllowed(repoName string) {
 	}
 	delete(s.NextRepoSyncAllowed, repoName)
 }
+
+// GetBackupRecord returns the backup record of a repo on a backup location
+func (s *State) GetBackupRecord(repoName, location string) BackupRecord {
+	if s == nil || s.Backups == nil {
+		return BackupRecord{}
+	}
+	return s.Backups[repoName][location]
+}
+
+// SetBackupVerified records a successful verification of a repo on a backup location
+func (s *State) SetBackupVerified(repoName, location string, verified time.Time) {
+	if s == nil {
+		return
+	}
+	record := s.ensureBackupRecord(repoName, location)
+	record.LastVerified = verified
+	s.Backups[repoName][location] = record
+}
+
+// SetBackupPush records a successful push of a repo to a backup location
+func (s *State) SetBackupPush(repoName, location string, pushed time.Time, refs map[string]string) {
+	if s == nil {
+		return
+	}
+	record := s.ensureBackupRecord(repoName, location)
+	record.LastPush = pushed
+	record.Refs = refs
+	s.Backups[repoName][location] = record
+}
+
+// SetRepoPrivate records whether a repo is private
+func (s *State) SetRepoPrivate(repoName string, private bool) {
+	if s == nil {
+		return
+	}
+	if s.PrivateRepos == nil {
+		s.PrivateRepos = make(map[string]bool)
+	}
+	s.PrivateRepos[repoName] = private
+}
+
+// RepoVisibilities returns a copy of the recorded visibilities, true if a
+// repo is private. Repos of unknown visibility are missing.
+func (s *State) RepoVisibilities() map[string]bool {
+	visibilities := make(map[string]bool)
+	if s == nil {
+		return visibilities
+	}
+	for name, private := range s.PrivateRepos {
+		visibilities[name] = private
+	}
+	return visibilities
+}
+
+// PrivateRepoNames returns the sorted names of all repos known to be private
+func (s *State) PrivateRepoNames() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.PrivateRepos))
+	for name, private := range s.PrivateRepos {
+		if private {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetRepoArchived records when a repo was archived
+func (s *State) SetRepoArchived(repoName string, archived time.Time) {
+	if s == nil {
+		return
+	}
+	if s.ArchivedRepos == nil {
+		s.ArchivedRepos = make(map[string]time.Time)
+	}
+	s.ArchivedRepos[repoName] = archived
+}
+
+// ClearRepoArchived removes the archived mark of a repo
+func (s *State) ClearRepoArchived(repoName string) {
+	if s == nil {
+		return
+	}
+	delete(s.ArchivedRepos, repoName)
+}
+
+// RepoArchived returns when a repo was archived, and whether it is
+func (s *State) RepoArchived(repoName string) (time.Time, bool) {
+	if s == nil {
+		return time.Time{}, false
+	}
+	archived, ok := s.ArchivedRepos[repoName]
+	return archived, ok
+}
+
+func (s *State) ensureBackupRecord(repoName, location string) BackupRecord {
+	if s.Backups == nil {
+		s.Backups = make(map[string]map[string]BackupRecord)
+	}
+	if s.Backups[repoName] == nil {
+		s.Backups[repoName] = make(map[string]BackupRecord)
+	}
+	return s.Backups[repoName][location]
+}
+
+// RenameRepo moves all per-repo records from oldName to newName
+func (s *State) RenameRepo(oldName, newName string) {
+	if s == nil || oldName == newName {
+		return
+	}
+	if t, ok := s.LastRepoSync[oldName]; ok {
+		s.LastRepoSync[newName] = t
+		delete(s.LastRepoSync, oldName)
+	}
+	if t, ok := s.NextRepoSyncAllowed[oldName]; ok {
+		s.NextRepoSyncAllowed[newName] = t
+		delete(s.NextRepoSyncAllowed, oldName)
+	}
+	if records, ok := s.Backups[oldName]; ok {
+		s.Backups[newName] = records
+		delete(s.Backups, oldName)
+	}
+	if private, ok := s.PrivateRepos[oldName]; ok {
+		s.PrivateRepos[newName] = private
+		delete(s.PrivateRepos, oldName)
+	}
+	if t, ok := s.ArchivedRepos[oldName]; ok {
+		s.ArchivedRepos[newName] = t
+		delete(s.ArchivedRepos, oldName)
+	}
+}
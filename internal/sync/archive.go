@@ -0,0 +1,81 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// BundleRepository fetches the latest state of a repository from all of its
+// remotes and writes a bundle of every branch, tag and remote-tracking
+// branch, so that it can be restored without any forge
+func (s *Syncer) BundleRepository(repoName, bundlePath string) error {
+	s.repoName = repoName
+	if err := os.MkdirAll(s.workDir, 0755); err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	if err := s.setupRepository(s.repoPath()); err != nil {
+		return fmt.Errorf("failed to setup repository: %w", err)
+	}
+	if err := s.fetchAll(); err != nil {
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(bundlePath), 0755); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	output, err := gitCommand(s.repoPath(), "bundle", "create", bundlePath, "--all").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w\n%s", err, string(output))
+	}
+	return nil
+}
+
+// ErrEmptyRepository is returned by BundleRemoteRepository for a repository
+// without any refs, which has nothing to bundle
+var ErrEmptyRepository = errors.New("repository is empty")
+
+// BundleRemoteRepository writes a bundle of every ref of a repository in an
+// organization, mirror-cloned into a temporary repository. Unlike
+// BundleRepository it does not use the work-dir clone, so it also covers
+// copies that are never fetched, such as native mirror targets.
+func BundleRemoteRepository(org *config.Organization, repoName, bundlePath string) error {
+	tmpDir, err := os.MkdirTemp("", "gitsyncer-bundle-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	url := repoURL(org, repoName)
+	mirrorPath := filepath.Join(tmpDir, repoName+".git")
+	if output, err := gitCommand("", "clone", "-q", "--mirror", url, mirrorPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w\n%s", url, err, string(output))
+	}
+	refs, err := gitCommand(mirrorPath, "for-each-ref", "--format=%(refname)").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list refs of %s: %w", url, err)
+	}
+	if strings.TrimSpace(string(refs)) == "" {
+		return ErrEmptyRepository
+	}
+
+	if err := os.MkdirAll(filepath.Dir(bundlePath), 0755); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	if output, err := gitCommand(mirrorPath, "bundle", "create", bundlePath, "--all").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create bundle: %w\n%s", err, string(output))
+	}
+	return nil
+}
+
+// CheckRemote verifies that a clone URL is reachable by listing its refs
+func CheckRemote(url string) error {
+	if output, err := gitCommand("", "ls-remote", "--heads", url).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s is not reachable: %w\n%s", url, err, string(output))
+	}
+	return nil
+}
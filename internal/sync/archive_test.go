@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestBundleRepository_IncludesAllRemotes(t *testing.T) {
+	primaryRoot := t.TempDir()
+	secondaryRoot := t.TempDir()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, work, "tag", "v1.0.0")
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(primaryRoot, "tool.git"))
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(secondaryRoot, "tool.git"))
+	// A branch only pushed to the second forge
+	runGitCmd(t, work, "checkout", "-q", "-b", "only-secondary")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "secondary")
+	runGitCmd(t, work, "push", "-q", filepath.Join(secondaryRoot, "tool.git"), "only-secondary")
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{
+			{Host: "file://" + primaryRoot},
+			{Host: "file://" + secondaryRoot},
+		},
+	}
+	bundlePath := filepath.Join(t.TempDir(), "archive", "tool.bundle")
+	if err := New(cfg, t.TempDir()).BundleRepository("tool", bundlePath); err != nil {
+		t.Fatalf("BundleRepository() error = %v", err)
+	}
+
+	output, err := gitCommand("", "bundle", "list-heads", bundlePath).Output()
+	if err != nil {
+		t.Fatalf("git bundle list-heads: %v", err)
+	}
+	heads := string(output)
+	for _, ref := range []string{"refs/tags/v1.0.0", "/only-secondary", "refs/heads/main"} {
+		if !strings.Contains(heads, ref) {
+			t.Errorf("bundle lacks %s:\n%s", ref, heads)
+		}
+	}
+}
+
+func TestBundleRemoteRepository(t *testing.T) {
+	t.Parallel()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, work, "tag", "v1.0.0")
+	orgDir := t.TempDir()
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(orgDir, "tool.git"))
+	runGitCmd(t, "", "init", "-q", "--bare", filepath.Join(orgDir, "empty.git"))
+	org := &config.Organization{Host: "file://" + orgDir}
+
+	bundlePath := filepath.Join(t.TempDir(), "deleted", "tool.bundle")
+	if err := BundleRemoteRepository(org, "tool", bundlePath); err != nil {
+		t.Fatalf("BundleRemoteRepository() error = %v", err)
+	}
+	output, err := gitCommand("", "bundle", "list-heads", bundlePath).Output()
+	if err != nil || !strings.Contains(string(output), "refs/tags/v1.0.0") || !strings.Contains(string(output), "refs/heads/main") {
+		t.Fatalf("bundle heads = %s, %v", output, err)
+	}
+
+	if err := BundleRemoteRepository(org, "empty", filepath.Join(t.TempDir(), "empty.bundle")); !errors.Is(err, ErrEmptyRepository) {
+		t.Fatalf("expected ErrEmptyRepository, got %v", err)
+	}
+	if err := CheckRemote(repoURL(org, "tool")); err != nil {
+		t.Fatalf("CheckRemote() error = %v", err)
+	}
+	if err := CheckRemote(repoURL(org, "missing")); err == nil {
+		t.Fatal("expected a missing repository to be unreachable")
+	}
+}
@@ -0,0 +1,106 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// ListBackupRepositories lists the repository names stored at a backup location
+func ListBackupRepositories(org *config.Organization) ([]string, error) {
+	var names []string
+
+	switch {
+	case org.IsS3():
+		repos, err := newS3BackupTarget(org).ListRepositories()
+		if err != nil {
+			return nil, err
+		}
+		names = repos
+	case strings.HasPrefix(org.Host, "file://"):
+		entries, err := os.ReadDir(strings.TrimPrefix(org.Host, "file://"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && strings.HasSuffix(entry.Name(), ".git") {
+				names = append(names, strings.TrimSuffix(entry.Name(), ".git"))
+			}
+		}
+	case org.IsSSH():
+		_, sshArgs, basePath, err := parseSSHLocation(org.Host)
+		if err != nil {
+			return nil, err
+		}
+		cmd := exec.Command("ssh", append(sshArgs, fmt.Sprintf("ls -1 %q", basePath))...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backup repositories: %w\n%s", err, string(output))
+		}
+		for _, line := range strings.Split(string(output), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasSuffix(line, ".git") {
+				names = append(names, strings.TrimSuffix(line, ".git"))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("listing repositories is not supported for %s", org.Host)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// RestoreRepository copies every branch and tag of repoName from a backup
+// location to the target organization. The target repository must already
+// exist unless the target is an SSH location, where a bare repo is created.
+func RestoreRepository(backupOrg, targetOrg *config.Organization, repoName string) error {
+	tmpDir, err := os.MkdirTemp("", "gitsyncer-restore-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	source := repoURL(backupOrg, repoName)
+	if backupOrg.IsS3() {
+		source = filepath.Join(tmpDir, repoName+".bundle")
+		fmt.Printf("  Downloading bundle from %s...\n", backupOrg.Host)
+		if _, err := newS3BackupTarget(backupOrg).DownloadBundle(repoName, source); err != nil {
+			return err
+		}
+	}
+
+	mirrorPath := filepath.Join(tmpDir, repoName+".git")
+	fmt.Printf("  Cloning %s...\n", source)
+	if output, err := gitCommand("", "clone", "--mirror", source, mirrorPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone backup: %w\n%s", err, string(output))
+	}
+
+	target := repoURL(targetOrg, repoName)
+	fmt.Printf("  Pushing branches and tags to %s...\n", target)
+	if err := pushAllRefs(mirrorPath, target); err != nil {
+		if !targetOrg.IsSSH() || !isRepositoryMissing(err.Error()) {
+			return err
+		}
+		if err := createSSHBareRepository(targetOrg.Host, repoName); err != nil {
+			return fmt.Errorf("failed to create SSH repository: %w", err)
+		}
+		return pushAllRefs(mirrorPath, target)
+	}
+
+	return nil
+}
+
+// pushAllRefs pushes all branches and tags of a bare repository to url
+func pushAllRefs(repoPath, url string) error {
+	output, err := gitCommand(repoPath, "push", url, "refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to push to %s: %w\n%s", url, err, string(output))
+	}
+	return nil
+}
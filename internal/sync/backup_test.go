@@ -2,6 +2,8 @@ package sync
 
 import (
 	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"codeberg.org/snonux/gitsyncer/internal/config"
@@ -61,3 +63,81 @@ func TestParseSSHLocation_SupportsSSHURLWithPort(t *testing.T) {
 		}
 	}
 }
+
+func TestRestoreRepository_FileBackupToFileTarget(t *testing.T) {
+	t.Parallel()
+
+	backupRoot := t.TempDir()
+	targetRoot := t.TempDir()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, work, "tag", "v1.0.0")
+	runGitCmd(t, work, "branch", "feature")
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(backupRoot, "sample.git"))
+	runGitCmd(t, "", "init", "-q", "--bare", filepath.Join(targetRoot, "sample.git"))
+
+	backupOrg := &config.Organization{Host: "file://" + backupRoot, BackupLocation: true}
+	targetOrg := &config.Organization{Host: "file://" + targetRoot}
+
+	names, err := ListBackupRepositories(backupOrg)
+	if err != nil {
+		t.Fatalf("ListBackupRepositories() error = %v", err)
+	}
+	if len(names) != 1 || names[0] != "sample" {
+		t.Fatalf("ListBackupRepositories() = %v, want [sample]", names)
+	}
+
+	if err := RestoreRepository(backupOrg, targetOrg, "sample"); err != nil {
+		t.Fatalf("RestoreRepository() error = %v", err)
+	}
+
+	output, err := gitCommand(filepath.Join(targetRoot, "sample.git"), "for-each-ref", "--format=%(refname)").Output()
+	if err != nil {
+		t.Fatalf("for-each-ref: %v", err)
+	}
+	for _, ref := range []string{"refs/heads/main", "refs/heads/feature", "refs/tags/v1.0.0"} {
+		if !strings.Contains(string(output), ref) {
+			t.Fatalf("restored refs %q missing %s", output, ref)
+		}
+	}
+}
+
+func runGitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := gitCommand(dir, append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func TestVerifyBackup_ClassifiesFileBackups(t *testing.T) {
+	t.Parallel()
+
+	backupRoot := t.TempDir()
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(backupRoot, "sample.git"))
+
+	org := &config.Organization{Host: "file://" + backupRoot, BackupLocation: true}
+
+	if check := VerifyBackup(org, "sample", work, true); check.Health != BackupOK {
+		t.Fatalf("VerifyBackup() health = %s (%v), want ok", check.Health, check.Err)
+	}
+
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "second")
+	check := VerifyBackup(org, "sample", work, false)
+	if check.Health != BackupStale {
+		t.Fatalf("VerifyBackup() health = %s (%v), want stale", check.Health, check.Err)
+	}
+	if len(check.Outdated) != 1 || check.Outdated[0] != "refs/heads/main" {
+		t.Fatalf("Outdated = %v, want [refs/heads/main]", check.Outdated)
+	}
+
+	if check := VerifyBackup(org, "other", work, false); check.Health != BackupMissing {
+		t.Fatalf("VerifyBackup() health = %s (%v), want missing", check.Health, check.Err)
+	}
+}
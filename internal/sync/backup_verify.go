@@ -0,0 +1,146 @@
+package sync
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// BackupHealth classifies the state of a repository on a backup location
+type BackupHealth string
+
+const (
+	BackupOK      BackupHealth = "ok"
+	BackupStale   BackupHealth = "stale"
+	BackupMissing BackupHealth = "missing"
+	BackupError   BackupHealth = "error"
+)
+
+// BackupCheck is the verification result for one repository on one backup location
+type BackupCheck struct {
+	Repo     string
+	Location string
+	Health   BackupHealth
+	Outdated []string // Refs that are missing or point elsewhere on the backup
+	Err      error
+}
+
+// VerifyBackup compares the refs of a repository on a backup location with
+// the local branches and tags of the work-dir clone at repoPath. With fsck
+// enabled, git fsck --connectivity-only is run against the backup copy.
+func VerifyBackup(org *config.Organization, repoName, repoPath string, fsck bool) BackupCheck {
+	check := BackupCheck{Repo: repoName, Location: org.Host}
+
+	local, err := listLocalRefs(repoPath)
+	if err != nil {
+		check.Health, check.Err = BackupError, err
+		return check
+	}
+
+	remote, found, err := listBackupRefs(org, repoName)
+	if err != nil {
+		check.Health, check.Err = BackupError, err
+		return check
+	}
+	if !found {
+		check.Health = BackupMissing
+		return check
+	}
+
+	check.Outdated = outdatedRefs(local, remote)
+	if len(check.Outdated) > 0 {
+		check.Health = BackupStale
+		return check
+	}
+
+	if fsck {
+		if err := fsckBackup(org, repoName); err != nil {
+			check.Health, check.Err = BackupError, err
+			return check
+		}
+	}
+
+	check.Health = BackupOK
+	return check
+}
+
+// outdatedRefs returns the local refs that the backup lacks or has at another commit
+func outdatedRefs(local, remote map[string]string) []string {
+	var outdated []string
+	for ref, sha := range local {
+		if remote[ref] != sha {
+			outdated = append(outdated, ref)
+		}
+	}
+	sort.Strings(outdated)
+	return outdated
+}
+
+// listLocalRefs returns the branch and tag tips of a work-dir clone
+func listLocalRefs(repoPath string) (map[string]string, error) {
+	output, err := gitCommand(repoPath, "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local refs: %w", err)
+	}
+	return parseRefLines(string(output), " "), nil
+}
+
+// listBackupRefs returns the branch and tag tips stored on a backup location
+func listBackupRefs(org *config.Organization, repoName string) (map[string]string, bool, error) {
+	if org.IsS3() {
+		manifest, found, err := newS3BackupTarget(org).GetManifest(repoName)
+		if err != nil || !found {
+			return nil, found, err
+		}
+		return manifest.Refs, true, nil
+	}
+
+	output, err := gitCommand("", "ls-remote", "--heads", "--tags", repoURL(org, repoName)).CombinedOutput()
+	if err != nil {
+		if isRepositoryMissing(string(output)) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("ls-remote failed: %w\n%s", err, string(output))
+	}
+	return parseRefLines(string(output), "\t"), true, nil
+}
+
+// parseRefLines parses "<sha><sep><ref>" lines, skipping peeled tag entries
+func parseRefLines(output, sep string) map[string]string {
+	refs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		sha, ref, ok := strings.Cut(strings.TrimSpace(line), sep)
+		if !ok || strings.HasSuffix(ref, "^{}") {
+			continue
+		}
+		refs[strings.TrimSpace(ref)] = sha
+	}
+	return refs
+}
+
+// fsckBackup runs a connectivity check on the backup copy of a repository
+func fsckBackup(org *config.Organization, repoName string) error {
+	var cmd *exec.Cmd
+	switch {
+	case strings.HasPrefix(org.Host, "file://"):
+		cmd = gitCommand("", "-C", strings.TrimPrefix(repoURL(org, repoName), "file://"), "fsck", "--connectivity-only")
+	case org.IsSSH():
+		_, sshArgs, basePath, err := parseSSHLocation(org.Host)
+		if err != nil {
+			return err
+		}
+		fullRepoPath := strings.TrimRight(basePath, "/") + "/" + repoName + ".git"
+		cmd = exec.Command("ssh", append(sshArgs, fmt.Sprintf("git -C %q fsck --connectivity-only", fullRepoPath))...)
+	default:
+		// Object storage bundles are hash-checked on download instead
+		return nil
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("fsck failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
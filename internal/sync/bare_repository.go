@@ -0,0 +1,133 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// bareRepositoryPath returns where the bare repository of a plain git
+// location lives. sshArgs is nil for file:// locations, whose path is local.
+func bareRepositoryPath(org *config.Organization, repoName string) (sshArgs []string, repoPath string, err error) {
+	if strings.HasPrefix(org.Host, "file://") {
+		return nil, filepath.Join(strings.TrimPrefix(org.Host, "file://"), repoName+".git"), nil
+	}
+	if !org.IsSSH() {
+		return nil, "", fmt.Errorf("%s is not an SSH or file:// location", org.Host)
+	}
+	_, sshArgs, basePath, err := parseSSHLocation(org.Host)
+	if err != nil {
+		return nil, "", err
+	}
+	return sshArgs, strings.TrimRight(basePath, "/") + "/" + repoName + ".git", nil
+}
+
+// runSSHScript runs a shell script on an SSH location and returns its output
+func runSSHScript(sshArgs []string, script string) (string, error) {
+	output, err := exec.Command("ssh", append(sshArgs, script)...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w\n%s", err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// BackupRepositoryExists reports whether an SSH, file:// or S3 location holds
+// a copy of the repository
+func BackupRepositoryExists(org *config.Organization, repoName string) (bool, error) {
+	if org.IsS3() {
+		_, found, err := newS3BackupTarget(org).GetManifest(repoName)
+		return found, err
+	}
+	sshArgs, repoPath, err := bareRepositoryPath(org, repoName)
+	if err != nil {
+		return false, err
+	}
+	if sshArgs == nil {
+		_, err := os.Stat(repoPath)
+		return err == nil, nil
+	}
+	output, err := runSSHScript(sshArgs, fmt.Sprintf("if [ -d %q ]; then echo yes; else echo no; fi", repoPath))
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", repoPath, err)
+	}
+	return output == "yes", nil
+}
+
+// CreateBareRepository creates an empty bare repository on an SSH or file://
+// location unless it already exists
+func CreateBareRepository(org *config.Organization, repoName string) error {
+	sshArgs, repoPath, err := bareRepositoryPath(org, repoName)
+	if err != nil {
+		return err
+	}
+	if sshArgs == nil {
+		if _, err := os.Stat(repoPath); err == nil {
+			return nil
+		}
+		if output, err := gitCommand("", "init", "-q", "--bare", repoPath).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create %s: %w\n%s", repoPath, err, string(output))
+		}
+		return nil
+	}
+	if _, err := runSSHScript(sshArgs, fmt.Sprintf("[ -d %q ] || git init -q --bare %q", repoPath, repoPath)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", repoPath, err)
+	}
+	return nil
+}
+
+// DeleteBackupRepository removes the copy of a repository from an SSH,
+// file:// or S3 location. Deleting a missing repository is not an error.
+func DeleteBackupRepository(org *config.Organization, repoName string) error {
+	if org.IsS3() {
+		_, err := newS3BackupTarget(org).DeleteRepository(repoName)
+		return err
+	}
+	sshArgs, repoPath, err := bareRepositoryPath(org, repoName)
+	if err != nil {
+		return err
+	}
+	if sshArgs == nil {
+		return os.RemoveAll(repoPath)
+	}
+	if _, err := runSSHScript(sshArgs, fmt.Sprintf("if [ -d %q ]; then rm -rf %q; fi", repoPath, repoPath)); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", repoPath, err)
+	}
+	return nil
+}
+
+// RenameBackupRepository renames the bare repository on an SSH or file://
+// location. It reports false if the location has no repository under the old
+// name and fails if the new name is taken.
+func RenameBackupRepository(org *config.Organization, oldName, newName string) (bool, error) {
+	sshArgs, oldPath, err := bareRepositoryPath(org, oldName)
+	if err != nil {
+		return false, err
+	}
+	_, newPath, _ := bareRepositoryPath(org, newName)
+
+	if sshArgs == nil {
+		if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+			return false, nil
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			return false, fmt.Errorf("%s already exists", newPath)
+		}
+		if err := os.Rename(oldPath, newPath); err != nil {
+			return false, fmt.Errorf("failed to rename bare repository: %w", err)
+		}
+		return true, nil
+	}
+
+	// Prints "missing" if there is nothing to rename and refuses to overwrite
+	script := fmt.Sprintf("if [ ! -d %q ]; then echo missing; elif [ -e %q ]; then echo %q already exists >&2; exit 1; else mv %q %q; fi",
+		oldPath, newPath, newPath, oldPath, newPath)
+	output, err := runSSHScript(sshArgs, script)
+	if err != nil {
+		return false, fmt.Errorf("failed to rename bare repository: %w", err)
+	}
+	return output != "missing", nil
+}
@@ -0,0 +1,61 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestDeleteBackupRepository_FileLocation(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	org := &config.Organization{Host: "file://" + root, BackupLocation: true}
+	runGitCmd(t, "", "init", "-q", "--bare", filepath.Join(root, "tool.git"))
+	runGitCmd(t, "", "init", "-q", "--bare", filepath.Join(root, "other.git"))
+
+	if exists, err := BackupRepositoryExists(org, "tool"); err != nil || !exists {
+		t.Fatalf("BackupRepositoryExists() = %v, %v", exists, err)
+	}
+	if err := DeleteBackupRepository(org, "tool"); err != nil {
+		t.Fatalf("DeleteBackupRepository() error = %v", err)
+	}
+	if exists, _ := BackupRepositoryExists(org, "tool"); exists {
+		t.Fatal("expected tool.git to be deleted")
+	}
+	if exists, _ := BackupRepositoryExists(org, "other"); !exists {
+		t.Fatal("expected other.git to be kept")
+	}
+	if err := DeleteBackupRepository(org, "tool"); err != nil {
+		t.Fatalf("deleting a missing repository: %v", err)
+	}
+}
+
+func TestBareRepositoryPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		host     string
+		wantSSH  []string
+		wantPath string
+	}{
+		{host: "file:///srv/git", wantPath: "/srv/git/tool.git"},
+		{host: "backup@nas:git/", wantSSH: []string{"backup@nas"}, wantPath: "git/tool.git"},
+		{host: "ssh://backup@nas:2222/srv/git", wantSSH: []string{"-p", "2222", "backup@nas"}, wantPath: "/srv/git/tool.git"},
+	}
+	for _, tt := range tests {
+		sshArgs, repoPath, err := bareRepositoryPath(&config.Organization{Host: tt.host, BackupLocation: true}, "tool")
+		if err != nil {
+			t.Fatalf("bareRepositoryPath(%s) error = %v", tt.host, err)
+		}
+		if repoPath != tt.wantPath || len(sshArgs) != len(tt.wantSSH) {
+			t.Fatalf("bareRepositoryPath(%s) = %v, %q", tt.host, sshArgs, repoPath)
+		}
+		for i := range sshArgs {
+			if sshArgs[i] != tt.wantSSH[i] {
+				t.Fatalf("bareRepositoryPath(%s) ssh args = %v, want %v", tt.host, sshArgs, tt.wantSSH)
+			}
+		}
+	}
+}
@@ -94,8 +94,11 @@ func (s *Syncer) analyzeAbandonedBranches() (*AbandonedBranchReport, error) {
 	excludedBranches := s.branchFilter.GetExcludedBranches(allBranches)
 	report.TotalIgnoredBranches = len(excludedBranches)
 
-	// Check main/master branch status
-	mainBranch := s.findMainBranch(branches)
+	// Check default branch status
+	mainBranch := s.defaultBranch
+	if mainBranch == "" {
+		mainBranch = findMainBranch(branches)
+	}
 	if mainBranch != "" {
 		mainInfo, err := s.getBranchInfo(mainBranch)
 		if err == nil {
@@ -114,8 +117,8 @@ func (s *Syncer) analyzeAbandonedBranches() (*AbandonedBranchReport, error) {
 	sixMonthsAgo := time.Now().AddDate(0, -6, 0)
 
 	for _, branch := range branches {
-		// Skip main/master branches
-		if branch == "main" || branch == "master" {
+		// Skip the default branch
+		if branch == mainBranch {
 			continue
 		}
 
@@ -135,8 +138,8 @@ func (s *Syncer) analyzeAbandonedBranches() (*AbandonedBranchReport, error) {
 
 	// Also analyze ignored branches for abandonment
 	for _, branch := range excludedBranches {
-		// Skip main/master branches even if they match exclusion patterns
-		if branch == "main" || branch == "master" {
+		// Skip the default branch even if it matches exclusion patterns
+		if branch == mainBranch {
 			continue
 		}
 
@@ -157,16 +160,6 @@ func (s *Syncer) analyzeAbandonedBranches() (*AbandonedBranchReport, error) {
 	return filterProtectedAbandonedBranchReport(s.repoName, report), nil
 }
 
-// findMainBranch finds the main or master branch
-func (s *Syncer) findMainBranch(branches []string) string {
-	for _, branch := range branches {
-		if branch == "main" || branch == "master" {
-			return branch
-		}
-	}
-	return ""
-}
-
 // getBranchInfo gets information about a specific branch
 func (s *Syncer) getBranchInfo(branch string) (*BranchInfo, error) {
 	info := &BranchInfo{
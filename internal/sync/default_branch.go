@@ -0,0 +1,66 @@
+package sync
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBranch returns the default branch detected by the last SyncRepository call
+func (s *Syncer) DefaultBranch() string {
+	return s.defaultBranch
+}
+
+// detectDefaultBranch determines the default branch of the repository. It reads
+// the HEAD symref of every non-backup remote in organization order; the first
+// remote reporting one wins, as the first organization is the primary. If no
+// remote reports a HEAD, main or master is used when present.
+func (s *Syncer) detectDefaultBranch(branches []string) string {
+	for i := range s.config.Organizations {
+		org := &s.config.Organizations[i]
+		if org.BackupLocation || org.IsS3() || s.nativeMirror(org) {
+			continue
+		}
+
+		remoteName := s.getRemoteName(org)
+		branch, err := remoteHeadBranch(s.repoPath(), remoteName)
+		// A HEAD pointing to a branch that was never pushed is ignored
+		if err != nil || branch == "" || !s.remoteBranchExists(remoteName, branch) {
+			continue
+		}
+		return branch
+	}
+
+	return findMainBranch(branches)
+}
+
+// remoteHeadBranch asks a remote which branch its HEAD points to
+func remoteHeadBranch(repoPath, remoteName string) (string, error) {
+	output, err := gitCommand(repoPath, "ls-remote", "--symref", remoteName, "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD of %s: %w", remoteName, err)
+	}
+	return parseSymrefHead(string(output)), nil
+}
+
+// parseSymrefHead extracts the branch from git ls-remote --symref output,
+// e.g. "ref: refs/heads/main\tHEAD"
+func parseSymrefHead(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != "ref:" || fields[2] != "HEAD" {
+			continue
+		}
+		return strings.TrimPrefix(fields[1], "refs/heads/")
+	}
+	return ""
+}
+
+// findMainBranch finds the main or master branch
+func findMainBranch(branches []string) string {
+	for _, branch := range branches {
+		if branch == "main" || branch == "master" {
+			return branch
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestParseSymrefHead(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"ref: refs/heads/trunk\tHEAD\n0123456789abcdef\tHEAD\n": "trunk",
+		"0123456789abcdef\tHEAD\n":                              "",
+		"":                                                      "",
+	}
+	for output, want := range tests {
+		if got := parseSymrefHead(output); got != want {
+			t.Errorf("parseSymrefHead(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
+
+func TestSyncRepository_DetectsDefaultBranchOfPrimary(t *testing.T) {
+	primaryRoot := t.TempDir()
+	secondaryRoot := t.TempDir()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "trunk")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, work, "branch", "main")
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(primaryRoot, "sample.git"))
+	runGitCmd(t, "", "clone", "-q", "--bare", "--branch", "main", work, filepath.Join(secondaryRoot, "sample.git"))
+
+	cfg := &config.Config{Organizations: []config.Organization{
+		{Host: "file://" + primaryRoot},
+		{Host: "file://" + secondaryRoot},
+	}}
+	syncer := New(cfg, t.TempDir())
+	if err := syncer.SyncRepository("sample"); err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+	if got := syncer.DefaultBranch(); got != "trunk" {
+		t.Fatalf("DefaultBranch() = %q, want trunk", got)
+	}
+}
@@ -0,0 +1,40 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+// RootCommits returns the root commits reachable from any ref of a local
+// repository. They identify a repository independently of its name.
+func RootCommits(repoPath string) ([]string, error) {
+	output, err := gitCommand(repoPath, "rev-list", "--max-parents=0", "--all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list root commits of %s: %w", repoPath, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// RemoteRootCommits returns the root commits of a repository in an
+// organization. Only commits and trees of its branches are fetched, into a
+// temporary repository that is removed afterwards.
+func RemoteRootCommits(org *config.Organization, repoName string) ([]string, error) {
+	tmpDir, err := os.MkdirTemp("", "gitsyncer-identity-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if output, err := gitCommand(tmpDir, "init", "-q", "--bare").CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git init failed: %w\n%s", err, string(output))
+	}
+	url := repoURL(org, repoName)
+	cmd := gitCommand(tmpDir, "fetch", "-q", "--filter=blob:none", url, "+refs/heads/*:refs/heads/*")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w\n%s", url, err, string(output))
+	}
+	return RootCommits(tmpDir)
+}
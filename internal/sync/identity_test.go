@@ -0,0 +1,34 @@
+package sync
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestRemoteRootCommits_MatchesRenamedRepository(t *testing.T) {
+	t.Parallel()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "second")
+	root := revParse(t, work, "HEAD~1")
+
+	orgDir := t.TempDir()
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(orgDir, "renamed.git"))
+
+	local, err := RootCommits(work)
+	if err != nil || !reflect.DeepEqual(local, []string{root}) {
+		t.Fatalf("RootCommits() = %v, %v, want [%s]", local, err, root)
+	}
+	remote, err := RemoteRootCommits(&config.Organization{Host: "file://" + orgDir}, "renamed")
+	if err != nil || !reflect.DeepEqual(remote, local) {
+		t.Fatalf("RemoteRootCommits() = %v, %v, want %v", remote, err, local)
+	}
+	if _, err := RemoteRootCommits(&config.Organization{Host: "file://" + orgDir}, "missing"); err == nil {
+		t.Fatal("expected an error for a missing repository")
+	}
+}
@@ -0,0 +1,55 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// ImportRepository copies a repository from any clone URL to the configured
+// organizations and sets up its work-dir clone. All branches and tags are
+// pushed to every forge, which must already have the repository, and to SSH
+// and file:// locations, where a bare repository is created if needed.
+// Backup locations are only included while backup sync is active; S3 backups
+// get their bundle on the next sync.
+func (s *Syncer) ImportRepository(sourceURL, repoName string) error {
+	s.repoName = repoName
+	tmpDir, err := os.MkdirTemp("", "gitsyncer-import-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	mirrorPath := filepath.Join(tmpDir, repoName+".git")
+	fmt.Printf("  Cloning %s...\n", sourceURL)
+	if output, err := gitCommand("", "clone", "-q", "--mirror", sourceURL, mirrorPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w\n%s", sourceURL, err, string(output))
+	}
+
+	for i := range s.config.Organizations {
+		org := &s.config.Organizations[i]
+		if (org.BackupLocation && !s.backupActive()) || org.IsS3() || s.nativeMirror(org) {
+			continue
+		}
+		if forge.TypeOf(org) == "" {
+			if err := CreateBareRepository(org, repoName); err != nil {
+				return err
+			}
+		}
+		target := repoURL(org, repoName)
+		fmt.Printf("  Pushing branches and tags to %s...\n", target)
+		if err := pushAllRefs(mirrorPath, target); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(s.workDir, 0755); err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	if err := s.setupRepository(s.repoPath()); err != nil {
+		return fmt.Errorf("failed to setup repository: %w", err)
+	}
+	return nil
+}
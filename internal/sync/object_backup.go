@@ -0,0 +1,49 @@
+package sync
+
+import (
+	"fmt"
+	"time"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/s3backup"
+)
+
+// newS3BackupTarget builds an object storage backup target for an s3:// organization
+func newS3BackupTarget(org *config.Organization) *s3backup.Target {
+	bucket, prefix := org.S3Location()
+	client := s3backup.NewClient(org.S3Endpoint, org.S3Region, bucket)
+	return s3backup.NewTarget(client, prefix)
+}
+
+// syncObjectStorageBackups uploads a bundle of the current repository to every
+// configured S3 backup location. Failures only disable backups for the session.
+func (s *Syncer) syncObjectStorageBackups() {
+	for i := range s.config.Organizations {
+		org := &s.config.Organizations[i]
+		if !org.BackupLocation || !org.IsS3() || !s.backupActive() {
+			continue
+		}
+
+		fmt.Printf("Uploading bundle to %s...\n", org.Host)
+		result, err := newS3BackupTarget(org).BackupRepository(s.repoPath(), s.repoName)
+		if err != nil {
+			s.disableBackupForSession(org.Host, err)
+			continue
+		}
+
+		s.backupPushes = append(s.backupPushes, BackupPush{Location: org.Host, Refs: result.Manifest.Refs, PushedAt: time.Now()})
+
+		if result.Uploaded {
+			fmt.Printf("  Uploaded %s (%d bytes, %d refs)\n", result.Manifest.BundleKey, result.Manifest.BundleSize, len(result.Manifest.Refs))
+		} else {
+			fmt.Printf("  Bundle unchanged (sha256 %s), skipping upload\n", shortHash(result.Manifest.BundleSHA256))
+		}
+	}
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
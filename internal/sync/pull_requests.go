@@ -0,0 +1,184 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+// pullRefsNamespace holds the fetched pull request heads in the work-dir
+// clone. They live outside refs/heads and refs/remotes, so they are never
+// treated as branches.
+const pullRefsNamespace = "refs/gitsyncer/pulls"
+
+// syncPullRequestRefs pushes the heads of open pull requests on every GitHub
+// and Codeberg/Gitea remote to the other remotes as <prefix>/<forge>/<number>
+// branches, and deletes the branches of pull requests that were merged or
+// closed since. Failures only print warnings, as the git data is already
+// synchronized at this point.
+func (s *Syncer) syncPullRequestRefs(remotes map[string]*config.Organization) {
+	if s.config.PullRequestMirror == nil {
+		return
+	}
+
+	remoteNames := make([]string, 0, len(remotes))
+	for remoteName := range remotes {
+		remoteNames = append(remoteNames, remoteName)
+	}
+	sort.Strings(remoteNames)
+
+	for _, sourceRemote := range remoteNames {
+		org := remotes[sourceRemote]
+		if org.BackupLocation {
+			continue
+		}
+		f, err := forge.New(org)
+		if err != nil {
+			continue
+		}
+		source, ok := f.(forge.PullRequestLister)
+		if !ok {
+			continue
+		}
+
+		open, err := s.openPullRequests(source, s.repoName)
+		if err != nil {
+			fmt.Printf("Warning: Failed to list %s pull requests, not mirroring them: %v\n", source.DisplayName(), err)
+			continue
+		}
+		heads, err := s.fetchPullRequestHeads(sourceRemote, open)
+		if err != nil {
+			fmt.Printf("Warning: Failed to fetch %s pull requests: %v\n", source.DisplayName(), err)
+			continue
+		}
+
+		namespace := s.config.PullRequestMirror.BranchPrefix() + "/" + source.Type()
+		fmt.Printf("\nMirroring %d open %s pull requests as %s/*\n", len(open), source.DisplayName(), namespace)
+		for _, targetRemote := range remoteNames {
+			if targetRemote == sourceRemote {
+				continue
+			}
+			err := s.pushPullRequestBranches(targetRemote, namespace, heads)
+			if err = s.handlePushError(targetRemote, remotes[targetRemote], err); err != nil {
+				fmt.Printf("Warning: Failed to mirror pull requests to %s: %v\n", targetRemote, err)
+			}
+		}
+	}
+}
+
+// fetchPullRequestHeads fetches refs/pull/<number>/head of the given pull
+// requests into pullRefsNamespace, drops the fetched heads of pull requests
+// that are no longer open and returns the commit of every open one
+func (s *Syncer) fetchPullRequestHeads(remote string, open []int) (map[int]string, error) {
+	localPrefix := fmt.Sprintf("%s/%s/", pullRefsNamespace, remote)
+
+	if len(open) > 0 {
+		args := []string{"fetch", "--no-tags", remote}
+		for _, number := range open {
+			args = append(args, fmt.Sprintf("+refs/pull/%d/head:%s%d", number, localPrefix, number))
+		}
+		if output, err := gitCommand(s.repoPath(), args...).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("%w\n%s", err, string(output))
+		}
+	}
+
+	fetched, err := listRefs(s.repoPath(), localPrefix)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(map[int]string, len(open))
+	for _, number := range open {
+		heads[number] = fetched[number]
+	}
+	for number := range fetched {
+		if _, ok := heads[number]; !ok {
+			_ = gitCommand(s.repoPath(), "update-ref", "-d", localPrefix+strconv.Itoa(number)).Run()
+		}
+	}
+	return heads, nil
+}
+
+// pushPullRequestBranches makes the <namespace>/<number> branches of a remote
+// match the given pull request heads in a single push
+func (s *Syncer) pushPullRequestBranches(remote, namespace string, heads map[int]string) error {
+	current, err := listRefs(s.repoPath(), fmt.Sprintf("refs/remotes/%s/%s/", remote, namespace))
+	if err != nil {
+		return err
+	}
+
+	var refspecs []string
+	var updated, deleted int
+	for _, number := range sortedNumbers(heads) {
+		if current[number] == heads[number] {
+			continue
+		}
+		refspecs = append(refspecs, fmt.Sprintf("%s:refs/heads/%s/%d", heads[number], namespace, number))
+		updated++
+	}
+	for _, number := range sortedNumbers(current) {
+		if _, open := heads[number]; !open {
+			refspecs = append(refspecs, fmt.Sprintf(":refs/heads/%s/%d", namespace, number))
+			deleted++
+		}
+	}
+	if len(refspecs) == 0 {
+		return nil
+	}
+
+	args := append([]string{"push", "--force", remote}, refspecs...)
+	if output, err := gitCommand(s.repoPath(), args...).CombinedOutput(); err != nil {
+		if isRepositoryMissing(string(output)) {
+			return nil
+		}
+		return fmt.Errorf("%w\n%s", err, string(output))
+	}
+	fmt.Printf("  %s: %d pull request branches updated, %d closed ones deleted\n", remote, updated, deleted)
+	return nil
+}
+
+// isPullRequestBranch reports whether a branch is a mirrored pull request
+// head, which is managed by syncPullRequestRefs instead of the branch sync
+func (s *Syncer) isPullRequestBranch(branch string) bool {
+	if s.config.PullRequestMirror == nil {
+		return false
+	}
+	rest, ok := strings.CutPrefix(branch, s.config.PullRequestMirror.BranchPrefix()+"/")
+	if !ok {
+		return false
+	}
+	forgeType, number, ok := strings.Cut(rest, "/")
+	_, err := strconv.Atoi(number)
+	return ok && forgeType != "" && err == nil
+}
+
+// listRefs returns the commits of the refs <prefix><number>
+func listRefs(repoPath, prefix string) (map[int]string, error) {
+	output, err := gitCommand(repoPath, "for-each-ref", "--format=%(objectname) %(refname)", prefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+	refs := make(map[int]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		commit, ref, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if number, err := strconv.Atoi(strings.TrimPrefix(ref, prefix)); err == nil {
+			refs[number] = commit
+		}
+	}
+	return refs, nil
+}
+
+func sortedNumbers(refs map[int]string) []int {
+	numbers := make([]int, 0, len(refs))
+	for number := range refs {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
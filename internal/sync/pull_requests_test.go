@@ -0,0 +1,105 @@
+package sync
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
+)
+
+func revParse(t *testing.T, dir, ref string) string {
+	t.Helper()
+
+	output, err := gitCommand(dir, "rev-parse", "--verify", "--quiet", ref).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestSyncRepository_MirrorsOpenPullRequestHeads(t *testing.T) {
+	githubRoot := t.TempDir()
+	targetRoot := t.TempDir()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	for _, branch := range []string{"feature", "closed"} {
+		runGitCmd(t, work, "checkout", "-q", "-b", branch, "main")
+		runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", branch)
+	}
+	runGitCmd(t, work, "checkout", "-q", "main")
+
+	githubRepo := filepath.Join(githubRoot, "tool.git")
+	targetRepo := filepath.Join(targetRoot, "tool.git")
+	runGitCmd(t, "", "clone", "-q", "--bare", "--single-branch", "--branch", "main", work, githubRepo)
+	runGitCmd(t, "", "clone", "-q", "--bare", "--single-branch", "--branch", "main", work, targetRepo)
+	runGitCmd(t, githubRepo, "fetch", "-q", work, "refs/heads/feature:refs/pull/1/head", "refs/heads/closed:refs/pull/2/head")
+	// A branch left over from a pull request that was closed since
+	runGitCmd(t, targetRepo, "branch", "pr/github/7", "main")
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{
+			{Host: "file://" + githubRoot, Type: config.TypeGitHub},
+			{Host: "file://" + targetRoot},
+		},
+		PullRequestMirror: &config.PullRequestMirror{},
+	}
+	syncer := New(cfg, t.TempDir())
+	open := []int{1}
+	syncer.openPullRequests = func(f forge.PullRequestLister, repoName string) ([]int, error) {
+		if f.Type() != forge.TypeGitHub || repoName != "tool" {
+			t.Fatalf("unexpected pull request lookup for %s on %s", repoName, f.Type())
+		}
+		return open, nil
+	}
+
+	if err := syncer.SyncRepository("tool"); err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+	if got, want := revParse(t, targetRepo, "refs/heads/pr/github/1"), revParse(t, work, "feature"); got != want {
+		t.Fatalf("pr/github/1 = %q, want %q", got, want)
+	}
+	for _, ref := range []string{"refs/heads/pr/github/2", "refs/heads/pr/github/7"} {
+		if revParse(t, targetRepo, ref) != "" {
+			t.Fatalf("expected %s to be absent on the target", ref)
+		}
+	}
+	if revParse(t, githubRepo, "refs/heads/pr/github/7") != "" {
+		t.Fatal("mirrored pull request branches must not be synced as regular branches")
+	}
+	for _, branch := range syncer.abandonedReports["tool"].AbandonedBranches {
+		if strings.HasPrefix(branch.Name, "pr/") {
+			t.Fatalf("pull request branch %s in the abandoned-branch report", branch.Name)
+		}
+	}
+
+	// Once the pull request is merged or closed its branch goes away
+	open = nil
+	if err := syncer.SyncRepository("tool"); err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+	if revParse(t, targetRepo, "refs/heads/pr/github/1") != "" {
+		t.Fatal("expected pr/github/1 to be deleted after the pull request closed")
+	}
+}
+
+func TestIsPullRequestBranch(t *testing.T) {
+	t.Parallel()
+
+	syncer := New(&config.Config{PullRequestMirror: &config.PullRequestMirror{}}, t.TempDir())
+	for branch, want := range map[string]bool{
+		"pr/github/12":   true,
+		"pr/codeberg/3":  true,
+		"pr/github/main": false,
+		"pr/12":          false,
+		"prs/github/12":  false,
+		"main":           false,
+	} {
+		if got := syncer.isPullRequestBranch(branch); got != want {
+			t.Errorf("isPullRequestBranch(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RenameLocalRepository moves the work-dir clone of a repository to its new
+// name and points every remote of a configured organization at the renamed
+// repository. Does nothing if there is no clone.
+func (s *Syncer) RenameLocalRepository(oldName, newName string) error {
+	oldPath := filepath.Join(s.workDir, oldName)
+	newPath := filepath.Join(s.workDir, newName)
+	if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("%s already exists", newPath)
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("failed to move %s: %w", oldPath, err)
+	}
+
+	s.repoName = newName
+	for i := range s.config.Organizations {
+		org := &s.config.Organizations[i]
+		remoteName := s.getRemoteName(org)
+		if _, err := getRemoteURL(newPath, remoteName); err != nil {
+			continue // not a remote of this clone, e.g. an S3 backup
+		}
+		if output, err := gitCommand(newPath, "remote", "set-url", remoteName, repoURL(org, newName)).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to update remote %s: %w\n%s", remoteName, err, string(output))
+		}
+	}
+	return nil
+}
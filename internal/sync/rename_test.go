@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestRenameRepository_MovesCloneAndBareRepositories(t *testing.T) {
+	primaryRoot := t.TempDir()
+	backupRoot := t.TempDir()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGitCmd(t, "", "clone", "-q", "--bare", work, filepath.Join(primaryRoot, "tool.git"))
+	runGitCmd(t, "", "init", "-q", "--bare", filepath.Join(backupRoot, "tool.git"))
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{
+			{Host: "file://" + primaryRoot},
+			{Host: "file://" + backupRoot, BackupLocation: true},
+		},
+	}
+	workDir := t.TempDir()
+	syncer := New(cfg, workDir)
+	syncer.SetBackupEnabled(true)
+	if err := syncer.SyncRepository("tool"); err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+
+	for i := range cfg.Organizations {
+		renamed, err := RenameBackupRepository(&cfg.Organizations[i], "tool", "gadget")
+		if err != nil || !renamed {
+			t.Fatalf("RenameBackupRepository(%s) = %v, %v", cfg.Organizations[i].Host, renamed, err)
+		}
+	}
+	if err := New(cfg, workDir).RenameLocalRepository("tool", "gadget"); err != nil {
+		t.Fatalf("RenameLocalRepository() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(workDir, "tool")); !os.IsNotExist(err) {
+		t.Fatalf("old clone still exists: %v", err)
+	}
+	clone := filepath.Join(workDir, "gadget")
+	for i := range cfg.Organizations {
+		org := &cfg.Organizations[i]
+		got, err := getRemoteURL(clone, syncer.getRemoteName(org))
+		if err != nil {
+			t.Fatalf("getRemoteURL() error = %v", err)
+		}
+		if want := repoURL(org, "gadget"); got != want {
+			t.Fatalf("remote URL = %q, want %q", got, want)
+		}
+	}
+
+	// The renamed clone keeps syncing without a fresh clone
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "second")
+	runGitCmd(t, work, "push", "-q", filepath.Join(primaryRoot, "gadget.git"), "main")
+	syncer = New(cfg, workDir)
+	syncer.SetBackupEnabled(true)
+	if err := syncer.SyncRepository("gadget"); err != nil {
+		t.Fatalf("SyncRepository() after rename error = %v", err)
+	}
+	if got, want := revParse(t, filepath.Join(backupRoot, "gadget.git"), "main"), revParse(t, work, "main"); got != want {
+		t.Fatalf("backup main = %q, want %q", got, want)
+	}
+}
+
+func TestRenameBackupRepository_MissingAndConflicting(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	org := &config.Organization{Host: "file://" + root, BackupLocation: true}
+	if renamed, err := RenameBackupRepository(org, "tool", "gadget"); err != nil || renamed {
+		t.Fatalf("RenameBackupRepository() without repository = %v, %v", renamed, err)
+	}
+
+	for _, name := range []string{"tool.git", "gadget.git"} {
+		runGitCmd(t, "", "init", "-q", "--bare", filepath.Join(root, name))
+	}
+	if _, err := RenameBackupRepository(org, "tool", "gadget"); err == nil {
+		t.Fatal("expected an error when the new name is taken")
+	}
+	if _, err := os.Stat(filepath.Join(root, "tool.git")); err != nil {
+		t.Fatalf("the old repository must be left in place: %v", err)
+	}
+}
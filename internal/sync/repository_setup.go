@@ -26,7 +26,7 @@ func (s *Syncer) setupNewRepository(repoPath string) error {
 	var firstOrg *config.Organization
 	var firstOrgIndex int
 	for i := range s.config.Organizations {
-		if !s.config.Organizations[i].BackupLocation {
+		if !s.config.Organizations[i].BackupLocation && !s.nativeMirror(&s.config.Organizations[i]) {
 			firstOrg = &s.config.Organizations[i]
 			firstOrgIndex = i
 			break
@@ -59,6 +59,14 @@ func (s *Syncer) setupNewRepository(repoPath string) error {
 		if org.BackupLocation && !s.backupActive() {
 			continue
 		}
+		// Object storage backups are uploaded as bundles, not pushed as remotes.
+		if org.IsS3() {
+			continue
+		}
+		// Native mirrors pull on their own
+		if s.nativeMirror(org) {
+			continue
+		}
 
 		if err := s.addRemote(repoPath, org); err != nil {
 			return fmt.Errorf("failed to add remote %s: %w", s.getRemoteName(org), err)
@@ -80,6 +88,9 @@ func (s *Syncer) setupExistingRepository(repoPath string) error {
 		if org.BackupLocation && !s.backupActive() {
 			continue
 		}
+		if org.IsS3() || s.nativeMirror(org) {
+			continue
+		}
 
 		remoteName := s.getRemoteName(org)
 
@@ -106,6 +117,9 @@ func (s *Syncer) getRemotesMap() map[string]*config.Organization {
 		if org.BackupLocation && !s.backupActive() {
 			continue
 		}
+		if org.IsS3() || s.nativeMirror(org) {
+			continue
+		}
 
 		remoteName := s.getRemoteName(org)
 		remotes[remoteName] = org
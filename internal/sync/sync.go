@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"strings"
 	stdsync "sync"
+	"time"
 
 	"codeberg.org/snonux/gitsyncer/internal/config"
+	"codeberg.org/snonux/gitsyncer/internal/forge"
 )
 
 type backupSessionState struct {
@@ -27,6 +29,17 @@ type Syncer struct {
 	abandonedReports map[string]*AbandonedBranchReport // Collects reports across repos
 	branchFilter     *BranchFilter                     // Filter for excluding branches
 	backupEnabled    bool                              // Whether to sync to backup locations
+	backupPushes     []BackupPush                      // Completed backup pushes of the last synced repo
+	defaultBranch    string                            // Default branch of the last synced repo
+	// openPullRequests lists the open pull requests of a repository on a forge
+	openPullRequests func(f forge.PullRequestLister, repoName string) ([]int, error)
+}
+
+// BackupPush records a completed push of a repository to a backup location
+type BackupPush struct {
+	Location string
+	Refs     map[string]string
+	PushedAt time.Time
 }
 
 // CLAUDE: Is there a reason, we return a pointer to Syncer?
@@ -46,6 +59,7 @@ func New(cfg *config.Config, workDir string) *Syncer {
 		abandonedReports: make(map[string]*AbandonedBranchReport),
 		branchFilter:     branchFilter,
 		backupEnabled:    false, // Default to false, will be set via SetBackupEnabled
+		openPullRequests: forge.PullRequestLister.ListOpenPullRequests,
 	}
 }
 
@@ -106,6 +120,8 @@ func resetBackupSessionState() {
 // SyncRepository synchronizes a repository across all configured organizations
 func (s *Syncer) SyncRepository(repoName string) error {
 	s.repoName = repoName
+	s.backupPushes = nil
+	s.defaultBranch = ""
 
 	// Create work directory if it doesn't exist
 	if err := os.MkdirAll(s.workDir, 0755); err != nil {
@@ -118,6 +134,12 @@ func (s *Syncer) SyncRepository(repoName string) error {
 		return err
 	}
 
+	for i := range s.config.Organizations {
+		if org := &s.config.Organizations[i]; s.nativeMirror(org) {
+			fmt.Printf("Skipping %s: it pull-mirrors %s on its own\n", org.Host, repoName)
+		}
+	}
+
 	// Fetch all remotes
 	fmt.Printf("Fetching updates from all remotes...\n")
 	if err := s.fetchAll(); err != nil {
@@ -139,6 +161,12 @@ func (s *Syncer) SyncRepository(repoName string) error {
 		fmt.Print(exclusionReport)
 	}
 
+	// Detect the default branch from the remotes' HEAD symrefs
+	s.defaultBranch = s.detectDefaultBranch(allBranches)
+	if s.defaultBranch != "" {
+		fmt.Printf("Default branch: %s\n", s.defaultBranch)
+	}
+
 	// Get remotes map
 	remotes := s.getRemotesMap()
 
@@ -147,6 +175,15 @@ func (s *Syncer) SyncRepository(repoName string) error {
 		return err
 	}
 
+	// Heads of open pull requests are pushed as namespaced branches
+	s.syncPullRequestRefs(remotes)
+
+	// Git backup remotes have received every branch if backup is still active
+	s.recordGitBackupPushes(remotes)
+
+	// Object storage backups are not git remotes and receive a bundle instead
+	s.syncObjectStorageBackups()
+
 	// Analyze abandoned branches
 	report, err := s.analyzeAbandonedBranches()
 	if err != nil {
@@ -165,6 +202,39 @@ func (s *Syncer) SyncRepository(repoName string) error {
 	return nil
 }
 
+// BackupPushes returns the backup pushes completed by the last SyncRepository call
+func (s *Syncer) BackupPushes() []BackupPush {
+	return s.backupPushes
+}
+
+// recordGitBackupPushes remembers the refs pushed to git backup remotes
+func (s *Syncer) recordGitBackupPushes(remotes map[string]*config.Organization) {
+	if !s.backupActive() {
+		return
+	}
+
+	var refs map[string]string
+	for _, org := range remotes {
+		if !org.BackupLocation {
+			continue
+		}
+		if refs == nil {
+			var err error
+			if refs, err = listLocalRefs(s.repoPath()); err != nil {
+				fmt.Printf("Warning: Failed to record backup refs: %v\n", err)
+				return
+			}
+		}
+		s.backupPushes = append(s.backupPushes, BackupPush{Location: org.Host, Refs: refs, PushedAt: time.Now()})
+	}
+}
+
+// nativeMirror reports whether an organization pull-mirrors the current
+// repository on its own; such remotes are neither fetched nor pushed
+func (s *Syncer) nativeMirror(org *config.Organization) bool {
+	return s.config.IsNativeMirror(org, s.repoName)
+}
+
 func (s *Syncer) repoPath() string {
 	return filepath.Join(s.workDir, s.repoName)
 }
@@ -219,18 +289,7 @@ func (s *Syncer) cloneRepository(org *config.Organization, repoPath string) erro
 		return fmt.Errorf("cannot clone from backup location %s", org.Host)
 	}
 
-	// For file:// URLs, we need special handling
-	var cloneURL string
-	if strings.HasPrefix(org.Host, "file://") {
-		// For local file paths, the format is: file:///path/to/repo.git
-		cloneURL = fmt.Sprintf("%s/%s.git", org.Host, s.repoName)
-	} else if org.IsSSH() && org.Name == "" {
-		// For SSH backup locations: user@host:path/repo.git
-		cloneURL = fmt.Sprintf("%s/%s.git", org.Host, s.repoName)
-	} else {
-		// For SSH URLs, the format is: git@host:org/repo.git
-		cloneURL = fmt.Sprintf("%s/%s.git", org.GetGitURL(), s.repoName)
-	}
+	cloneURL := repoURL(org, s.repoName)
 
 	fmt.Printf("Cloning from %s...\n", cloneURL)
 
@@ -245,20 +304,26 @@ func (s *Syncer) cloneRepository(org *config.Organization, repoPath string) erro
 	return nil
 }
 
-// addRemote adds a remote to the repository
-func (s *Syncer) addRemote(repoPath string, org *config.Organization) error {
-	remoteName := s.getRemoteName(org)
-
+// repoURL returns the git URL of a repository within an organization
+func repoURL(org *config.Organization, repoName string) string {
 	// For file:// URLs, we need special handling
-	var remoteURL string
 	if strings.HasPrefix(org.Host, "file://") {
-		remoteURL = fmt.Sprintf("%s/%s.git", org.Host, s.repoName)
-	} else if org.IsSSH() && org.Name == "" {
+		// For local file paths, the format is: file:///path/to/repo.git
+		return fmt.Sprintf("%s/%s.git", org.Host, repoName)
+	}
+	if org.IsSSH() && org.Name == "" {
 		// For SSH backup locations: user@host:path/repo.git
-		remoteURL = fmt.Sprintf("%s/%s.git", org.Host, s.repoName)
-	} else {
-		remoteURL = fmt.Sprintf("%s/%s.git", org.GetGitURL(), s.repoName)
+		return fmt.Sprintf("%s/%s.git", org.Host, repoName)
 	}
+	// For SSH URLs, the format is: git@host:org/repo.git
+	return fmt.Sprintf("%s/%s.git", org.GetGitURL(), repoName)
+}
+
+// addRemote adds a remote to the repository
+func (s *Syncer) addRemote(repoPath string, org *config.Organization) error {
+	remoteName := s.getRemoteName(org)
+
+	remoteURL := repoURL(org, s.repoName)
 
 	fmt.Printf("Adding remote %s: %s\n", remoteName, remoteURL)
 
@@ -290,6 +355,11 @@ func (s *Syncer) fetchAll() error {
 
 	// Fetch from each remote
 	for remote := range remotes {
+		// Native mirrors may lag behind their source and are never fetched
+		if org, exists := allOrgsMap[remote]; exists && s.nativeMirror(org) {
+			continue
+		}
+
 		// Check if this remote is a backup location
 		if org, exists := allOrgsMap[remote]; exists && org.BackupLocation {
 			if !s.backupActive() {
@@ -310,7 +380,8 @@ func (s *Syncer) fetchAll() error {
 	return nil
 }
 
-// getAllBranches gets all unique branches from all remotes
+// getAllBranches gets all unique branches from all remotes, without mirrored
+// pull request branches
 func (s *Syncer) getAllBranches() ([]string, error) {
 	cmd := gitCommand(s.repoPath(), "branch", "-r")
 	output, err := cmd.Output()
@@ -320,7 +391,13 @@ func (s *Syncer) getAllBranches() ([]string, error) {
 
 	// Backup remotes are push-only and must never influence branch discovery.
 	filteredOutput := s.filterBackupBranches(output)
-	return getAllUniqueBranches(filteredOutput), nil
+	var branches []string
+	for _, branch := range getAllUniqueBranches(filteredOutput) {
+		if !s.isPullRequestBranch(branch) {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
 }
 
 // syncBranch synchronizes a specific branch across all remotes
@@ -430,7 +507,8 @@ func (s *Syncer) getRemoteName(org *config.Organization) string {
 	return host
 }
 
-// filterBackupBranches filters out branches from backup locations
+// filterBackupBranches filters out branches from backup locations and
+// native mirrors
 func (s *Syncer) filterBackupBranches(output []byte) []byte {
 	lines := strings.Split(string(output), "\n")
 	var filtered []string
@@ -445,7 +523,7 @@ func (s *Syncer) filterBackupBranches(output []byte) []byte {
 		isBackup := false
 		for i := range s.config.Organizations {
 			org := &s.config.Organizations[i]
-			if org.BackupLocation {
+			if org.BackupLocation || s.nativeMirror(org) {
 				remoteName := s.getRemoteName(org)
 				if strings.HasPrefix(line, remoteName+"/") {
 					isBackup = true
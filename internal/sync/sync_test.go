@@ -0,0 +1,43 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"codeberg.org/snonux/gitsyncer/internal/config"
+)
+
+func TestSyncRepository_SkipsNativeMirrors(t *testing.T) {
+	githubRoot := t.TempDir()
+	mirrorRoot := t.TempDir()
+
+	work := t.TempDir()
+	runGitCmd(t, work, "init", "-q", "-b", "main")
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "initial")
+	githubRepo := filepath.Join(githubRoot, "tool.git")
+	mirrorRepo := filepath.Join(mirrorRoot, "tool.git")
+	runGitCmd(t, "", "clone", "-q", "--bare", work, githubRepo)
+	runGitCmd(t, "", "clone", "-q", "--bare", work, mirrorRepo)
+	// The mirror lags behind and still has a branch deleted on GitHub
+	runGitCmd(t, work, "commit", "-q", "--allow-empty", "-m", "second")
+	runGitCmd(t, work, "push", "-q", githubRepo, "main")
+	runGitCmd(t, mirrorRepo, "branch", "deleted-upstream", "main")
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{
+			{Host: "file://" + mirrorRoot, Type: config.TypeGitea},
+			{Host: "file://" + githubRoot, Type: config.TypeGitHub},
+		},
+		MirrorMode: map[string]string{"tool": config.MirrorModeNative},
+	}
+	if err := New(cfg, t.TempDir()).SyncRepository("tool"); err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+
+	if revParse(t, githubRepo, "refs/heads/deleted-upstream") != "" {
+		t.Fatal("branches of the native mirror must not be pushed to the source")
+	}
+	if got, want := revParse(t, mirrorRepo, "main"), revParse(t, work, "main~1"); got != want {
+		t.Fatalf("native mirror main = %q, want it untouched at %q", got, want)
+	}
+}